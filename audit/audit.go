@@ -0,0 +1,181 @@
+// Package audit registra a trilha de auditoria do serviço: quem (ator do JWT),
+// fez o quê (ação), em qual recurso, com quais alterações (diff entre o
+// estado anterior e o posterior) e em qual requisição (correlation id). As
+// entradas são gravadas em sinks plugáveis, como uma tabela do banco ou uma fila.
+//
+//	recorder := audit.New(audit.NewTable(database))
+//	audit.SetDefault(recorder)
+//
+//	server.N().Middlewares(auth.Middleware(verifier), audit.Middleware(recorder, audit.MiddlewareOptions{}))
+//
+//	err := audit.Record(ctx, audit.Entry{
+//		Action:     "limit.updated",
+//		Resource:   "account",
+//		ResourceID: account.ID,
+//		Before:     previous,
+//		After:      account,
+//	})
+package audit
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/auth"
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/idgen"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"github.com/nathanribeiroo/module-dep-projects/tenant"
+)
+
+// Resultados de uma ação auditada.
+const (
+	Success = "success"
+	Failure = "failure"
+)
+
+// Entry é um registro da trilha de auditoria.
+type Entry struct {
+	// ID identifica a entrada (padrão: UUIDv7).
+	ID string `json:"id"`
+	// Time é o instante da ação (padrão: agora).
+	Time time.Time `json:"time"`
+	// Actor é quem executou a ação (padrão: o subject do JWT da requisição).
+	Actor string `json:"actor,omitempty"`
+	// Tenant é o tenant da ação (padrão: o tenant do contexto).
+	Tenant string `json:"tenant,omitempty"`
+	// Action descreve a ação (ex.: "limit.updated" ou "PUT /accounts/:id").
+	Action string `json:"action"`
+	// Resource e ResourceID identificam o recurso afetado.
+	Resource   string `json:"resource,omitempty"`
+	ResourceID string `json:"resource_id,omitempty"`
+	// Outcome é Success ou Failure (padrão: Success).
+	Outcome string `json:"outcome"`
+	// Status é o status HTTP da resposta, quando registrada pelo middleware.
+	Status int `json:"status,omitempty"`
+	// Before e After são os estados do recurso antes e depois da ação; apenas
+	// a diferença entre eles é gravada, em Changes.
+	Before interface{} `json:"-"`
+	After  interface{} `json:"-"`
+	// Changes são os campos alterados, pelo caminho do campo (ex.: "limits.daily").
+	Changes map[string]Change `json:"changes,omitempty"`
+	// CorrelationID é o correlation id da requisição (padrão: o do contexto).
+	CorrelationID string `json:"correlation_id,omitempty"`
+	// IP e UserAgent identificam a origem, quando registrada pelo middleware.
+	IP        string `json:"ip,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+	// Metadata são dados adicionais da ação.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Sink grava as entradas de auditoria.
+type Sink interface {
+	Write(ctx context.Context, entries ...Entry) error
+}
+
+// SinkFunc adapta uma função à interface Sink.
+type SinkFunc func(ctx context.Context, entries ...Entry) error
+
+// Write implementa Sink.
+func (f SinkFunc) Write(ctx context.Context, entries ...Entry) error {
+	return f(ctx, entries...)
+}
+
+// Recorder completa as entradas com os dados do contexto e as grava nos sinks.
+type Recorder struct {
+	sinks []Sink
+}
+
+// New cria o Recorder; cada entrada é gravada em todos os sinks.
+func New(sinks ...Sink) *Recorder {
+	return &Recorder{sinks: sinks}
+}
+
+// Record completa a entrada (ID, horário, ator, tenant, correlation id e
+// alterações) e a grava nos sinks, devolvendo os erros de todos eles.
+func (r *Recorder) Record(ctx context.Context, entry Entry) error {
+	entry, err := complete(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, sink := range r.sinks {
+		if err := sink.Write(ctx, entry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		dd.Metrics().Incr("audit.failed", "action:"+entry.Action)
+		logx.Ctx(ctx).Error("Failed to record audit entry", "action", entry.Action, "resource", entry.Resource, "error", err)
+		return err
+	}
+
+	dd.Metrics().Incr("audit.recorded", "action:"+entry.Action)
+	return nil
+}
+
+// complete preenche os campos padrão da entrada.
+func complete(ctx context.Context, entry Entry) (Entry, error) {
+	if entry.ID == "" {
+		entry.ID = idgen.UUIDv7()
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	if entry.Outcome == "" {
+		entry.Outcome = Success
+	}
+	if entry.Actor == "" {
+		if claims, ok := auth.ClaimsFromContext(ctx); ok {
+			entry.Actor = claims.Subject()
+		}
+	}
+	if entry.Tenant == "" {
+		entry.Tenant = tenant.ID(ctx)
+	}
+	if entry.CorrelationID == "" {
+		entry.CorrelationID = logx.CorrelationID(ctx)
+	}
+
+	if entry.Before != nil || entry.After != nil {
+		changes, err := Diff(entry.Before, entry.After)
+		if err != nil {
+			return entry, err
+		}
+		if entry.Changes == nil {
+			entry.Changes = changes
+		} else {
+			for path, change := range changes {
+				entry.Changes[path] = change
+			}
+		}
+	}
+	return entry, nil
+}
+
+// recorder é o Recorder usado pelas funções do pacote.
+var recorder atomic.Pointer[Recorder]
+
+// SetDefault define o Recorder usado por Record.
+func SetDefault(r *Recorder) {
+	recorder.Store(r)
+}
+
+// Default devolve o Recorder padrão, ou nil se não configurado.
+func Default() *Recorder {
+	return recorder.Load()
+}
+
+// Record grava a entrada com o Recorder padrão. Sem Recorder configurado, a
+// entrada é descartada com um aviso no log.
+func Record(ctx context.Context, entry Entry) error {
+	r := Default()
+	if r == nil {
+		logx.Ctx(ctx).Warn("Audit recorder not configured, entry discarded", "action", entry.Action)
+		return nil
+	}
+	return r.Record(ctx, entry)
+}
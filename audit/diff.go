@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Change é a alteração de um campo.
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Diff compara as representações JSON de before e after e devolve os campos
+// alterados pelo caminho (ex.: "address.city"). Listas são comparadas por
+// inteiro; um lado nil representa a criação ou a remoção do recurso.
+func Diff(before interface{}, after interface{}) (map[string]Change, error) {
+	b, err := normalize(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := normalize(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]Change{}
+	diff("", b, a, changes)
+	return changes, nil
+}
+
+// normalize converte o valor na sua representação JSON genérica.
+func normalize(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("audit: diff: %w", err)
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("audit: diff: %w", err)
+	}
+	return out, nil
+}
+
+// diff percorre os objetos recursivamente acumulando as diferenças em changes.
+func diff(path string, before interface{}, after interface{}, changes map[string]Change) {
+	b, bIsObject := before.(map[string]interface{})
+	a, aIsObject := after.(map[string]interface{})
+	if (bIsObject || before == nil) && (aIsObject || after == nil) && (bIsObject || aIsObject) {
+		for key, value := range b {
+			diff(join(path, key), value, a[key], changes)
+		}
+		for key, value := range a {
+			if _, ok := b[key]; !ok {
+				diff(join(path, key), nil, value, changes)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		changes[path] = Change{Before: before, After: after}
+	}
+}
+
+// join monta o caminho do campo.
+func join(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MiddlewareOptions configura o middleware de auditoria.
+type MiddlewareOptions struct {
+	// Methods são os métodos auditados (padrão: POST, PUT, PATCH e DELETE).
+	Methods []string
+	// Action nomeia a ação (padrão: "<método> <rota>", ex.: "PUT /accounts/:id").
+	Action func(c *gin.Context) string
+	// Resource identifica o recurso (padrão: a rota; ResourceID vazio).
+	Resource func(c *gin.Context) (resource string, id string)
+}
+
+// entryKey é a chave do contexto onde fica a entrada em construção.
+type entryKey struct{}
+
+// Middleware registra uma entrada para cada requisição auditada, após a
+// resposta, com o status e o resultado. Os handlers podem completá-la com
+// Annotate (ex.: ResourceID, Before e After). Falhas ao gravar são registradas
+// no log sem afetar a resposta.
+func Middleware(r *Recorder, opts MiddlewareOptions) gin.HandlerFunc {
+	if len(opts.Methods) == 0 {
+		opts.Methods = []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	}
+	methods := make(map[string]bool, len(opts.Methods))
+	for _, m := range opts.Methods {
+		methods[m] = true
+	}
+
+	return func(c *gin.Context) {
+		if !methods[c.Request.Method] {
+			c.Next()
+			return
+		}
+
+		entry := &Entry{}
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), entryKey{}, entry))
+		c.Next()
+
+		if entry.Action == "" {
+			entry.Action = c.Request.Method + " " + c.FullPath()
+			if opts.Action != nil {
+				entry.Action = opts.Action(c)
+			}
+		}
+		if entry.Resource == "" {
+			resource, id := c.FullPath(), ""
+			if opts.Resource != nil {
+				resource, id = opts.Resource(c)
+			}
+			entry.Resource = resource
+			if entry.ResourceID == "" {
+				entry.ResourceID = id
+			}
+		}
+		entry.Status = c.Writer.Status()
+		if entry.Outcome == "" && entry.Status >= http.StatusBadRequest {
+			entry.Outcome = Failure
+		}
+		entry.IP = c.ClientIP()
+		entry.UserAgent = c.Request.UserAgent()
+
+		// Record já registra a falha no log; a resposta não é afetada.
+		_ = r.Record(context.WithoutCancel(c.Request.Context()), *entry)
+	}
+}
+
+// Annotate altera a entrada que o Middleware registrará para a requisição do
+// contexto; fora do middleware, não tem efeito.
+//
+//	audit.Annotate(ctx, func(e *audit.Entry) {
+//		e.ResourceID = account.ID
+//		e.Before, e.After = previous, account
+//	})
+func Annotate(ctx context.Context, fn func(e *Entry)) {
+	if entry, ok := ctx.Value(entryKey{}).(*Entry); ok {
+		fn(entry)
+	}
+}
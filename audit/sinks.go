@@ -0,0 +1,123 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nathanribeiroo/module-dep-projects/db"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"github.com/nathanribeiroo/module-dep-projects/queue"
+)
+
+// auditTable é a tabela padrão das entradas de auditoria.
+const auditTable = "audit_entries"
+
+// Table grava as entradas em uma tabela do banco.
+type Table struct {
+	db    *db.DB
+	table string
+}
+
+// NewTable cria o sink sobre o banco informado, na tabela audit_entries.
+func NewTable(database *db.DB) *Table {
+	return &Table{db: database, table: auditTable}
+}
+
+// CreateTable cria a tabela de auditoria, se ainda não existir. Alternativamente,
+// inclua o DDL equivalente nas migrações do serviço.
+func (t *Table) CreateTable(ctx context.Context) error {
+	_, err := t.db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+t.table+` (
+		id VARCHAR(64) PRIMARY KEY,
+		occurred_at TIMESTAMP NOT NULL,
+		actor VARCHAR(255) NOT NULL,
+		tenant VARCHAR(255) NOT NULL,
+		action VARCHAR(255) NOT NULL,
+		resource VARCHAR(255) NOT NULL,
+		resource_id VARCHAR(255) NOT NULL,
+		outcome VARCHAR(16) NOT NULL,
+		status INT NOT NULL,
+		changes TEXT NOT NULL,
+		correlation_id VARCHAR(64) NOT NULL,
+		ip VARCHAR(64) NOT NULL,
+		user_agent TEXT NOT NULL,
+		metadata TEXT NOT NULL
+	)`)
+	return err
+}
+
+// Write implementa Sink.
+func (t *Table) Write(ctx context.Context, entries ...Entry) error {
+	return t.insert(ctx, t.db, entries)
+}
+
+// WriteTx grava as entradas usando a transação da regra de negócio, para que a
+// auditoria só exista se a alteração for confirmada.
+func (t *Table) WriteTx(ctx context.Context, tx *sql.Tx, entries ...Entry) error {
+	completed := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		e, err := complete(ctx, e)
+		if err != nil {
+			return err
+		}
+		completed = append(completed, e)
+	}
+	return t.insert(ctx, tx, completed)
+}
+
+// execer é a parte comum de *db.DB e *sql.Tx usada na gravação.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// insert grava as entradas já completas.
+func (t *Table) insert(ctx context.Context, exec execer, entries []Entry) error {
+	query := t.db.Rebind("INSERT INTO " + t.table + " (id, occurred_at, actor, tenant, action, resource, resource_id, outcome, status, changes, correlation_id, ip, user_agent, metadata) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	for _, e := range entries {
+		changes, err := json.Marshal(e.Changes)
+		if err != nil {
+			return err
+		}
+		metadata, err := json.Marshal(e.Metadata)
+		if err != nil {
+			return err
+		}
+
+		if _, err := exec.ExecContext(ctx, query, e.ID, e.Time, e.Actor, e.Tenant, e.Action, e.Resource, e.ResourceID,
+			e.Outcome, e.Status, string(changes), e.CorrelationID, e.IP, e.UserAgent, string(metadata)); err != nil {
+			return fmt.Errorf("audit: insert: %w", err)
+		}
+	}
+	return nil
+}
+
+// Queue devolve um Sink que publica as entradas em JSON no produtor informado,
+// com o recurso como chave e a ação no atributo audit_action.
+func Queue(producer queue.Producer) Sink {
+	return SinkFunc(func(ctx context.Context, entries ...Entry) error {
+		msgs := make([]queue.Message, 0, len(entries))
+		for _, e := range entries {
+			body, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, queue.Message{
+				Key:        e.Resource + ":" + e.ResourceID,
+				Body:       body,
+				Attributes: map[string]string{"audit_action": e.Action, "audit_id": e.ID},
+			})
+		}
+		return producer.Publish(ctx, msgs...)
+	})
+}
+
+// Log devolve um Sink que escreve as entradas no log, útil em desenvolvimento.
+func Log() Sink {
+	return SinkFunc(func(ctx context.Context, entries ...Entry) error {
+		for _, e := range entries {
+			logx.Ctx(ctx).Info("Audit entry", "audit", e)
+		}
+		return nil
+	})
+}
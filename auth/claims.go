@@ -0,0 +1,99 @@
+// Package auth emite e valida JWTs para autenticação entre serviços: assina
+// tokens RS256/ES256, valida tokens com chaves publicadas em JWKS (com cache e
+// rotação), expõe as claims no contexto e protege rotas do Gin.
+//
+//	verifier, err := auth.NewVerifier(auth.VerifierOptions{
+//		JWKSURL:  "https://auth.internal/.well-known/jwks.json",
+//		Issuer:   "https://auth.internal",
+//		Audience: "payments",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//
+//	r.POST("/payments", auth.Middleware(verifier), auth.RequireScope("payments:write"), createPayment)
+package auth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nathanribeiroo/module-dep-projects/server"
+)
+
+// Claims são as claims de um token validado.
+type Claims jwt.MapClaims
+
+// Subject devolve a claim "sub".
+func (c Claims) Subject() string {
+	return c.String("sub")
+}
+
+// Issuer devolve a claim "iss".
+func (c Claims) Issuer() string {
+	return c.String("iss")
+}
+
+// Audience devolve a claim "aud", que pode ser texto ou lista no token.
+func (c Claims) Audience() []string {
+	aud, _ := jwt.MapClaims(c).GetAudience()
+	return aud
+}
+
+// ExpiresAt devolve a claim "exp", ou o instante zero se ausente.
+func (c Claims) ExpiresAt() time.Time {
+	exp, _ := jwt.MapClaims(c).GetExpirationTime()
+	if exp == nil {
+		return time.Time{}
+	}
+	return exp.Time
+}
+
+// Scopes devolve os escopos da claim "scope" (separados por espaço) ou "scp" (lista).
+func (c Claims) Scopes() []string {
+	if scope := c.String("scope"); scope != "" {
+		return strings.Fields(scope)
+	}
+
+	list, _ := c["scp"].([]interface{})
+	scopes := make([]string, 0, len(list))
+	for _, s := range list {
+		if str, ok := s.(string); ok {
+			scopes = append(scopes, str)
+		}
+	}
+	return scopes
+}
+
+// HasScope informa se o token concede o escopo.
+func (c Claims) HasScope(scope string) bool {
+	for _, s := range c.Scopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// String devolve uma claim de texto, ou "" se ausente ou de outro tipo.
+func (c Claims) String(key string) string {
+	s, _ := c[key].(string)
+	return s
+}
+
+type claimsKey struct{}
+
+// WithClaims associa as claims ao contexto, incluindo o subject como cliente
+// autenticado do server (ver server.SubjectFromContext).
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	ctx = server.WithSubject(ctx, claims.Subject())
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext devolve as claims associadas ao contexto pelo Middleware.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(Claims)
+	return claims, ok
+}
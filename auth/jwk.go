@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+)
+
+// JWK é uma chave pública no formato JSON Web Key (RFC 7517).
+type JWK struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+	// N e E são o módulo e o expoente das chaves RSA.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Crv, X e Y são a curva e as coordenadas das chaves EC.
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet é o documento publicado em /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// curves associa os nomes das curvas JWK às curvas do Go.
+var curves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+// PublicKey converte a JWK na chave pública correspondente.
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("auth: jwk %s: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("auth: jwk %s: %w", k.Kid, err)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+
+	case "EC":
+		curve, ok := curves[k.Crv]
+		if !ok {
+			return nil, fmt.Errorf("auth: jwk %s: unsupported curve %s", k.Kid, k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("auth: jwk %s: %w", k.Kid, err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("auth: jwk %s: %w", k.Kid, err)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if len(x) != size || len(y) != size || !curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, fmt.Errorf("auth: jwk %s: invalid ec point", k.Kid)
+		}
+		return pub, nil
+
+	default:
+		return nil, fmt.Errorf("auth: jwk %s: unsupported key type %s", k.Kid, k.Kty)
+	}
+}
+
+// newJWK converte a chave pública em JWK.
+func newJWK(kid string, alg string, key crypto.PublicKey) (JWK, error) {
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kid: kid, Kty: "RSA", Alg: alg, Use: "sig",
+			N: base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}, nil
+
+	case *ecdsa.PublicKey:
+		// As coordenadas têm o tamanho fixo da curva (RFC 7518, seção 6.2.1).
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		return JWK{
+			Kid: kid, Kty: "EC", Alg: alg, Use: "sig",
+			Crv: pub.Curve.Params().Name,
+			X:   base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size))),
+			Y:   base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size))),
+		}, nil
+
+	default:
+		return JWK{}, fmt.Errorf("auth: unsupported public key %T", key)
+	}
+}
@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// ErrKeyNotFound indica que nenhuma chave conhecida tem o kid do token.
+var ErrKeyNotFound = errors.New("auth: signing key not found")
+
+// KeySource resolve a chave pública de verificação pelo kid do token.
+type KeySource interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+// StaticKeys é um KeySource com chaves fixas, indexadas pelo kid.
+type StaticKeys map[string]crypto.PublicKey
+
+// Key implementa KeySource.
+func (k StaticKeys) Key(_ context.Context, kid string) (crypto.PublicKey, error) {
+	key, ok := k[kid]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return key, nil
+}
+
+// JWKSOptions configura o cache de JWKS.
+type JWKSOptions struct {
+	// TTL é o tempo de reaproveitamento das chaves baixadas (padrão: 1h).
+	TTL time.Duration
+	// MinRefreshInterval é o intervalo mínimo entre downloads provocados por um
+	// kid desconhecido, evitando que tokens forjados sobrecarreguem o emissor (padrão: 1min).
+	MinRefreshInterval time.Duration
+	// Client é o cliente HTTP usado nos downloads (padrão: timeout de 10s).
+	Client *http.Client
+}
+
+// JWKS é um KeySource que baixa as chaves publicadas pelo emissor e as mantém
+// em cache. Um kid desconhecido provoca um novo download, acompanhando a
+// rotação de chaves sem reiniciar o serviço.
+type JWKS struct {
+	url  string
+	opts JWKSOptions
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	lastErr     error
+	// inflight é fechado ao fim do download em andamento, compartilhado entre as verificações.
+	inflight chan struct{}
+}
+
+// NewJWKS cria o cache das chaves publicadas em url. As chaves são baixadas na primeira verificação.
+func NewJWKS(url string, opts JWKSOptions) *JWKS {
+	if opts.TTL <= 0 {
+		opts.TTL = time.Hour
+	}
+	if opts.MinRefreshInterval <= 0 {
+		opts.MinRefreshInterval = time.Minute
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &JWKS{url: url, opts: opts}
+}
+
+// Key implementa KeySource. O download acontece fora do lock e é compartilhado
+// pelas verificações concorrentes; cada uma espera no máximo até o próprio ctx.
+// Tanto o kid desconhecido quanto o cache vencido respeitam MinRefreshInterval:
+// com o emissor fora do ar, as chaves anteriores continuam valendo.
+func (j *JWKS) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	j.mu.Lock()
+	key, ok := j.keys[kid]
+	if ok && time.Since(j.fetchedAt) <= j.opts.TTL {
+		j.mu.Unlock()
+		return key, nil
+	}
+	done := j.inflight
+	if done == nil && time.Since(j.attemptedAt) > j.opts.MinRefreshInterval {
+		done = j.startRefresh(ctx)
+	}
+	j.mu.Unlock()
+
+	if done != nil {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if key, ok := j.keys[kid]; ok {
+		return key, nil
+	}
+	if j.keys == nil && j.lastErr != nil {
+		return nil, j.lastErr
+	}
+	return nil, ErrKeyNotFound
+}
+
+// startRefresh inicia o download em segundo plano; deve ser chamado com j.mu travado.
+func (j *JWKS) startRefresh(ctx context.Context) chan struct{} {
+	done := make(chan struct{})
+	j.inflight = done
+	j.attemptedAt = time.Now()
+
+	// O download não é interrompido pelo cancelamento de quem o iniciou; o
+	// timeout do Client o limita.
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		keys, err := j.fetch(ctx)
+		if err != nil {
+			// Sem conseguir atualizar, as chaves anteriores continuam valendo.
+			logx.Ctx(ctx).Warn("Failed to refresh JWKS", "url", j.url, "error", err)
+		}
+
+		j.mu.Lock()
+		if err == nil {
+			j.keys = keys
+			j.fetchedAt = time.Now()
+		}
+		j.lastErr = err
+		j.inflight = nil
+		j.mu.Unlock()
+		close(done)
+	}()
+	return done
+}
+
+// fetch baixa o documento JWKS; chaves de tipos não suportados são ignoradas.
+func (j *JWKS) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := j.opts.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("auth: jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth: jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	var set JWKSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("auth: jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/server"
+)
+
+// Middleware exige um token Bearer válido no cabeçalho Authorization e associa
+// as claims ao contexto da requisição (veja ClaimsFromContext). Falhas são
+// respondidas com 401 no formato errx.
+func Middleware(v *Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			server.Fail(c, errx.New("missing bearer token").WithCode(errx.UNAUTHORIZED))
+			return
+		}
+
+		claims, err := v.Verify(c.Request.Context(), token)
+		if err != nil {
+			server.Fail(c, err)
+			return
+		}
+
+		c.Request = c.Request.WithContext(WithClaims(c.Request.Context(), claims))
+		c.Next()
+	}
+}
+
+// RequireScope exige que o token validado pelo Middleware conceda todos os
+// escopos informados, respondendo 403 caso contrário.
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := ClaimsFromContext(c.Request.Context())
+		if !ok {
+			server.Fail(c, errx.New("missing bearer token").WithCode(errx.UNAUTHORIZED))
+			return
+		}
+
+		for _, scope := range scopes {
+			if !claims.HasScope(scope) {
+				server.Fail(c, errx.New("insufficient scope").
+					WithCode(errx.FORBIDDEN).
+					WithDetails(map[string]interface{}{"required_scope": scope}))
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// Subject devolve o subject do token validado pelo Middleware, ou vazio. Serve
+// como chave de cliente em middlewares como server.EnforceQuota.
+func Subject(c *gin.Context) string {
+	claims, _ := ClaimsFromContext(c.Request.Context())
+	return claims.Subject()
+}
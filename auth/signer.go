@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Signer emite tokens assinados com uma chave privada RSA (RS256) ou EC (ES256/ES384/ES512).
+type Signer struct {
+	kid    string
+	issuer string
+	method jwt.SigningMethod
+	key    crypto.Signer
+}
+
+// NewSigner cria o Signer; kid identifica a chave no JWKS e issuer é gravado na claim "iss".
+func NewSigner(kid string, issuer string, key crypto.Signer) (*Signer, error) {
+	var method jwt.SigningMethod
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		method = jwt.SigningMethodRS256
+	case *ecdsa.PrivateKey:
+		switch k.Curve.Params().BitSize {
+		case 256:
+			method = jwt.SigningMethodES256
+		case 384:
+			method = jwt.SigningMethodES384
+		case 521:
+			method = jwt.SigningMethodES512
+		default:
+			return nil, fmt.Errorf("auth: unsupported curve %s", k.Curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("auth: unsupported private key %T", key)
+	}
+	return &Signer{kid: kid, issuer: issuer, method: method, key: key}, nil
+}
+
+// ParsePrivateKeyPEM lê uma chave privada em PEM (PKCS#8, PKCS#1 ou SEC 1).
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("auth: no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if signer, ok := key.(crypto.Signer); ok {
+			return signer, nil
+		}
+		return nil, fmt.Errorf("auth: unsupported private key %T", key)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("auth: unsupported private key format")
+}
+
+// Sign assina as claims informadas, preenchendo "iss", "iat" e "jti" quando ausentes.
+func (s *Signer) Sign(claims map[string]interface{}) (string, error) {
+	mc := jwt.MapClaims{}
+	for k, v := range claims {
+		mc[k] = v
+	}
+	if _, ok := mc["iss"]; !ok && s.issuer != "" {
+		mc["iss"] = s.issuer
+	}
+	if _, ok := mc["iat"]; !ok {
+		mc["iat"] = time.Now().Unix()
+	}
+	if _, ok := mc["jti"]; !ok {
+		mc["jti"] = uuid.NewString()
+	}
+
+	token := jwt.NewWithClaims(s.method, mc)
+	token.Header["kid"] = s.kid
+	return token.SignedString(s.key)
+}
+
+// Mint emite um token de serviço para o audience informado, válido por ttl,
+// com os escopos na claim "scope".
+func (s *Signer) Mint(subject string, audience string, ttl time.Duration, scopes ...string) (string, error) {
+	claims := map[string]interface{}{
+		"sub": subject,
+		"aud": audience,
+		"exp": time.Now().Add(ttl).Unix(),
+	}
+	if len(scopes) > 0 {
+		claims["scope"] = strings.Join(scopes, " ")
+	}
+	return s.Sign(claims)
+}
+
+// Key implementa KeySource com a chave pública do próprio Signer, permitindo
+// validar localmente os tokens emitidos.
+func (s *Signer) Key(_ context.Context, kid string) (crypto.PublicKey, error) {
+	if kid != s.kid {
+		return nil, ErrKeyNotFound
+	}
+	return s.key.Public(), nil
+}
+
+// JWKS devolve o documento JWKS com a chave pública do Signer.
+func (s *Signer) JWKS() (JWKSet, error) {
+	jwk, err := newJWK(s.kid, s.method.Alg(), s.key.Public())
+	if err != nil {
+		return JWKSet{}, err
+	}
+	return JWKSet{Keys: []JWK{jwk}}, nil
+}
+
+// JWKSHandler publica o JWKS do Signer (ex.: em GET /.well-known/jwks.json).
+func (s *Signer) JWKSHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		set, err := s.JWKS()
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, set)
+	}
+}
+
+// TokenSource devolve tokens emitidos pelo Signer, reaproveitando o último
+// até faltar um quinto da validade. É seguro para uso concorrente.
+type TokenSource struct {
+	signer   *Signer
+	subject  string
+	audience string
+	ttl      time.Duration
+	scopes   []string
+
+	mu      sync.Mutex
+	token   string
+	renewAt time.Time
+}
+
+// TokenSource cria uma fonte de tokens de serviço para o audience informado,
+// compatível com httpclient.SetTokenSource.
+func (s *Signer) TokenSource(subject string, audience string, ttl time.Duration, scopes ...string) *TokenSource {
+	return &TokenSource{signer: s, subject: subject, audience: audience, ttl: ttl, scopes: scopes}
+}
+
+// Token devolve um token válido, emitindo um novo quando necessário.
+func (t *TokenSource) Token(_ context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.token != "" && now.Before(t.renewAt) {
+		return t.token, nil
+	}
+
+	token, err := t.signer.Mint(t.subject, t.audience, t.ttl, t.scopes...)
+	if err != nil {
+		return "", err
+	}
+	t.token = token
+	t.renewAt = now.Add(t.ttl - t.ttl/5)
+	return token, nil
+}
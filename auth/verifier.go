@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// VerifierOptions configura a validação dos tokens.
+type VerifierOptions struct {
+	// JWKSURL é o endereço do JWKS do emissor; ignorado quando Keys é informado.
+	JWKSURL string
+	// Keys resolve as chaves de verificação (ex.: StaticKeys ou um Signer local).
+	Keys KeySource
+	// Issuer, quando informado, é exigido na claim "iss".
+	Issuer string
+	// Audience, quando informado, é exigido na claim "aud".
+	Audience string
+	// Leeway é a tolerância de relógio na validação de "exp" e "nbf" (padrão: 30s).
+	Leeway time.Duration
+	// Algorithms são os algoritmos aceitos (padrão: RS256 e ES256).
+	Algorithms []string
+}
+
+// Verifier valida tokens assinados e devolve as suas claims.
+type Verifier struct {
+	keys   KeySource
+	parser *jwt.Parser
+}
+
+// NewVerifier cria o Verifier; exige JWKSURL ou Keys.
+func NewVerifier(opts VerifierOptions) (*Verifier, error) {
+	keys := opts.Keys
+	if keys == nil {
+		if opts.JWKSURL == "" {
+			return nil, errors.New("auth: JWKSURL or Keys is required")
+		}
+		keys = NewJWKS(opts.JWKSURL, JWKSOptions{})
+	}
+	if opts.Leeway <= 0 {
+		opts.Leeway = 30 * time.Second
+	}
+	if len(opts.Algorithms) == 0 {
+		opts.Algorithms = []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(opts.Algorithms),
+		jwt.WithLeeway(opts.Leeway),
+		jwt.WithExpirationRequired(),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+
+	return &Verifier{keys: keys, parser: jwt.NewParser(parserOpts...)}, nil
+}
+
+// Verify valida assinatura, expiração, emissor e audience do token, devolvendo
+// uma AppError UNAUTHORIZED em caso de falha.
+func (v *Verifier) Verify(ctx context.Context, token string) (Claims, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, errx.New("invalid token").WithCode(errx.UNAUTHORIZED).WithError(err)
+	}
+	return Claims(claims), nil
+}
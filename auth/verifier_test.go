@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+func newTestSigner(t *testing.T, kid string) *Signer {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	signer, err := NewSigner(kid, "https://issuer.test", key)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	return signer
+}
+
+func TestVerifierVerify(t *testing.T) {
+	signer := newTestSigner(t, "key-1")
+	stranger := newTestSigner(t, "key-2")
+	impostor := newTestSigner(t, "key-1")
+
+	verifier, err := NewVerifier(VerifierOptions{
+		Keys:     signer,
+		Issuer:   "https://issuer.test",
+		Audience: "payments",
+		Leeway:   time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+
+	sign := func(s *Signer, claims map[string]interface{}) string {
+		token, err := s.Sign(claims)
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		return token
+	}
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"sub": "client-1",
+			"aud": "payments",
+			"exp": time.Now().Add(time.Minute).Unix(),
+		}
+	}
+	with := func(key string, value interface{}) map[string]interface{} {
+		claims := valid()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "valid", token: sign(signer, valid())},
+		{name: "expired", token: sign(signer, with("exp", time.Now().Add(-time.Minute).Unix())), wantErr: true},
+		{name: "without expiration", token: sign(signer, with("exp", nil)), wantErr: true},
+		{name: "not yet valid", token: sign(signer, with("nbf", time.Now().Add(time.Minute).Unix())), wantErr: true},
+		{name: "wrong audience", token: sign(signer, with("aud", "billing")), wantErr: true},
+		{name: "wrong issuer", token: sign(signer, with("iss", "https://other.test")), wantErr: true},
+		{name: "unknown kid", token: sign(stranger, valid()), wantErr: true},
+		{name: "signed by another key", token: sign(impostor, valid()), wantErr: true},
+		{name: "malformed", token: "not.a.token", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(context.Background(), tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Verify succeeded, want error")
+				}
+				if code := errx.GetCode(err); code != errx.UNAUTHORIZED {
+					t.Fatalf("error code = %s, want UNAUTHORIZED", code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if claims.Subject() != "client-1" {
+				t.Fatalf("Subject = %q, want client-1", claims.Subject())
+			}
+		})
+	}
+}
+
+func TestJWKSKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	signer := newTestSigner(t, "key-1")
+
+	var downloads atomic.Int32
+	router := gin.New()
+	router.GET("/jwks", func(c *gin.Context) {
+		downloads.Add(1)
+		c.Next()
+	}, signer.JWKSHandler())
+	issuer := httptest.NewServer(router)
+	defer issuer.Close()
+
+	jwks := NewJWKS(issuer.URL+"/jwks", JWKSOptions{MinRefreshInterval: time.Hour})
+
+	tests := []struct {
+		name    string
+		kid     string
+		wantErr error
+	}{
+		{name: "known kid", kid: "key-1"},
+		{name: "cached kid", kid: "key-1"},
+		{name: "unknown kid", kid: "key-9", wantErr: ErrKeyNotFound},
+		{name: "unknown kid within the refresh interval", kid: "key-9", wantErr: ErrKeyNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := jwks.Key(context.Background(), tt.kid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Key error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && key == nil {
+				t.Fatal("Key returned no key")
+			}
+		})
+	}
+
+	if got := downloads.Load(); got != 1 {
+		t.Fatalf("JWKS downloaded %d times, want 1", got)
+	}
+}
+
+func TestJWKSUnavailableIssuer(t *testing.T) {
+	issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer issuer.Close()
+
+	jwks := NewJWKS(issuer.URL, JWKSOptions{})
+	if _, err := jwks.Key(context.Background(), "key-1"); err == nil || errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("Key error = %v, want the download error", err)
+	}
+}
@@ -0,0 +1,81 @@
+// Package cache oferece caches genéricos com TTL (em memória ou no Redis) e o
+// helper GetOrLoad, que carrega o valor ausente uma única vez mesmo sob
+// requisições concorrentes (proteção contra stampede).
+//
+//	users := cache.NewMemory[User](cache.Options{MaxEntries: 10_000, TTL: time.Minute})
+//	user, err := cache.GetOrLoad(ctx, users, id, 0, func(ctx context.Context) (User, error) {
+//		return repo.Find(ctx, id)
+//	})
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Cache é a interface comum às implementações do pacote.
+type Cache[T any] interface {
+	// Get devolve o valor e true quando a chave existe e não expirou.
+	Get(ctx context.Context, key string) (T, bool, error)
+	// Set grava o valor pelo ttl informado; com ttl zero, usa o TTL padrão do cache.
+	Set(ctx context.Context, key string, value T, ttl time.Duration) error
+	// Delete remove a chave.
+	Delete(ctx context.Context, key string) error
+}
+
+// Options configura um cache.
+type Options struct {
+	// MaxEntries limita as entradas mantidas em memória; as menos usadas
+	// recentemente são descartadas (padrão: sem limite).
+	MaxEntries int
+	// TTL é a validade padrão das entradas (padrão: sem expiração).
+	TTL time.Duration
+	// OnHit e OnMiss são chamados a cada consulta, para emissão de métricas.
+	OnHit  func(key string)
+	OnMiss func(key string)
+}
+
+// loads agrupa os carregamentos concorrentes da mesma chave no mesmo cache.
+var loads singleflight.Group
+
+// GetOrLoad devolve o valor em cache ou, se ausente, executa load uma única vez
+// por chave (chamadas concorrentes aguardam o mesmo resultado) e grava o valor
+// pelo ttl informado. Erros de load não são armazenados.
+//
+// O carregamento roda com um contexto que não é cancelado junto com o de quem
+// o iniciou, e cada chamada aguarda apenas até o fim do próprio ctx: o
+// cancelamento de uma requisição não derruba as demais que esperam a mesma chave.
+func GetOrLoad[T any](ctx context.Context, c Cache[T], key string, ttl time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	if value, ok, err := c.Get(ctx, key); err == nil && ok {
+		return value, nil
+	}
+
+	loadCtx := context.WithoutCancel(ctx)
+	results := loads.DoChan(fmt.Sprintf("%p:%s", c, key), func() (interface{}, error) {
+		// Outra chamada pode ter carregado o valor enquanto esta aguardava.
+		if value, ok, err := c.Get(loadCtx, key); err == nil && ok {
+			return value, nil
+		}
+
+		value, err := load(loadCtx)
+		if err != nil {
+			return value, err
+		}
+		_ = c.Set(loadCtx, key, value, ttl)
+		return value, nil
+	})
+
+	select {
+	case result := <-results:
+		if result.Err != nil {
+			return zero, result.Err
+		}
+		return result.Val.(T), nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
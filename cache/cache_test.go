@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetOrLoad(t *testing.T) {
+	failure := errors.New("load failed")
+
+	tests := []struct {
+		name      string
+		cached    bool
+		loadErr   error
+		wantValue string
+		wantLoads int32
+		wantErr   error
+	}{
+		{name: "miss", wantValue: "loaded", wantLoads: 1},
+		{name: "hit", cached: true, wantValue: "cached"},
+		{name: "load error", loadErr: failure, wantLoads: 1, wantErr: failure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMemory[string](Options{})
+			if tt.cached {
+				_ = c.Set(context.Background(), "key", "cached", 0)
+			}
+
+			var loads atomic.Int32
+			value, err := GetOrLoad(context.Background(), c, "key", 0, func(context.Context) (string, error) {
+				loads.Add(1)
+				return "loaded", tt.loadErr
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetOrLoad error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && value != tt.wantValue {
+				t.Fatalf("GetOrLoad = %q, want %q", value, tt.wantValue)
+			}
+			if got := loads.Load(); got != tt.wantLoads {
+				t.Fatalf("load ran %d times, want %d", got, tt.wantLoads)
+			}
+			if _, ok, _ := c.Get(context.Background(), "key"); ok != (tt.wantErr == nil) {
+				t.Fatalf("cached = %v after GetOrLoad", ok)
+			}
+		})
+	}
+}
+
+func TestGetOrLoadConcurrentCallers(t *testing.T) {
+	c := NewMemory[string](Options{})
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (string, error) {
+		loads.Add(1)
+		<-release
+		return "loaded", ctx.Err()
+	}
+
+	// O primeiro chamador inicia o carregamento e desiste antes do fim.
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := GetOrLoad(first, c, "key", time.Minute, load)
+		firstErr <- err
+	}()
+	for loads.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	var (
+		wg     sync.WaitGroup
+		values = make([]string, 5)
+		errs   = make([]error, 5)
+	)
+	for i := range values {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i], errs[i] = GetOrLoad(context.Background(), c, "key", time.Minute, load)
+		}(i)
+	}
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled caller error = %v, want context.Canceled", err)
+	}
+	close(release)
+	wg.Wait()
+
+	for i := range values {
+		if errs[i] != nil || values[i] != "loaded" {
+			t.Fatalf("caller %d = %q, %v; want loaded", i, values[i], errs[i])
+		}
+	}
+	if got := loads.Load(); got != 1 {
+		t.Fatalf("load ran %d times, want 1", got)
+	}
+}
@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory é um cache em memória com TTL e descarte LRU, seguro para uso concorrente.
+type Memory[T any] struct {
+	opts Options
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// memoryEntry é uma entrada do cache em memória.
+type memoryEntry[T any] struct {
+	key     string
+	value   T
+	expires time.Time
+}
+
+// NewMemory cria um cache em memória.
+func NewMemory[T any](opts Options) *Memory[T] {
+	return &Memory[T]{
+		opts:    opts,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+}
+
+// Get implementa Cache.
+func (m *Memory[T]) Get(_ context.Context, key string) (T, bool, error) {
+	m.mu.Lock()
+	el, ok := m.entries[key]
+	if ok && el.Value.(*memoryEntry[T]).expired() {
+		m.remove(el)
+		ok = false
+	}
+	if !ok {
+		m.mu.Unlock()
+		if m.opts.OnMiss != nil {
+			m.opts.OnMiss(key)
+		}
+		var zero T
+		return zero, false, nil
+	}
+
+	m.lru.MoveToFront(el)
+	value := el.Value.(*memoryEntry[T]).value
+	m.mu.Unlock()
+
+	if m.opts.OnHit != nil {
+		m.opts.OnHit(key)
+	}
+	return value, true, nil
+}
+
+// Set implementa Cache.
+func (m *Memory[T]) Set(_ context.Context, key string, value T, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = m.opts.TTL
+	}
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		entry := el.Value.(*memoryEntry[T])
+		entry.value = value
+		entry.expires = expires
+		m.lru.MoveToFront(el)
+		return nil
+	}
+
+	m.entries[key] = m.lru.PushFront(&memoryEntry[T]{key: key, value: value, expires: expires})
+	if m.opts.MaxEntries > 0 && m.lru.Len() > m.opts.MaxEntries {
+		m.remove(m.lru.Back())
+	}
+	return nil
+}
+
+// Delete implementa Cache.
+func (m *Memory[T]) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		m.remove(el)
+	}
+	return nil
+}
+
+// Len devolve o número de entradas, incluindo as expiradas ainda não removidas.
+func (m *Memory[T]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+// Purge remove todas as entradas.
+func (m *Memory[T]) Purge() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = map[string]*list.Element{}
+	m.lru.Init()
+}
+
+// remove descarta a entrada; deve ser chamado com o lock adquirido.
+func (m *Memory[T]) remove(el *list.Element) {
+	m.lru.Remove(el)
+	delete(m.entries, el.Value.(*memoryEntry[T]).key)
+}
+
+// expired informa se a entrada passou da validade.
+func (e *memoryEntry[T]) expired() bool {
+	return !e.expires.IsZero() && time.Now().After(e.expires)
+}
@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/resilience"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrUnavailable indica que o circuito do Redis está aberto após falhas
+// consecutivas; as operações falham imediatamente até o fim do intervalo de espera.
+var ErrUnavailable = errors.New("cache: redis unavailable")
+
+// Codec serializa os valores gravados no Redis.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONCodec serializa os valores em JSON.
+type JSONCodec struct{}
+
+// Marshal implementa Codec.
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+// Unmarshal implementa Codec.
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// RedisOptions configura o cache no Redis. MaxEntries não se aplica.
+type RedisOptions struct {
+	Options
+	// Namespace prefixa as chaves no formato "<namespace>:<chave>"
+	// (padrão: o serviço informado em dd.Load).
+	Namespace string
+	// Codec serializa os valores (padrão: JSONCodec).
+	Codec Codec
+	// FailureThreshold é o número de falhas consecutivas que abre o circuito (padrão: 5).
+	FailureThreshold int
+	// Cooldown é o tempo com o circuito aberto antes de uma nova tentativa (padrão: 10s).
+	Cooldown time.Duration
+}
+
+// Redis é um cache no Redis com a mesma interface do cache em memória. Para que
+// os comandos apareçam nos traces, crie o cliente com dd.NewRedisClient.
+type Redis[T any] struct {
+	client  redis.UniversalClient
+	opts    RedisOptions
+	breaker *resilience.CircuitBreaker
+}
+
+// NewRedis cria um cache sobre o cliente Redis informado.
+func NewRedis[T any](client redis.UniversalClient, opts RedisOptions) *Redis[T] {
+	if opts.Namespace == "" {
+		opts.Namespace = dd.ServiceName()
+	}
+	if opts.Codec == nil {
+		opts.Codec = JSONCodec{}
+	}
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = 5
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = 10 * time.Second
+	}
+
+	return &Redis[T]{
+		client: client,
+		opts:   opts,
+		breaker: resilience.NewCircuitBreaker(resilience.BreakerOptions{
+			Name:             "cache:" + opts.Namespace,
+			FailureThreshold: opts.FailureThreshold,
+			Cooldown:         opts.Cooldown,
+		}),
+	}
+}
+
+// NewTracedRedis cria o cliente Redis instrumentado com o Datadog e o cache sobre ele.
+func NewTracedRedis[T any](options *redis.Options, opts RedisOptions) *Redis[T] {
+	return NewRedis[T](dd.NewRedisClient(options), opts)
+}
+
+// Get implementa Cache.
+func (r *Redis[T]) Get(ctx context.Context, key string) (T, bool, error) {
+	var zero T
+	if !r.breaker.Allow() {
+		return zero, false, ErrUnavailable
+	}
+
+	data, err := r.client.Get(ctx, r.key(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		r.breaker.Success()
+		if r.opts.OnMiss != nil {
+			r.opts.OnMiss(key)
+		}
+		return zero, false, nil
+	}
+	if err := r.track(ctx, err); err != nil {
+		return zero, false, err
+	}
+
+	var value T
+	if err := r.opts.Codec.Unmarshal(data, &value); err != nil {
+		return zero, false, err
+	}
+	if r.opts.OnHit != nil {
+		r.opts.OnHit(key)
+	}
+	return value, true, nil
+}
+
+// Set implementa Cache.
+func (r *Redis[T]) Set(ctx context.Context, key string, value T, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = r.opts.TTL
+	}
+
+	// A serialização vem antes de Allow: uma falha aqui não envolve o Redis e
+	// não pode deixar pendente a chamada de teste do circuito.
+	data, err := r.opts.Codec.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if !r.breaker.Allow() {
+		return ErrUnavailable
+	}
+	return r.track(ctx, r.client.Set(ctx, r.key(key), data, ttl).Err())
+}
+
+// Delete implementa Cache.
+func (r *Redis[T]) Delete(ctx context.Context, key string) error {
+	if !r.breaker.Allow() {
+		return ErrUnavailable
+	}
+	return r.track(ctx, r.client.Del(ctx, r.key(key)).Err())
+}
+
+// key aplica o namespace à chave.
+func (r *Redis[T]) key(key string) string {
+	if r.opts.Namespace == "" {
+		return key
+	}
+	return r.opts.Namespace + ":" + key
+}
+
+// track registra o resultado da operação no circuito. Falhas causadas pelo
+// fim do contexto do chamador não contam contra o Redis.
+func (r *Redis[T]) track(ctx context.Context, err error) error {
+	switch {
+	case err == nil:
+		r.breaker.Success()
+	case ctx.Err() != nil:
+		r.breaker.Release()
+	default:
+		r.breaker.Failure()
+	}
+	return err
+}
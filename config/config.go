@@ -0,0 +1,463 @@
+// Package config carrega structs de configuração tipadas a partir de valores
+// padrão, arquivos YAML/JSON, arquivos .env e variáveis de ambiente, nesta ordem
+// de precedência (a variável de ambiente sempre prevalece).
+//
+// Os campos são descritos por tags:
+//
+//	type Config struct {
+//		Port     int           `env:"PORT" default:"8080"`
+//		Timeout  time.Duration `env:"TIMEOUT" default:"5s"`
+//		Password string        `env:"DB_PASSWORD" required:"true" secret:"true"`
+//		Redis    struct {
+//			Addr string `env:"ADDR" default:"localhost:6379"`
+//		} `env:"REDIS"` // lido de REDIS_ADDR
+//	}
+//
+// Sem a tag env, o nome da variável é derivado do campo (ex.: MaxConns → MAX_CONNS).
+// Nos arquivos YAML/JSON, os nomes seguem as tags yaml e json do campo.
+// Valores no formato "secret://db/password" são resolvidos pelo pacote secrets.
+package config
+
+import (
+	"bufio"
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/nathanribeiroo/module-dep-projects/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+// Option personaliza o carregamento da configuração.
+type Option func(*options)
+
+// options reúne as fontes usadas por Load.
+type options struct {
+	prefix         string
+	files          []file
+	envFiles       []file
+	reloadInterval time.Duration
+	secrets        *secrets.Store
+}
+
+// file descreve um arquivo de configuração e se ele é obrigatório.
+type file struct {
+	path     string
+	optional bool
+}
+
+// WithPrefix acrescenta um prefixo a todas as variáveis de ambiente (ex.: "APP_").
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithFile lê um arquivo YAML (.yaml, .yml) ou JSON (.json), que deve existir.
+func WithFile(path string) Option {
+	return func(o *options) {
+		o.files = append(o.files, file{path: path})
+	}
+}
+
+// WithOptionalFile lê um arquivo YAML ou JSON, ignorando-o quando não existe.
+func WithOptionalFile(path string) Option {
+	return func(o *options) {
+		o.files = append(o.files, file{path: path, optional: true})
+	}
+}
+
+// WithEnvFile lê um arquivo no formato .env, que deve existir. Sem a opção, o
+// arquivo .env do diretório atual é lido quando existir. Os valores do arquivo
+// não sobrescrevem variáveis já definidas no ambiente.
+func WithEnvFile(path string) Option {
+	return func(o *options) {
+		o.envFiles = append(o.envFiles, file{path: path})
+	}
+}
+
+// WithSecrets define o Store usado para resolver as referências secret://
+// (padrão: secrets.Default()).
+func WithSecrets(store *secrets.Store) Option {
+	return func(o *options) {
+		o.secrets = store
+	}
+}
+
+// Load preenche dst, que deve ser um ponteiro para struct, com os valores padrão,
+// os arquivos e as variáveis de ambiente, validando os campos obrigatórios.
+func Load(dst interface{}, opts ...Option) error {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if len(o.envFiles) == 0 {
+		o.envFiles = []file{{path: ".env", optional: true}}
+	}
+
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return errors.New("config: destination must be a pointer to struct")
+	}
+
+	err := walk(v.Elem(), o.prefix, func(f field) error {
+		if def, ok := f.tag.Lookup("default"); ok && f.value.IsZero() {
+			if err := setValue(f.value, def); err != nil {
+				return fmt.Errorf("config: default of %s: %w", f.key, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, fl := range o.files {
+		if err := decodeFile(fl, dst); err != nil {
+			return err
+		}
+	}
+
+	dotenv := map[string]string{}
+	for _, fl := range o.envFiles {
+		if err := readEnvFile(fl, dotenv); err != nil {
+			return err
+		}
+	}
+
+	var missing []string
+	err = walk(v.Elem(), o.prefix, func(f field) error {
+		raw, ok := os.LookupEnv(f.key)
+		if !ok {
+			raw, ok = dotenv[f.key]
+		}
+		if ok {
+			if err := setValue(f.value, raw); err != nil {
+				return fmt.Errorf("config: %s: %w", f.key, err)
+			}
+		}
+		if f.value.Kind() == reflect.String && strings.HasPrefix(f.value.String(), secretScheme) {
+			if err := resolveSecret(o, f.value); err != nil {
+				return fmt.Errorf("config: %s: %w", f.key, err)
+			}
+			markSecret(v.Elem().Type(), f.key)
+		}
+		if f.tag.Get("required") == "true" && f.value.IsZero() {
+			missing = append(missing, f.key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("config: required fields not set: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// secretScheme identifica valores que referenciam um segredo (ex.: "secret://db/password").
+const secretScheme = "secret://"
+
+// resolvedSecrets guarda, por tipo de configuração, as chaves cujo valor veio
+// do pacote secrets, para que Dump as oculte mesmo sem a tag secret.
+var resolvedSecrets = struct {
+	sync.Mutex
+	keys map[reflect.Type]map[string]bool
+}{keys: map[reflect.Type]map[string]bool{}}
+
+// markSecret registra que a chave do tipo t foi resolvida a partir de um segredo.
+func markSecret(t reflect.Type, key string) {
+	resolvedSecrets.Lock()
+	defer resolvedSecrets.Unlock()
+
+	if resolvedSecrets.keys[t] == nil {
+		resolvedSecrets.keys[t] = map[string]bool{}
+	}
+	resolvedSecrets.keys[t][key] = true
+}
+
+// isResolvedSecret informa se a chave do tipo t já foi resolvida a partir de um segredo.
+func isResolvedSecret(t reflect.Type, key string) bool {
+	resolvedSecrets.Lock()
+	defer resolvedSecrets.Unlock()
+
+	return resolvedSecrets.keys[t][key]
+}
+
+// resolveSecret substitui a referência secret:// pelo valor do segredo.
+func resolveSecret(o *options, v reflect.Value) error {
+	store := o.secrets
+	if store == nil {
+		store = secrets.Default()
+	}
+	value, err := store.Get(context.Background(), strings.TrimPrefix(v.String(), secretScheme))
+	if err != nil {
+		return err
+	}
+	v.SetString(value)
+	return nil
+}
+
+// MustLoad é como Load, mas encerra a aplicação com panic em caso de erro.
+func MustLoad(dst interface{}, opts ...Option) {
+	if err := Load(dst, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// Dump descreve a configuração carregada no formato CHAVE=valor, uma por linha
+// e em ordem alfabética, ocultando os campos marcados com secret:"true" e os
+// que Load resolveu a partir de uma referência secret://.
+func Dump(cfg interface{}, opts ...Option) string {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	v := reflect.ValueOf(cfg)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	var lines []string
+	_ = walk(v, o.prefix, func(f field) error {
+		value := formatValue(f.value)
+		secret := f.tag.Get("secret") == "true" || isResolvedSecret(v.Type(), f.key)
+		if secret && !f.value.IsZero() {
+			value = "******"
+		}
+		lines = append(lines, f.key+"="+value)
+		return nil
+	})
+
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// field é um campo folha da struct de configuração.
+type field struct {
+	key   string
+	tag   reflect.StructTag
+	value reflect.Value
+}
+
+// textUnmarshalerType identifica tipos que se convertem a partir de texto.
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// walk percorre os campos exportados da struct, descendo nas structs aninhadas.
+func walk(v reflect.Value, prefix string, fn func(field) error) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := sf.Tag.Get("env")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = envName(sf.Name)
+		}
+
+		fv := v.Field(i)
+		if isNested(fv) {
+			if err := walk(fv, prefix+name+"_", fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := fn(field{key: prefix + name, tag: sf.Tag, value: fv}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isNested informa se o campo é uma struct de configuração aninhada.
+func isNested(v reflect.Value) bool {
+	if v.Kind() != reflect.Struct || v.Type() == reflect.TypeOf(time.Time{}) {
+		return false
+	}
+	return !reflect.PointerTo(v.Type()).Implements(textUnmarshalerType)
+}
+
+// setValue converte raw para o tipo do campo.
+func setValue(v reflect.Value, raw string) error {
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		parts := strings.Split(raw, ",")
+		slice := reflect.MakeSlice(v.Type(), 0, len(parts))
+		for _, part := range parts {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			item := reflect.New(v.Type().Elem()).Elem()
+			if err := setValue(item, part); err != nil {
+				return err
+			}
+			slice = reflect.Append(slice, item)
+		}
+		v.Set(slice)
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		for _, pair := range strings.Split(raw, ",") {
+			key, value, ok := strings.Cut(pair, ":")
+			if !ok {
+				continue
+			}
+			k := reflect.New(v.Type().Key()).Elem()
+			if err := setValue(k, strings.TrimSpace(key)); err != nil {
+				return err
+			}
+			item := reflect.New(v.Type().Elem()).Elem()
+			if err := setValue(item, strings.TrimSpace(value)); err != nil {
+				return err
+			}
+			m.SetMapIndex(k, item)
+		}
+		v.Set(m)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// formatValue representa o valor do campo em texto, no mesmo formato aceito por setValue.
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Slice {
+		parts := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			parts = append(parts, fmt.Sprint(v.Index(i).Interface()))
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// decodeFile lê um arquivo YAML ou JSON sobre dst.
+func decodeFile(fl file, dst interface{}) error {
+	data, err := os.ReadFile(fl.path)
+	if err != nil {
+		if fl.optional && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("config: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(fl.path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, dst)
+	case ".json":
+		err = json.Unmarshal(data, dst)
+	default:
+		return fmt.Errorf("config: unsupported file format %s", fl.path)
+	}
+	if err != nil {
+		return fmt.Errorf("config: %s: %w", fl.path, err)
+	}
+	return nil
+}
+
+// readEnvFile lê um arquivo .env (CHAVE=valor, com comentários iniciados por #).
+func readEnvFile(fl file, dst map[string]string) error {
+	f, err := os.Open(fl.path)
+	if err != nil {
+		if fl.optional && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("config: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		dst[strings.TrimSpace(key)] = value
+	}
+	return scanner.Err()
+}
+
+// envName converte o nome do campo para o formato de variável de ambiente (MaxConns → MAX_CONNS).
+func envName(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// defaultReloadInterval é o intervalo padrão entre as releituras da configuração.
+const defaultReloadInterval = 10 * time.Second
+
+// WithReloadInterval define o intervalo entre as releituras feitas por Watch
+// (padrão: 10s, também usado para valores zero ou negativos).
+func WithReloadInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.reloadInterval = interval
+	}
+}
+
+// Watcher mantém uma configuração do tipo T atualizada, relendo periodicamente
+// os arquivos e as variáveis de ambiente e notificando os assinantes quando
+// algum valor muda. Uma releitura inválida (ex.: campo obrigatório ausente)
+// é registrada no log e a configuração anterior é mantida.
+//
+//	w, err := config.Watch[AppConfig](config.WithFile("config.yaml"))
+//	w.OnChange(func(c AppConfig) {
+//		logx.SetLevel(logx.ParseLevel(c.LogLevel))
+//	})
+//	defer w.Close()
+type Watcher[T any] struct {
+	opts     []Option
+	interval time.Duration
+
+	mu          sync.RWMutex
+	current     T
+	subscribers []func(T)
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// Watch carrega a configuração inicial e passa a observá-la em segundo plano.
+func Watch[T any](opts ...Option) (*Watcher[T], error) {
+	o := &options{reloadInterval: defaultReloadInterval}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.reloadInterval <= 0 {
+		o.reloadInterval = defaultReloadInterval
+	}
+
+	w := &Watcher[T]{
+		opts:     opts,
+		interval: o.reloadInterval,
+		stop:     make(chan struct{}),
+	}
+	if err := Load(&w.current, opts...); err != nil {
+		return nil, err
+	}
+
+	go w.run()
+	return w, nil
+}
+
+// Get devolve a configuração atual.
+func (w *Watcher[T]) Get() T {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// OnChange registra uma função chamada com a nova configuração a cada mudança.
+func (w *Watcher[T]) OnChange(fn func(T)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Reload relê a configuração imediatamente e notifica os assinantes se houver mudança.
+func (w *Watcher[T]) Reload() error {
+	var next T
+	if err := Load(&next, w.opts...); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	if reflect.DeepEqual(w.current, next) {
+		w.mu.Unlock()
+		return nil
+	}
+	w.current = next
+	subscribers := append([]func(T){}, w.subscribers...)
+	w.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(next)
+	}
+	return nil
+}
+
+// Close encerra a observação da configuração.
+func (w *Watcher[T]) Close() {
+	w.once.Do(func() {
+		close(w.stop)
+	})
+}
+
+// run relê a configuração a cada intervalo até Close ser chamado.
+func (w *Watcher[T]) run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if err := w.Reload(); err != nil {
+				logx.L().Error("Failed to reload config", "error", err)
+			}
+		}
+	}
+}
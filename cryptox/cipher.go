@@ -0,0 +1,152 @@
+// Package cryptox reúne primitivas criptográficas de uso comum com padrões
+// seguros: criptografia de campos com AES-256-GCM e rotação de chaves, assinatura
+// HMAC-SHA256 e hash de senhas com argon2id.
+//
+//	cipher, err := cryptox.CipherFromSecrets(ctx, secrets.Default(), "payments/field-key-v2", "payments/field-key-v1")
+//	if err != nil {
+//		return err
+//	}
+//	encrypted, err := cipher.EncryptString(customer.Document, customer.ID)
+package cryptox
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nathanribeiroo/module-dep-projects/secrets"
+)
+
+// ErrDecrypt indica que o texto cifrado é inválido, foi adulterado ou usa uma chave desconhecida.
+var ErrDecrypt = errors.New("cryptox: unable to decrypt")
+
+// Cipher cifra valores com AES-256-GCM. O resultado inclui o identificador da
+// chave usada ("<keyID>:<base64>"), permitindo rotacionar a chave atual sem
+// perder a leitura dos valores cifrados com as anteriores.
+type Cipher struct {
+	current string
+	aeads   map[string]cipher.AEAD
+}
+
+// NewCipher cria o Cipher com as chaves de 32 bytes informadas por ID; current
+// é a chave usada para cifrar e as demais servem apenas para decifrar.
+func NewCipher(current string, keys map[string][]byte) (*Cipher, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("cryptox: current key %q not provided", current)
+	}
+
+	c := &Cipher{current: current, aeads: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if strings.Contains(id, ":") {
+			return nil, fmt.Errorf("cryptox: key id %q must not contain ':'", id)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("cryptox: key %q must have 32 bytes, got %d", id, len(key))
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		c.aeads[id] = aead
+	}
+	return c, nil
+}
+
+// CipherFromSecrets cria o Cipher com chaves lidas do Store de segredos, em
+// base64. O primeiro nome é a chave atual e os seguintes, as anteriores; o
+// nome do segredo é usado como identificador da chave.
+func CipherFromSecrets(ctx context.Context, store *secrets.Store, names ...string) (*Cipher, error) {
+	if len(names) == 0 {
+		return nil, errors.New("cryptox: at least one key is required")
+	}
+
+	keys := make(map[string][]byte, len(names))
+	for _, name := range names {
+		value, err := store.Get(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("cryptox: key %s: %w", name, err)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("cryptox: key %s: %w", name, err)
+		}
+		keys[name] = key
+	}
+	return NewCipher(names[0], keys)
+}
+
+// Encrypt cifra plaintext com a chave atual. aad (dados associados, ex.: o ID
+// do registro) não é cifrado, mas precisa ser o mesmo em Decrypt, impedindo
+// que o valor seja copiado para outro registro.
+func (c *Cipher) Encrypt(plaintext []byte, aad []byte) (string, error) {
+	aead := c.aeads[c.current]
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, aad)
+	return c.current + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decifra um valor produzido por Encrypt com qualquer das chaves configuradas.
+func (c *Cipher) Decrypt(ciphertext string, aad []byte) ([]byte, error) {
+	i := strings.LastIndex(ciphertext, ":")
+	if i < 0 {
+		return nil, ErrDecrypt
+	}
+
+	aead, ok := c.aeads[ciphertext[:i]]
+	if !ok {
+		return nil, ErrDecrypt
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(ciphertext[i+1:])
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], aad)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// EncryptString cifra um texto usando aad como dado associado (pode ser vazio).
+func (c *Cipher) EncryptString(plaintext string, aad string) (string, error) {
+	return c.Encrypt([]byte(plaintext), []byte(aad))
+}
+
+// DecryptString decifra um valor produzido por EncryptString.
+func (c *Cipher) DecryptString(ciphertext string, aad string) (string, error) {
+	plaintext, err := c.Decrypt(ciphertext, []byte(aad))
+	return string(plaintext), err
+}
+
+// NeedsRotation informa se o valor foi cifrado com uma chave diferente da atual
+// e deve ser recifrado.
+func (c *Cipher) NeedsRotation(ciphertext string) bool {
+	i := strings.LastIndex(ciphertext, ":")
+	return i < 0 || ciphertext[:i] != c.current
+}
+
+// GenerateKey devolve uma chave aleatória de 32 bytes em base64, no formato esperado por CipherFromSecrets.
+func GenerateKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
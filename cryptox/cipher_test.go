@@ -0,0 +1,139 @@
+package cryptox
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestCipher(t *testing.T, current string, ids ...string) *Cipher {
+	t.Helper()
+
+	keys := map[string][]byte{}
+	for _, id := range ids {
+		keys[id] = bytes.Repeat([]byte(id[:1]), 32)
+	}
+	c, err := NewCipher(current, keys)
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+	return c
+}
+
+func TestCipherRoundTrip(t *testing.T) {
+	c := newTestCipher(t, "v1", "v1")
+
+	tests := []struct {
+		name      string
+		plaintext []byte
+		aad       []byte
+	}{
+		{name: "empty", plaintext: []byte{}},
+		{name: "text", plaintext: []byte("123.456.789-00"), aad: []byte("customer-1")},
+		{name: "binary", plaintext: []byte{0, 1, 2, 0xff, ':'}, aad: []byte("customer-2")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encrypted, err := c.Encrypt(tt.plaintext, tt.aad)
+			if err != nil {
+				t.Fatalf("Encrypt: %v", err)
+			}
+			if !strings.HasPrefix(encrypted, "v1:") {
+				t.Fatalf("ciphertext %q does not carry the key id", encrypted)
+			}
+
+			decrypted, err := c.Decrypt(encrypted, tt.aad)
+			if err != nil {
+				t.Fatalf("Decrypt: %v", err)
+			}
+			if !bytes.Equal(decrypted, tt.plaintext) {
+				t.Fatalf("Decrypt = %q, want %q", decrypted, tt.plaintext)
+			}
+		})
+	}
+}
+
+func TestCipherRejectsTampering(t *testing.T) {
+	c := newTestCipher(t, "v1", "v1")
+	encrypted, err := c.EncryptString("secret", "customer-1")
+	if err != nil {
+		t.Fatalf("EncryptString: %v", err)
+	}
+
+	flipped := func(s string) string {
+		raw, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(s, "v1:"))
+		if err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		raw[len(raw)-1] ^= 0x01
+		return "v1:" + base64.RawStdEncoding.EncodeToString(raw)
+	}
+
+	tests := []struct {
+		name       string
+		ciphertext string
+		aad        string
+	}{
+		{name: "flipped byte", ciphertext: flipped(encrypted), aad: "customer-1"},
+		{name: "other record", ciphertext: encrypted, aad: "customer-2"},
+		{name: "unknown key", ciphertext: "v9" + strings.TrimPrefix(encrypted, "v1"), aad: "customer-1"},
+		{name: "missing key id", ciphertext: strings.TrimPrefix(encrypted, "v1:"), aad: "customer-1"},
+		{name: "truncated", ciphertext: "v1:AAAA", aad: "customer-1"},
+		{name: "invalid base64", ciphertext: "v1:***", aad: "customer-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.DecryptString(tt.ciphertext, tt.aad); !errors.Is(err, ErrDecrypt) {
+				t.Fatalf("DecryptString error = %v, want ErrDecrypt", err)
+			}
+		})
+	}
+}
+
+func TestCipherRotation(t *testing.T) {
+	old := newTestCipher(t, "v1", "v1")
+	encrypted, err := old.EncryptString("secret", "")
+	if err != nil {
+		t.Fatalf("EncryptString: %v", err)
+	}
+
+	rotated := newTestCipher(t, "v2", "v1", "v2")
+	if got, err := rotated.DecryptString(encrypted, ""); err != nil || got != "secret" {
+		t.Fatalf("DecryptString = %q, %v; want %q", got, err, "secret")
+	}
+	if !rotated.NeedsRotation(encrypted) {
+		t.Fatal("NeedsRotation = false for a value under the previous key")
+	}
+
+	reencrypted, err := rotated.EncryptString("secret", "")
+	if err != nil {
+		t.Fatalf("EncryptString: %v", err)
+	}
+	if rotated.NeedsRotation(reencrypted) {
+		t.Fatal("NeedsRotation = true for a value under the current key")
+	}
+}
+
+func TestNewCipherValidatesKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		keys    map[string][]byte
+	}{
+		{name: "missing current", current: "v2", keys: map[string][]byte{"v1": make([]byte, 32)}},
+		{name: "short key", current: "v1", keys: map[string][]byte{"v1": make([]byte, 16)}},
+		{name: "id with separator", current: "v:1", keys: map[string][]byte{"v:1": make([]byte, 32)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCipher(tt.current, tt.keys); err == nil {
+				t.Fatal("NewCipher succeeded, want error")
+			}
+		})
+	}
+}
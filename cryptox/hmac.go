@@ -0,0 +1,25 @@
+package cryptox
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Sign devolve a assinatura HMAC-SHA256 de data, em hexadecimal.
+func Sign(key []byte, data []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify compara, em tempo constante, a assinatura hexadecimal com a esperada para data.
+func Verify(key []byte, data []byte, signature string) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hmac.Equal(got, mac.Sum(nil))
+}
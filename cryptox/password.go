@@ -0,0 +1,112 @@
+package cryptox
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// ErrInvalidHash indica que o hash informado não está no formato argon2id esperado.
+var ErrInvalidHash = errors.New("cryptox: invalid password hash")
+
+// PasswordParams são os parâmetros do argon2id.
+type PasswordParams struct {
+	// Memory é a memória usada, em KiB.
+	Memory uint32
+	// Iterations é o número de passagens sobre a memória.
+	Iterations uint32
+	// Parallelism é o número de threads.
+	Parallelism uint8
+	// SaltLength e KeyLength são os tamanhos do salt e do hash, em bytes.
+	SaltLength uint32
+	KeyLength  uint32
+}
+
+// DefaultPasswordParams segue a recomendação da OWASP para argon2id (64 MiB, 3 iterações).
+var DefaultPasswordParams = PasswordParams{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// HashPassword gera o hash argon2id da senha com DefaultPasswordParams, no
+// formato PHC ("$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>").
+func HashPassword(password string) (string, error) {
+	return HashPasswordWith(password, DefaultPasswordParams)
+}
+
+// HashPasswordWith gera o hash argon2id da senha com os parâmetros informados.
+func HashPasswordWith(password string, p PasswordParams) (string, error) {
+	salt := make([]byte, p.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	hash := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Iterations, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	), nil
+}
+
+// VerifyPassword compara, em tempo constante, a senha com o hash gerado por HashPassword.
+func VerifyPassword(password string, encoded string) (bool, error) {
+	p, salt, hash, err := decodeHash(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	return subtle.ConstantTimeCompare(hash, other) == 1, nil
+}
+
+// NeedsRehash informa se o hash foi gerado com parâmetros diferentes de
+// DefaultPasswordParams e deve ser refeito no próximo login bem-sucedido.
+func NeedsRehash(encoded string) bool {
+	p, salt, _, err := decodeHash(encoded)
+	if err != nil {
+		return true
+	}
+	d := DefaultPasswordParams
+	return p.Memory != d.Memory || p.Iterations != d.Iterations || p.Parallelism != d.Parallelism ||
+		p.KeyLength != d.KeyLength || uint32(len(salt)) != d.SaltLength
+}
+
+// decodeHash interpreta um hash no formato PHC do argon2id.
+func decodeHash(encoded string) (PasswordParams, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return PasswordParams{}, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return PasswordParams{}, nil, nil, ErrInvalidHash
+	}
+
+	var p PasswordParams
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return PasswordParams{}, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return PasswordParams{}, nil, nil, ErrInvalidHash
+	}
+	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(hash) == 0 {
+		return PasswordParams{}, nil, nil, ErrInvalidHash
+	}
+
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(hash))
+	return p, salt, hash, nil
+}
@@ -0,0 +1,136 @@
+// Package db abre conexões database/sql instrumentadas com o Datadog, com
+// pool configurável, verificação de saúde e tradução dos erros dos drivers de
+// Postgres e MySQL para a errx.
+//
+//	database, err := db.Open(ctx, db.Options{Driver: db.Postgres, DSN: cfg.DatabaseURL})
+//	srv.HealthCheck("postgres", database.Check)
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// Drivers suportados, registrados em database/sql como "pgx" e "mysql".
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+)
+
+// Drivers suportados.
+const (
+	Postgres = "postgres"
+	MySQL    = "mysql"
+)
+
+// Options configura a conexão e o pool.
+type Options struct {
+	// Driver é Postgres ou MySQL.
+	Driver string
+	// DSN é a string de conexão no formato do driver.
+	DSN string
+	// MaxOpenConns limita as conexões abertas (padrão: 10).
+	MaxOpenConns int
+	// MaxIdleConns limita as conexões ociosas mantidas no pool (padrão: MaxOpenConns).
+	MaxIdleConns int
+	// ConnMaxLifetime recicla as conexões após esse tempo (padrão: 30min).
+	ConnMaxLifetime time.Duration
+	// ConnMaxIdleTime fecha as conexões ociosas após esse tempo (padrão: 5min).
+	ConnMaxIdleTime time.Duration
+	// ConnectTimeout limita a verificação da conexão em Open (padrão: 5s).
+	ConnectTimeout time.Duration
+}
+
+// DB é um *sql.DB instrumentado, que registra spans para cada query.
+type DB struct {
+	*sql.DB
+	driver string
+}
+
+// Open abre o pool de conexões e verifica a conectividade com o banco.
+func Open(ctx context.Context, opts Options) (*DB, error) {
+	driverName, err := sqlDriver(opts.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.MaxOpenConns <= 0 {
+		opts.MaxOpenConns = 10
+	}
+	if opts.MaxIdleConns <= 0 {
+		opts.MaxIdleConns = opts.MaxOpenConns
+	}
+	if opts.ConnMaxLifetime <= 0 {
+		opts.ConnMaxLifetime = 30 * time.Minute
+	}
+	if opts.ConnMaxIdleTime <= 0 {
+		opts.ConnMaxIdleTime = 5 * time.Minute
+	}
+	if opts.ConnectTimeout <= 0 {
+		opts.ConnectTimeout = 5 * time.Second
+	}
+
+	conn, err := dd.OpenSQL(driverName, opts.DSN)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetMaxOpenConns(opts.MaxOpenConns)
+	conn.SetMaxIdleConns(opts.MaxIdleConns)
+	conn.SetConnMaxLifetime(opts.ConnMaxLifetime)
+	conn.SetConnMaxIdleTime(opts.ConnMaxIdleTime)
+
+	pingCtx, cancel := context.WithTimeout(ctx, opts.ConnectTimeout)
+	defer cancel()
+	if err := conn.PingContext(pingCtx); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("db: connect: %w", err)
+	}
+
+	return &DB{DB: conn, driver: opts.Driver}, nil
+}
+
+// Driver devolve o driver informado em Open (Postgres ou MySQL).
+func (d *DB) Driver() string {
+	return d.driver
+}
+
+// Rebind converte os placeholders "?" da consulta para o formato do driver
+// ($1, $2... no Postgres; no MySQL, a consulta é devolvida como está).
+func (d *DB) Rebind(query string) string {
+	if d.driver == MySQL {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Check verifica a conexão com o banco; é compatível com server.HealthCheck.
+func (d *DB) Check(ctx context.Context) error {
+	return d.PingContext(ctx)
+}
+
+// sqlDriver devolve o nome do driver registrado em database/sql.
+func sqlDriver(driver string) (string, error) {
+	switch driver {
+	case Postgres:
+		return "pgx", nil
+	case MySQL:
+		return "mysql", nil
+	default:
+		return "", fmt.Errorf("db: unsupported driver %q", driver)
+	}
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// Códigos de erro do Postgres (SQLSTATE) tratados pelo pacote.
+const (
+	pgUniqueViolation      = "23505"
+	pgForeignKeyViolation  = "23503"
+	pgNotNullViolation     = "23502"
+	pgCheckViolation       = "23514"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	pgQueryCanceled        = "57014"
+)
+
+// Códigos de erro do MySQL tratados pelo pacote.
+const (
+	myDuplicateEntry      = 1062
+	myRowIsReferenced     = 1451
+	myNoReferencedRow     = 1452
+	myBadNull             = 1048
+	myCheckConstraint     = 3819
+	myLockWaitTimeout     = 1205
+	myDeadlock            = 1213
+	myQueryInterrupted    = 1317
+	myMaxExecutionTimeout = 3024
+)
+
+// TranslateError converte os erros comuns dos drivers em uma *errx.AppError com
+// o código adequado: registro inexistente (NOT_FOUND), violação de unicidade
+// (CONFLICT), de chave estrangeira, NOT NULL ou CHECK (BAD_REQUEST), query
+// cancelada (CLIENT_CLOSED) ou interrompida por timeout (TIMEOUT). Os demais
+// erros são devolvidos como INTERNAL. Devolve nil quando err é nil.
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errx.IsAppError(err) {
+		return err
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return errx.New("record not found").WithCode(errx.NOT_FOUND).WithError(err)
+	}
+	if errors.Is(err, context.Canceled) {
+		return errx.New("query canceled").WithCode(errx.CLIENT_CLOSED).WithError(err)
+	}
+	if IsTimeout(err) {
+		return errx.New("query timed out").WithCode(errx.TIMEOUT).WithError(err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			return constraintError("duplicate record", errx.CONFLICT, pgErr.ConstraintName, err)
+		case pgForeignKeyViolation:
+			return constraintError("referenced record violation", errx.BAD_REQUEST, pgErr.ConstraintName, err)
+		case pgNotNullViolation, pgCheckViolation:
+			return constraintError("invalid record", errx.BAD_REQUEST, pgErr.ConstraintName, err)
+		}
+	}
+
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		switch myErr.Number {
+		case myDuplicateEntry:
+			return constraintError("duplicate record", errx.CONFLICT, "", err)
+		case myRowIsReferenced, myNoReferencedRow:
+			return constraintError("referenced record violation", errx.BAD_REQUEST, "", err)
+		case myBadNull, myCheckConstraint:
+			return constraintError("invalid record", errx.BAD_REQUEST, "", err)
+		}
+	}
+
+	return errx.New("database error").WithCode(errx.INTERNAL).WithError(err)
+}
+
+// constraintError monta a AppError de uma violação de restrição.
+func constraintError(message string, code errx.Code, constraint string, err error) error {
+	appErr := errx.New(message).WithCode(code).WithError(err)
+	if constraint != "" {
+		appErr.WithDetails(map[string]interface{}{"constraint": constraint})
+	}
+	return appErr
+}
+
+// IsRetryable informa se a operação pode ser repetida com segurança em uma nova
+// transação: falhas de serialização, deadlocks e timeouts de lock.
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+	}
+
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		return myErr.Number == myDeadlock || myErr.Number == myLockWaitTimeout
+	}
+	return false
+}
+
+// IsTimeout informa se a query foi cancelada por timeout ou cancelamento do contexto.
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgQueryCanceled
+	}
+
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		return myErr.Number == myQueryInterrupted || myErr.Number == myMaxExecutionTimeout
+	}
+	return false
+}
@@ -0,0 +1,256 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+const (
+	// migrationsTable é a tabela que registra as versões aplicadas.
+	migrationsTable = "schema_migrations"
+	// migrationsLock identifica o lock consultivo que serializa as migrações
+	// entre instâncias iniciadas ao mesmo tempo.
+	migrationsLock = "schema_migrations"
+	// migrationsLockKey é a chave numérica do lock consultivo no Postgres.
+	migrationsLockKey = 7_206_153_771
+)
+
+// migration é uma versão com seus scripts de aplicação e reversão.
+type migration struct {
+	version int64
+	name    string
+	up      string
+	down    string
+}
+
+// Migrate aplica, em ordem, as migrações ainda não registradas na tabela
+// schema_migrations. Os arquivos ficam na raiz de fsys (use fs.Sub para um
+// subdiretório) no formato "<versão>_<nome>.up.sql" e "<versão>_<nome>.down.sql":
+//
+//	//go:embed migrations/*.sql
+//	var migrations embed.FS
+//
+//	sub, _ := fs.Sub(migrations, "migrations")
+//	err := database.Migrate(ctx, sub)
+//
+// Um lock consultivo garante que apenas uma instância migre por vez; cada
+// migração roda em sua própria transação. No MySQL, scripts com mais de um
+// comando exigem multiStatements=true no DSN.
+func (d *DB) Migrate(ctx context.Context, fsys fs.FS) error {
+	migrations, err := readMigrations(fsys)
+	if err != nil {
+		return err
+	}
+
+	return d.withMigrationLock(ctx, func(conn *sql.Conn) error {
+		applied, err := d.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range migrations {
+			if applied[m.version] {
+				continue
+			}
+			if err := d.applyMigration(ctx, conn, m.version, m.name, m.up, true); err != nil {
+				return err
+			}
+			logx.Ctx(ctx).Info("Migration applied", "version", m.version, "name", m.name)
+		}
+		return nil
+	})
+}
+
+// MigrateDown reverte as últimas steps migrações aplicadas, usando os scripts .down.sql.
+func (d *DB) MigrateDown(ctx context.Context, fsys fs.FS, steps int) error {
+	migrations, err := readMigrations(fsys)
+	if err != nil {
+		return err
+	}
+	byVersion := make(map[int64]migration, len(migrations))
+	for _, m := range migrations {
+		byVersion[m.version] = m
+	}
+
+	return d.withMigrationLock(ctx, func(conn *sql.Conn) error {
+		applied, err := d.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		versions := make([]int64, 0, len(applied))
+		for v := range applied {
+			versions = append(versions, v)
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+		for i := 0; i < steps && i < len(versions); i++ {
+			m, ok := byVersion[versions[i]]
+			if !ok || m.down == "" {
+				return fmt.Errorf("db: migration %d has no down script", versions[i])
+			}
+			if err := d.applyMigration(ctx, conn, m.version, m.name, m.down, false); err != nil {
+				return err
+			}
+			logx.Ctx(ctx).Info("Migration reverted", "version", m.version, "name", m.name)
+		}
+		return nil
+	})
+}
+
+// MigrationVersion devolve a maior versão aplicada, ou 0 quando nenhuma foi aplicada.
+func (d *DB) MigrationVersion(ctx context.Context) (int64, error) {
+	conn, err := d.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	applied, err := d.appliedVersions(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+
+	var current int64
+	for v := range applied {
+		current = max(current, v)
+	}
+	return current, nil
+}
+
+// readMigrations lê e ordena os scripts de migração de fsys.
+func readMigrations(fsys fs.FS) ([]migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*migration{}
+	for _, file := range files {
+		base := path.Base(file)
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		versionPart, name, _ := strings.Cut(strings.TrimSuffix(base, "."+direction+".sql"), "_")
+		version, err := strconv.ParseInt(versionPart, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("db: invalid migration file %s", file)
+		}
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{version: version, name: name}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.up = string(content)
+		} else {
+			m.down = string(content)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" {
+			return nil, fmt.Errorf("db: migration %d has no up script", m.version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+	return migrations, nil
+}
+
+// withMigrationLock executa fn em uma conexão dedicada que detém o lock consultivo.
+func (d *DB) withMigrationLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := d.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	switch d.driver {
+	case MySQL:
+		var acquired sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, -1)", migrationsLock).Scan(&acquired); err != nil {
+			return fmt.Errorf("db: migration lock: %w", err)
+		}
+		if acquired.Int64 != 1 {
+			return fmt.Errorf("db: migration lock not acquired")
+		}
+		defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?)", migrationsLock)
+	default:
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationsLockKey); err != nil {
+			return fmt.Errorf("db: migration lock: %w", err)
+		}
+		defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", migrationsLockKey)
+	}
+
+	if _, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+migrationsTable+
+		" (version BIGINT PRIMARY KEY, name VARCHAR(255) NOT NULL, applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP)"); err != nil {
+		return fmt.Errorf("db: create %s: %w", migrationsTable, err)
+	}
+
+	return fn(conn)
+}
+
+// appliedVersions devolve as versões registradas na tabela de migrações.
+func (d *DB) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]bool, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM "+migrationsTable)
+	if err != nil {
+		return nil, fmt.Errorf("db: read %s: %w", migrationsTable, err)
+	}
+	defer rows.Close()
+
+	applied := map[int64]bool{}
+	for rows.Next() {
+		var v int64
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		applied[v] = true
+	}
+	return applied, rows.Err()
+}
+
+// applyMigration executa o script e atualiza a tabela de migrações na mesma transação.
+func (d *DB) applyMigration(ctx context.Context, conn *sql.Conn, version int64, name string, script string, up bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("db: migration %d_%s: %w", version, name, err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx, d.Rebind("INSERT INTO "+migrationsTable+" (version, name) VALUES (?, ?)"), version, name)
+	} else {
+		_, err = tx.ExecContext(ctx, d.Rebind("DELETE FROM "+migrationsTable+" WHERE version = ?"), version)
+	}
+	if err != nil {
+		return fmt.Errorf("db: record migration %d: %w", version, err)
+	}
+	return tx.Commit()
+}
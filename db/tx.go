@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/resilience"
+)
+
+// TxOptions configura as transações de WithTxOptions.
+type TxOptions struct {
+	// Isolation é o nível de isolamento (padrão: o do banco).
+	Isolation sql.IsolationLevel
+	// ReadOnly abre uma transação somente leitura.
+	ReadOnly bool
+	// MaxRetries é o número de novas tentativas em falhas de serialização ou
+	// deadlock (padrão: 3; use -1 para não repetir).
+	MaxRetries int
+	// Backoff é a espera antes da primeira nova tentativa, dobrada a cada
+	// tentativa seguinte (padrão: 50ms).
+	Backoff time.Duration
+}
+
+// WithTx executa fn em uma transação com as opções padrão: commit quando fn
+// devolve nil e rollback em caso de erro ou panic. Falhas de serialização e
+// deadlocks repetem a transação inteira, portanto fn não deve ter efeitos
+// colaterais fora do banco.
+//
+//	err := database.WithTx(ctx, func(tx *sql.Tx) error {
+//		_, err := tx.ExecContext(ctx, "UPDATE accounts SET balance = balance - $1 WHERE id = $2", amount, id)
+//		return err
+//	})
+func (d *DB) WithTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return d.WithTxOptions(ctx, TxOptions{}, fn)
+}
+
+// WithTxOptions é como WithTx, com isolamento, modo somente leitura e política
+// de novas tentativas configuráveis. A transação é anotada em um span
+// "db.transaction" com o número de tentativas.
+func (d *DB) WithTxOptions(ctx context.Context, opts TxOptions, fn func(tx *sql.Tx) error) (err error) {
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = 3
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = 50 * time.Millisecond
+	}
+
+	span, ctx := dd.StartSpan(ctx, "db.transaction")
+	defer func() {
+		dd.SetSpanError(span, err)
+		dd.FinishSpan(span)
+	}()
+	dd.SetSpanTag(span, "db.system", d.driver)
+	dd.SetSpanTag(span, "db.transaction.read_only", opts.ReadOnly)
+	if opts.Isolation != sql.LevelDefault {
+		dd.SetSpanTag(span, "db.transaction.isolation", opts.Isolation.String())
+	}
+
+	retry := resilience.NewRetry(resilience.RetryOptions{
+		Name:       "db.transaction",
+		MaxRetries: opts.MaxRetries,
+		Backoff:    opts.Backoff,
+		Retryable:  IsRetryable,
+	})
+	return retry.Execute(ctx, func(ctx context.Context) error {
+		dd.SetSpanTag(span, "db.transaction.attempts", resilience.Attempt(ctx))
+		return d.runTx(ctx, opts, fn)
+	})
+}
+
+// runTx executa uma tentativa da transação, garantindo o rollback em erro ou panic.
+func (d *DB) runTx(ctx context.Context, opts TxOptions, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := d.BeginTx(ctx, &sql.TxOptions{Isolation: opts.Isolation, ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("db: begin: %w", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("db: commit: %w", err)
+	}
+	return nil
+}
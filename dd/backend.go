@@ -0,0 +1,80 @@
+package dd
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// Backend é a implementação de tracing usada pelos helpers do pacote (StartSpan,
+// SpanFromContext, Trace, InjectHeaders, ExtractContext e GinMiddleware).
+// Os spans seguem a interface tracer.Span, de modo que o código da aplicação não
+// muda ao trocar o Datadog por outro backend.
+type Backend interface {
+	StartSpan(ctx context.Context, name string, opts ...tracer.StartSpanOption) (tracer.Span, context.Context)
+	SpanFromContext(ctx context.Context) (tracer.Span, bool)
+	Inject(ctx context.Context, header http.Header) error
+	Extract(header http.Header) (ddtrace.SpanContext, error)
+}
+
+// backendHolder permite guardar implementações distintas no mesmo atomic.Value.
+type backendHolder struct {
+	Backend
+}
+
+var (
+	// backend guarda o Backend ativo; por padrão, o tracer do Datadog.
+	backend atomic.Value
+	// customBackend indica que um Backend diferente do Datadog foi configurado.
+	customBackend atomic.Bool
+)
+
+// SetBackend substitui o backend de tracing usado pelos helpers. Com nil,
+// volta a usar o tracer do Datadog.
+func SetBackend(b Backend) {
+	if b == nil {
+		b = Datadog()
+	}
+	_, isDatadog := b.(datadogBackend)
+	customBackend.Store(!isDatadog)
+	backend.Store(backendHolder{b})
+}
+
+// current devolve o backend ativo.
+func current() Backend {
+	if h, ok := backend.Load().(backendHolder); ok {
+		return h.Backend
+	}
+	return Datadog()
+}
+
+// Datadog devolve o backend padrão, baseado no tracer do Datadog iniciado por Load.
+func Datadog() Backend {
+	return datadogBackend{}
+}
+
+// datadogBackend delega ao tracer global do Datadog.
+type datadogBackend struct{}
+
+func (datadogBackend) StartSpan(ctx context.Context, name string, opts ...tracer.StartSpanOption) (tracer.Span, context.Context) {
+	return tracer.StartSpanFromContext(ctx, name, opts...)
+}
+
+func (datadogBackend) SpanFromContext(ctx context.Context) (tracer.Span, bool) {
+	return tracer.SpanFromContext(ctx)
+}
+
+func (datadogBackend) Inject(ctx context.Context, header http.Header) error {
+	span, ok := tracer.SpanFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return tracer.Inject(span.Context(), tracer.HTTPHeadersCarrier(header))
+}
+
+func (datadogBackend) Extract(header http.Header) (ddtrace.SpanContext, error) {
+	return tracer.Extract(tracer.HTTPHeadersCarrier(header))
+}
@@ -0,0 +1,39 @@
+package dd
+
+import (
+	"database/sql"
+
+	"github.com/redis/go-redis/v9"
+	sqltrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/database/sql"
+	redistrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/redis/go-redis.v9"
+)
+
+// OpenSQL abre um *sql.DB instrumentado: cada query gera um span filho do span
+// ativo no contexto (use os métodos *Context). O driver precisa estar registrado
+// (ex.: import _ "github.com/lib/pq"). O serviço padrão é "<serviço>-<driver>".
+// Os spans são emitidos pelo tracer do Datadog, independentemente de SetBackend.
+func OpenSQL(driver string, dsn string, opts ...sqltrace.Option) (*sql.DB, error) {
+	if name := ServiceName(); name != "" {
+		opts = append([]sqltrace.Option{sqltrace.WithServiceName(name + "-" + driver)}, opts...)
+	}
+	return sqltrace.Open(driver, dsn, opts...)
+}
+
+// NewRedisClient cria um cliente Redis instrumentado, em que cada comando gera
+// um span filho do span ativo no contexto. O serviço padrão é "<serviço>-redis".
+func NewRedisClient(options *redis.Options, opts ...redistrace.ClientOption) redis.UniversalClient {
+	return redistrace.NewClient(options, redisOptions(opts)...)
+}
+
+// WrapRedis instrumenta um cliente Redis já existente (simples, cluster ou sentinel).
+func WrapRedis(client redis.UniversalClient, opts ...redistrace.ClientOption) {
+	redistrace.WrapClient(client, redisOptions(opts)...)
+}
+
+// redisOptions acrescenta o nome de serviço padrão às opções informadas.
+func redisOptions(opts []redistrace.ClientOption) []redistrace.ClientOption {
+	if name := ServiceName(); name != "" {
+		return append([]redistrace.ClientOption{redistrace.WithServiceName(name + "-redis")}, opts...)
+	}
+	return opts
+}
@@ -2,27 +2,100 @@ package dd
 
 import (
 	"context"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
 	gintrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/gin-gonic/gin"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 )
 
-func Load(dd_service string, dd_env string, dd_version string) {
+var (
+	// loaded indica se Load foi chamado e o tracer está ativo.
+	loaded atomic.Bool
+	// serviceName guarda o serviço informado em Load para uso pelas integrações.
+	serviceName atomic.Value
+	// environment e version guardam o ambiente e a versão informados em Load.
+	environment atomic.Value
+	version     atomic.Value
+)
+
+// Load inicia o tracer do Datadog. As opções complementam serviço, ambiente e
+// versão; o que não for informado é lido das variáveis de ambiente DD_*.
+// Sem agente disponível, Load pode entrar no modo no-op (veja WithNoop).
+func Load(dd_service string, dd_env string, dd_version string, opts ...Option) {
+	serviceName.Store(dd_service)
+	environment.Store(dd_env)
+	version.Store(dd_version)
+
+	cfg := newConfig(opts...)
+	agentAddress.Store(cfg.agentURL())
+	setIgnoredRoutes(cfg.ignoredRoutes)
+
+	// No modo no-op o tracer não é iniciado e os spans criados são descartados.
+	if cfg.noopMode() {
+		noop.Store(true)
+		log().Info("datadog agent not configured, running in no-op mode")
+		return
+	}
+	noop.Store(false)
+	loaded.Store(true)
+
 	// Implementação fictícia para iniciar o tracer do Datadog
-	tracer.Start(
+	tracer.Start(append([]tracer.StartOption{
 		tracer.WithServiceName(dd_service),
 		tracer.WithEnv(dd_env),
 		tracer.WithServiceVersion(dd_version),
-	)
+	}, cfg.startOptions()...)...)
+
+	namespace := cfg.namespace
+	if namespace == "" {
+		namespace = dd_service
+	}
+	configureMetrics(cfg.statsdAddr, namespace, []string{
+		"service:" + dd_service,
+		"env:" + dd_env,
+		"version:" + dd_version,
+	})
+
+	// O profiler contínuo é opcional e habilitado por WithProfiling ou DD_PROFILING_ENABLED.
+	if cfg.profiling {
+		if err := StartProfiler(dd_service, dd_env, dd_version); err != nil {
+			log().Warn("failed to start datadog profiler", "error", err)
+		}
+	}
 }
 
 func Stop() {
+	loaded.Store(false)
+	StopProfiler()
+	disableRuntimeMetrics()
+	closeMetrics()
 	tracer.Stop()
 }
 
+// Enabled informa se o tracer foi iniciado via Load ou se outro backend foi configurado com SetBackend.
+func Enabled() bool {
+	return loaded.Load() || customBackend.Load()
+}
+
+// ServiceName devolve o nome de serviço informado em Load.
+func ServiceName() string {
+	name, _ := serviceName.Load().(string)
+	return name
+}
+
 func StartSpan(ctx context.Context, name string, opts ...tracer.StartSpanOption) (tracer.Span, context.Context) {
-	return tracer.StartSpanFromContext(ctx, name, opts...)
+	// As tags do contexto vêm primeiro para que as opções explícitas prevaleçam.
+	return current().StartSpan(ctx, name, append(contextTagOptions(ctx), opts...)...)
+}
+
+// SpanFromContext devolve o span ativo no contexto, se houver.
+func SpanFromContext(ctx context.Context) (tracer.Span, bool) {
+	return current().SpanFromContext(ctx)
 }
 
 func FinishSpan(span tracer.Span) {
@@ -31,10 +104,38 @@ func FinishSpan(span tracer.Span) {
 	}
 }
 
+// SetSpanError marca o span como erro e preenche as tags usadas pelo Error
+// Tracking do Datadog: error.message, error.type (o Code da errx, quando houver,
+// ou o tipo Go do erro) e error.stack. O caller e os detalhes da errx também são anotados.
 func SetSpanError(span tracer.Span, err error) {
-	if span != nil && err != nil {
-		span.SetTag("error", err)
+	if span == nil || err == nil {
+		return
 	}
+
+	span.SetTag(ext.Error, err)
+	span.SetTag(ext.ErrorMsg, err.Error())
+
+	errType := fmt.Sprintf("%T", err)
+	if errx.IsAppError(err) {
+		errType = string(errx.GetCode(err))
+		if subCode := errx.GetSubCode(err); subCode != "" {
+			span.SetTag("error.sub_code", subCode)
+		}
+		if caller := errx.GetCaller(err); caller != "" {
+			span.SetTag("error.caller", caller)
+		}
+		for key, value := range errx.GetDetails(err) {
+			span.SetTag("error.details."+key, value)
+		}
+	}
+	span.SetTag("error.origin", string(errx.GetOrigin(err)))
+	if dependency := errx.GetDependency(err); dependency != "" {
+		span.SetTag("error.dependency", dependency)
+	}
+	span.SetTag("error.alert", errx.ShouldAlert(err))
+	span.SetTag("error.slo_impact", errx.AffectsSLO(err))
+	span.SetTag(ext.ErrorType, errType)
+	span.SetTag(ext.ErrorStack, string(debug.Stack()))
 }
 
 func SetSpanTag(span tracer.Span, key string, value interface{}) {
@@ -43,6 +144,53 @@ func SetSpanTag(span tracer.Span, key string, value interface{}) {
 	}
 }
 
+// GinMiddleware instrumenta as requisições do Gin, nomeando o resource pelo
+// template da rota (ex.: "GET /users/:id") para evitar alta cardinalidade.
+// Com um backend diferente do Datadog, o span é criado pelo próprio backend.
 func GinMiddleware(service string) gin.HandlerFunc {
-	return gintrace.Middleware(service)
+	if customBackend.Load() {
+		return backendMiddleware(service)
+	}
+	return gintrace.Middleware(service,
+		gintrace.WithResourceNamer(routeResource),
+		gintrace.WithIgnoreRequest(routeIgnored),
+	)
+}
+
+// backendMiddleware instrumenta as requisições pelo backend configurado,
+// continuando o trace propagado nos cabeçalhos da requisição.
+func backendMiddleware(service string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if routeIgnored(c) {
+			c.Next()
+			return
+		}
+
+		span, ctx := StartSpanFromHeaders(c.Request.Context(), c.Request.Header, "http.request",
+			tracer.ServiceName(service),
+			tracer.Tag(ext.SpanKind, ext.SpanKindServer),
+			tracer.Tag(ext.HTTPMethod, c.Request.Method),
+			tracer.Tag(ext.HTTPURL, c.Request.URL.Path),
+		)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		span.SetOperationName(routeResource(c))
+		span.SetTag(ext.HTTPRoute, c.FullPath())
+		span.SetTag(ext.HTTPCode, c.Writer.Status())
+		if c.Writer.Status() >= 500 {
+			span.SetTag(ext.Error, true)
+		}
+		span.Finish()
+	}
+}
+
+// routeResource monta o nome do resource a partir do método e do template da rota.
+func routeResource(c *gin.Context) string {
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	return c.Request.Method + " " + route
 }
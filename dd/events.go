@@ -0,0 +1,96 @@
+package dd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// AddEvent registra um evento pontual no span com atributos estruturados,
+// sem criar um span filho. Valores de tipos não suportados pelo tracer
+// (structs, mapas, durações) são convertidos para texto. Com span nil ou
+// sem suporte a eventos, não faz nada.
+//
+//	dd.AddEvent(span, "cache.miss", map[string]any{"key": key})
+func AddEvent(span tracer.Span, name string, attrs map[string]any) {
+	addEvent(span, name, time.Now(), attrs)
+}
+
+// Time inicia um cronômetro para uma suboperação do span. A função devolvida
+// encerra a medição: registra o evento name com o atributo duration_ms (e os
+// atributos extras informados) e define a tag "<name>.duration_ms" no span.
+//
+//	stop := dd.Time(span, "db.query")
+//	rows, err := db.Query(...)
+//	stop(map[string]any{"rows": len(rows)})
+//
+// Também pode ser usado com defer: defer dd.Time(span, "db.query")().
+func Time(span tracer.Span, name string) func(attrs ...map[string]any) {
+	start := time.Now()
+	return func(attrs ...map[string]any) {
+		if span == nil {
+			return
+		}
+		elapsed := time.Since(start)
+		ms := float64(elapsed) / float64(time.Millisecond)
+
+		payload := map[string]any{}
+		for _, a := range attrs {
+			for k, v := range a {
+				payload[k] = v
+			}
+		}
+		payload["duration_ms"] = ms
+
+		addEvent(span, name, start, payload)
+		span.SetTag(name+".duration_ms", ms)
+	}
+}
+
+// TimeContext é como Time, usando o span ativo no contexto. Sem span ativo,
+// a função devolvida não faz nada.
+func TimeContext(ctx context.Context, name string) func(attrs ...map[string]any) {
+	span, ok := SpanFromContext(ctx)
+	if !ok {
+		span = nil
+	}
+	return Time(span, name)
+}
+
+func addEvent(span tracer.Span, name string, at time.Time, attrs map[string]any) {
+	if span == nil {
+		return
+	}
+	ddtrace.AddSpanEvent(span, name,
+		ddtrace.WithSpanEventTimestamp(at),
+		ddtrace.WithSpanEventAttributes(eventAttributes(attrs)),
+	)
+}
+
+// eventAttributes converte os atributos para os tipos aceitos em eventos de
+// span: texto, números, booleanos e slices desses tipos.
+func eventAttributes(attrs map[string]any) map[string]any {
+	out := make(map[string]any, len(attrs))
+	for k, v := range attrs {
+		switch val := v.(type) {
+		case nil:
+			continue
+		case string, bool,
+			int, int8, int16, int32, int64,
+			uint, uint8, uint16, uint32, uint64,
+			float32, float64,
+			[]string, []bool, []int, []int64, []float64:
+			out[k] = val
+		case time.Duration:
+			out[k] = val.String()
+		case error:
+			out[k] = val.Error()
+		default:
+			out[k] = fmt.Sprint(val)
+		}
+	}
+	return out
+}
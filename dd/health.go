@@ -0,0 +1,95 @@
+package dd
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// defaultAgentAddr é o endereço padrão do agente do Datadog.
+const defaultAgentAddr = "localhost:8126"
+
+// agentDialTimeout limita a verificação de conectividade com o agente.
+const agentDialTimeout = 500 * time.Millisecond
+
+var (
+	// noop indica que Load entrou no modo no-op e o tracer não foi iniciado.
+	noop atomic.Bool
+	// agentAddress guarda o endereço do agente resolvido em Load.
+	agentAddress atomic.Value
+)
+
+// WithNoop força (true) ou desabilita (false) o modo no-op, em que Load não
+// inicia o tracer, o profiler nem as métricas (padrão: DD_TRACE_ENABLED=false
+// força o modo). Sem a opção e sem agente configurado (DD_AGENT_HOST,
+// DD_TRACE_AGENT_URL ou WithAgentAddr), o modo é ativado automaticamente
+// quando não há agente em localhost, como no desenvolvimento local e em testes.
+func WithNoop(enabled bool) Option {
+	return func(c *config) {
+		c.noop = enabled
+		c.hasNoop = true
+	}
+}
+
+// Noop informa se Load entrou no modo no-op.
+func Noop() bool {
+	return noop.Load()
+}
+
+// Healthy informa se o agente do Datadog está acessível. Devolve false antes do
+// Load e no modo no-op; com outro backend configurado via SetBackend, devolve true.
+func Healthy() bool {
+	if customBackend.Load() {
+		return true
+	}
+	if !loaded.Load() {
+		return false
+	}
+	addr, _ := agentAddress.Load().(string)
+	return agentReachable(addr)
+}
+
+// noopMode decide se Load deve entrar no modo no-op.
+func (c *config) noopMode() bool {
+	if c.hasNoop {
+		return c.noop
+	}
+	if c.agentAddr == "" && os.Getenv("DD_TRACE_AGENT_URL") == "" {
+		return !agentReachable(defaultAgentAddr)
+	}
+	return false
+}
+
+// agentURL devolve o endereço do agente usado na verificação de saúde.
+func (c *config) agentURL() string {
+	if c.agentAddr != "" {
+		return c.agentAddr
+	}
+	if v := os.Getenv("DD_TRACE_AGENT_URL"); v != "" {
+		return v
+	}
+	return defaultAgentAddr
+}
+
+// agentReachable tenta abrir uma conexão com o agente, aceitando host:porta ou
+// URLs http:// e unix://.
+func agentReachable(addr string) bool {
+	network := "tcp"
+	if u, err := url.Parse(addr); err == nil {
+		switch {
+		case u.Scheme == "unix":
+			network, addr = "unix", u.Path
+		case u.Host != "":
+			addr = u.Host
+		}
+	}
+
+	conn, err := net.DialTimeout(network, addr, agentDialTimeout)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
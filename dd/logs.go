@@ -0,0 +1,103 @@
+package dd
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// ddLogger é o logger dos avisos do próprio pacote, configurado pelo logx em
+// Init (o dd não importa o logx, que já depende dele).
+var ddLogger atomic.Pointer[slog.Logger]
+
+// SetLogger define o logger usado pelo pacote para os seus avisos; nil volta
+// a usar o slog.Default.
+func SetLogger(l *slog.Logger) {
+	ddLogger.Store(l)
+}
+
+// log devolve o logger configurado por SetLogger ou o slog.Default.
+func log() *slog.Logger {
+	if l := ddLogger.Load(); l != nil {
+		return l
+	}
+	return slog.Default()
+}
+
+// LogFields devolve os campos de correlação entre logs e traces do span ativo no
+// contexto (dd.trace_id e dd.span_id), além de dd.service, dd.env e dd.version
+// quando o Load foi chamado. Sem span ativo, os IDs são omitidos.
+func LogFields(ctx context.Context) map[string]string {
+	fields := map[string]string{}
+
+	if Enabled() {
+		if v, _ := serviceName.Load().(string); v != "" {
+			fields["dd.service"] = v
+		}
+		if v, _ := environment.Load().(string); v != "" {
+			fields["dd.env"] = v
+		}
+		if v, _ := version.Load().(string); v != "" {
+			fields["dd.version"] = v
+		}
+	}
+
+	if ctx == nil {
+		return fields
+	}
+	if span, ok := SpanFromContext(ctx); ok {
+		fields["dd.trace_id"] = strconv.FormatUint(span.Context().TraceID(), 10)
+		fields["dd.span_id"] = strconv.FormatUint(span.Context().SpanID(), 10)
+	}
+	return fields
+}
+
+// ZapFields devolve os campos de LogFields no formato do zap.
+func ZapFields(ctx context.Context) []zap.Field {
+	fields := LogFields(ctx)
+	out := make([]zap.Field, 0, len(fields))
+	for key, value := range fields {
+		out = append(out, zap.String(key, value))
+	}
+	return out
+}
+
+// WithZap devolve um logger derivado de logger com os campos de correlação do contexto.
+func WithZap(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	return logger.With(ZapFields(ctx)...)
+}
+
+// SlogHandler envolve um slog.Handler adicionando os campos de correlação aos
+// registros emitidos com contexto (ex.: slog.InfoContext).
+//
+//	slog.SetDefault(slog.New(dd.SlogHandler(slog.NewJSONHandler(os.Stdout, nil))))
+func SlogHandler(next slog.Handler) slog.Handler {
+	return slogHandler{next: next}
+}
+
+// slogHandler injeta os campos de LogFields em cada registro.
+type slogHandler struct {
+	next slog.Handler
+}
+
+func (h slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	for key, value := range LogFields(ctx) {
+		record.AddAttrs(slog.String(key, value))
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return slogHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h slogHandler) WithGroup(name string) slog.Handler {
+	return slogHandler{next: h.next.WithGroup(name)}
+}
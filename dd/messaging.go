@@ -0,0 +1,74 @@
+package dd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// InjectMap grava em carrier o contexto do span ativo, no formato usado em
+// atributos de mensagens (SQS MessageAttributes, headers do Kafka). As chaves
+// são gravadas em minúsculas.
+func InjectMap(ctx context.Context, carrier map[string]string) error {
+	header := http.Header{}
+	if err := InjectHeaders(ctx, header); err != nil {
+		return err
+	}
+	for key := range header {
+		carrier[strings.ToLower(key)] = header.Get(key)
+	}
+	return nil
+}
+
+// ExtractMap lê de carrier o contexto de trace gravado por InjectMap ou por
+// outro produtor instrumentado, ignorando a caixa das chaves.
+func ExtractMap(carrier map[string]string) (ddtrace.SpanContext, error) {
+	header := http.Header{}
+	for key, value := range carrier {
+		header.Set(key, value)
+	}
+	return ExtractContext(header)
+}
+
+// StartProducerSpan inicia o span de publicação de uma mensagem em destination
+// (fila ou tópico) do sistema informado (ex.: "sqs", "kafka") e injeta o contexto
+// em carrier, que deve ser enviado como atributos da mensagem.
+//
+//	attrs := map[string]string{}
+//	span, ctx := dd.StartProducerSpan(ctx, "kafka", "orders", attrs)
+//	defer dd.FinishSpan(span)
+func StartProducerSpan(ctx context.Context, system string, destination string, carrier map[string]string, opts ...tracer.StartSpanOption) (tracer.Span, context.Context) {
+	span, ctx := StartSpan(ctx, system+".produce", append([]tracer.StartSpanOption{
+		tracer.ResourceName("Produce " + destination),
+		tracer.SpanType(ext.SpanTypeMessageProducer),
+		tracer.Tag(ext.SpanKind, ext.SpanKindProducer),
+		tracer.Tag(ext.MessagingSystem, system),
+		tracer.Tag("messaging.destination.name", destination),
+	}, opts...)...)
+
+	if carrier != nil {
+		_ = InjectMap(ctx, carrier)
+	}
+	return span, ctx
+}
+
+// StartConsumerSpan inicia o span de processamento de uma mensagem recebida de
+// destination, continuando o trace do produtor a partir dos atributos da mensagem.
+// Sem contexto propagado, um novo trace é iniciado.
+func StartConsumerSpan(ctx context.Context, system string, destination string, carrier map[string]string, opts ...tracer.StartSpanOption) (tracer.Span, context.Context) {
+	base := []tracer.StartSpanOption{
+		tracer.ResourceName("Consume " + destination),
+		tracer.SpanType(ext.SpanTypeMessageConsumer),
+		tracer.Tag(ext.SpanKind, ext.SpanKindConsumer),
+		tracer.Tag(ext.MessagingSystem, system),
+		tracer.Tag("messaging.destination.name", destination),
+	}
+	if sc, err := ExtractMap(carrier); err == nil {
+		base = append(base, tracer.ChildOf(sc))
+	}
+	return StartSpan(ctx, system+".consume", append(base, opts...)...)
+}
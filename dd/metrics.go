@@ -0,0 +1,136 @@
+package dd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/DataDog/datadog-go/v5/statsd"
+)
+
+// MetricsClient é a fachada de métricas customizadas sobre o dogstatsd. Todas as
+// métricas recebem o namespace e as tags padrão (service, env, version) do Load.
+// Falhas de envio são ignoradas: métricas nunca interrompem o fluxo da aplicação.
+type MetricsClient struct {
+	client statsd.ClientInterface
+}
+
+var (
+	metricsMu sync.Mutex
+	// metrics é o cliente compartilhado, criado sob demanda após o Load.
+	metrics *MetricsClient
+	// metricsAddr, metricsNamespace e metricsTags são definidos em Load.
+	metricsAddr      string
+	metricsNamespace string
+	metricsTags      []string
+)
+
+// noopMetrics é usado enquanto o Load não foi chamado ou o cliente não pôde ser criado.
+var noopMetrics = &MetricsClient{client: &statsd.NoOpClient{}}
+
+// Metrics devolve o cliente de métricas compartilhado. Antes do Load, devolve um
+// cliente que descarta as métricas, de modo que os pacotes possam emiti-las sem verificações.
+func Metrics() *MetricsClient {
+	if !Enabled() {
+		return noopMetrics
+	}
+
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+
+	if metrics == nil {
+		// Com endereço vazio, o cliente usa DD_DOGSTATSD_URL ou DD_AGENT_HOST/DD_DOGSTATSD_PORT.
+		client, err := statsd.New(metricsAddr,
+			statsd.WithNamespace(metricsNamespace),
+			statsd.WithTags(metricsTags),
+		)
+		if err != nil {
+			log().Warn("failed to create datadog statsd client", "error", err)
+			return noopMetrics
+		}
+		metrics = &MetricsClient{client: client}
+	}
+	return metrics
+}
+
+// configureMetrics guarda a configuração usada na criação do cliente de métricas.
+func configureMetrics(addr, namespace string, tags []string) {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+
+	metricsAddr = addr
+	metricsNamespace = namespace
+	metricsTags = tags
+}
+
+// closeMetrics descarrega e encerra o cliente de métricas, se criado.
+func closeMetrics() {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+
+	if metrics != nil {
+		_ = metrics.client.Close()
+		metrics = nil
+	}
+}
+
+// Count soma value ao contador name.
+func (m *MetricsClient) Count(name string, value int64, tags ...string) {
+	_ = m.client.Count(name, value, tags, 1)
+}
+
+// Incr incrementa o contador name em uma unidade.
+func (m *MetricsClient) Incr(name string, tags ...string) {
+	_ = m.client.Incr(name, tags, 1)
+}
+
+// Gauge registra o valor atual de name.
+func (m *MetricsClient) Gauge(name string, value float64, tags ...string) {
+	_ = m.client.Gauge(name, value, tags, 1)
+}
+
+// Histogram registra uma amostra de name, agregada pelo agente.
+func (m *MetricsClient) Histogram(name string, value float64, tags ...string) {
+	_ = m.client.Histogram(name, value, tags, 1)
+}
+
+// Distribution registra uma amostra de name, agregada globalmente pelo Datadog.
+func (m *MetricsClient) Distribution(name string, value float64, tags ...string) {
+	_ = m.client.Distribution(name, value, tags, 1)
+}
+
+// Timing registra a duração d em name.
+func (m *MetricsClient) Timing(name string, d time.Duration, tags ...string) {
+	_ = m.client.Timing(name, d, tags, 1)
+}
+
+// Timer inicia a medição de name e devolve a função que registra o tempo decorrido.
+//
+//	defer dd.Metrics().Timer("checkout.duration")()
+func (m *MetricsClient) Timer(name string, tags ...string) func() {
+	start := time.Now()
+	return func() {
+		m.Timing(name, time.Since(start), tags...)
+	}
+}
+
+// ServiceCheckStatus é o estado enviado em ServiceCheck.
+type ServiceCheckStatus = statsd.ServiceCheckStatus
+
+// Estados aceitos por ServiceCheck.
+const (
+	CheckOK       = statsd.Ok
+	CheckWarning  = statsd.Warn
+	CheckCritical = statsd.Critical
+	CheckUnknown  = statsd.Unknown
+)
+
+// ServiceCheck envia o estado de um service check do Datadog (ex.: a saúde de
+// uma dependência), com uma mensagem opcional.
+func (m *MetricsClient) ServiceCheck(name string, status ServiceCheckStatus, message string, tags ...string) {
+	_ = m.client.ServiceCheck(&statsd.ServiceCheck{
+		Name:    name,
+		Status:  status,
+		Message: message,
+		Tags:    tags,
+	})
+}
@@ -0,0 +1,189 @@
+package dd
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// Option personaliza a inicialização do tracer em Load.
+type Option func(*config)
+
+// config reúne as configurações do tracer. Valores não informados via Option
+// são lidos das variáveis de ambiente padrão do Datadog.
+type config struct {
+	agentAddr      string
+	sampleRate     float64
+	hasSampleRate  bool
+	samplingRules  []tracer.SamplingRule
+	runtimeMetrics bool
+	debug          bool
+	analytics      bool
+	profiling      bool
+	globalTags     map[string]interface{}
+	statsdAddr     string
+	namespace      string
+	noop           bool
+	hasNoop        bool
+	ignoredRoutes  []string
+}
+
+// WithAgentAddr define o endereço host:porta do agente (padrão: DD_AGENT_HOST e DD_TRACE_AGENT_PORT).
+func WithAgentAddr(addr string) Option {
+	return func(c *config) {
+		c.agentAddr = addr
+	}
+}
+
+// WithSampleRate define a taxa de amostragem padrão, entre 0 e 1 (padrão: DD_TRACE_SAMPLE_RATE).
+func WithSampleRate(rate float64) Option {
+	return func(c *config) {
+		c.sampleRate = rate
+		c.hasSampleRate = true
+	}
+}
+
+// WithSamplingRules define regras de amostragem por serviço, operação ou resource,
+// avaliadas antes da taxa padrão.
+func WithSamplingRules(rules ...tracer.SamplingRule) Option {
+	return func(c *config) {
+		c.samplingRules = append(c.samplingRules, rules...)
+	}
+}
+
+// WithRuntimeMetrics habilita o envio de métricas do runtime Go (padrão: DD_RUNTIME_METRICS_ENABLED).
+func WithRuntimeMetrics(enabled bool) Option {
+	return func(c *config) {
+		c.runtimeMetrics = enabled
+	}
+}
+
+// WithDebug habilita o modo de depuração do tracer (padrão: DD_TRACE_DEBUG).
+func WithDebug(enabled bool) Option {
+	return func(c *config) {
+		c.debug = enabled
+	}
+}
+
+// WithAnalytics habilita o App Analytics para todas as integrações (padrão: DD_TRACE_ANALYTICS_ENABLED).
+func WithAnalytics(enabled bool) Option {
+	return func(c *config) {
+		c.analytics = enabled
+	}
+}
+
+// WithProfiling habilita o profiler contínuo (padrão: DD_PROFILING_ENABLED).
+func WithProfiling(enabled bool) Option {
+	return func(c *config) {
+		c.profiling = enabled
+	}
+}
+
+// WithGlobalTag adiciona uma tag a todos os spans (padrão: DD_TAGS, no formato "k1:v1,k2:v2").
+func WithGlobalTag(key string, value interface{}) Option {
+	return func(c *config) {
+		c.globalTags[key] = value
+	}
+}
+
+// WithStatsdAddr define o endereço do dogstatsd usado por Metrics
+// (padrão: DD_DOGSTATSD_URL ou DD_AGENT_HOST e DD_DOGSTATSD_PORT).
+func WithStatsdAddr(addr string) Option {
+	return func(c *config) {
+		c.statsdAddr = addr
+	}
+}
+
+// WithMetricsNamespace define o prefixo das métricas emitidas por Metrics
+// (padrão: DD_METRICS_NAMESPACE ou o nome do serviço).
+func WithMetricsNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// newConfig carrega os valores das variáveis de ambiente e aplica as opções informadas.
+func newConfig(opts ...Option) *config {
+	c := &config{
+		runtimeMetrics: envBool("DD_RUNTIME_METRICS_ENABLED"),
+		debug:          envBool("DD_TRACE_DEBUG"),
+		analytics:      envBool("DD_TRACE_ANALYTICS_ENABLED"),
+		profiling:      ProfilingEnabled(),
+		globalTags:     map[string]interface{}{},
+		namespace:      os.Getenv("DD_METRICS_NAMESPACE"),
+	}
+
+	if v := os.Getenv("DD_TRACE_ENABLED"); v != "" {
+		if enabled, err := strconv.ParseBool(v); err == nil && !enabled {
+			c.noop = true
+			c.hasNoop = true
+		}
+	}
+
+	if host := os.Getenv("DD_AGENT_HOST"); host != "" {
+		port := os.Getenv("DD_TRACE_AGENT_PORT")
+		if port == "" {
+			port = "8126"
+		}
+		c.agentAddr = net.JoinHostPort(host, port)
+	}
+
+	if v := os.Getenv("DD_TRACE_SAMPLE_RATE"); v != "" {
+		if rate, err := strconv.ParseFloat(v, 64); err == nil {
+			c.sampleRate = rate
+			c.hasSampleRate = true
+		}
+	}
+
+	for _, tag := range strings.FieldsFunc(os.Getenv("DD_TAGS"), func(r rune) bool { return r == ',' || r == ' ' }) {
+		if key, value, ok := strings.Cut(tag, ":"); ok && key != "" {
+			c.globalTags[key] = value
+		}
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// startOptions converte a configuração nas opções do tracer.
+func (c *config) startOptions() []tracer.StartOption {
+	var opts []tracer.StartOption
+
+	if c.agentAddr != "" {
+		opts = append(opts, tracer.WithAgentAddr(c.agentAddr))
+	}
+
+	rules := c.samplingRules
+	if c.hasSampleRate {
+		// A taxa padrão é a última regra, aplicada quando nenhuma outra casa.
+		rules = append(rules[:len(rules):len(rules)], tracer.RateRule(c.sampleRate))
+	}
+	if len(rules) > 0 {
+		opts = append(opts, tracer.WithSamplingRules(rules))
+	}
+
+	if c.runtimeMetrics {
+		opts = append(opts, tracer.WithRuntimeMetrics())
+	}
+	if c.debug {
+		opts = append(opts, tracer.WithDebugMode(true))
+	}
+	if c.analytics {
+		opts = append(opts, tracer.WithAnalytics(true))
+	}
+	for key, value := range c.globalTags {
+		opts = append(opts, tracer.WithGlobalTag(key, value))
+	}
+	return opts
+}
+
+// envBool interpreta a variável de ambiente como booleano, considerando false quando ausente ou inválida.
+func envBool(key string) bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(key))
+	return enabled
+}
@@ -0,0 +1,210 @@
+package dd
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// instrumentationName identifica o pacote como fonte dos spans no OpenTelemetry.
+const instrumentationName = "github.com/nathanribeiroo/module-dep-projects/dd"
+
+// OpenTelemetry devolve um backend que emite os spans pelo TracerProvider
+// informado (ex.: exportando para um OTel Collector). Com tp nil, usa o
+// provider global. A propagação usa o propagador global do OpenTelemetry.
+//
+//	dd.SetBackend(dd.OpenTelemetry(provider))
+//
+// Baggage do Datadog não é suportado: BaggageItem devolve sempre vazio.
+func OpenTelemetry(tp trace.TracerProvider) Backend {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return otelBackend{tracer: tp.Tracer(instrumentationName)}
+}
+
+// otelBackend adapta o OpenTelemetry à interface Backend.
+type otelBackend struct {
+	tracer trace.Tracer
+}
+
+func (b otelBackend) StartSpan(ctx context.Context, name string, opts ...tracer.StartSpanOption) (tracer.Span, context.Context) {
+	var cfg ddtrace.StartSpanConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// Assim como no Datadog, o span do contexto tem precedência sobre ChildOf.
+	if parent, ok := cfg.Parent.(otelSpanContext); ok && !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, parent.sc)
+	}
+
+	startOpts := []trace.SpanStartOption{trace.WithAttributes(attributesOf(cfg.Tags)...)}
+	if !cfg.StartTime.IsZero() {
+		startOpts = append(startOpts, trace.WithTimestamp(cfg.StartTime))
+	}
+	if kind, ok := cfg.Tags[ext.SpanKind].(string); ok {
+		startOpts = append(startOpts, trace.WithSpanKind(trace.ValidateSpanKind(spanKinds[kind])))
+	}
+
+	ctx, span := b.tracer.Start(ctx, name, startOpts...)
+	return otelSpan{span: span}, ctx
+}
+
+func (otelBackend) SpanFromContext(ctx context.Context) (tracer.Span, bool) {
+	span := trace.SpanFromContext(ctx)
+	return otelSpan{span: span}, span.SpanContext().IsValid()
+}
+
+func (otelBackend) Inject(ctx context.Context, header http.Header) error {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+	return nil
+}
+
+func (otelBackend) Extract(header http.Header) (ddtrace.SpanContext, error) {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(header))
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil, tracer.ErrSpanContextNotFound
+	}
+	return otelSpanContext{sc: sc}, nil
+}
+
+// spanKinds converte os valores de ext.SpanKind para o OpenTelemetry.
+var spanKinds = map[string]trace.SpanKind{
+	ext.SpanKindServer:   trace.SpanKindServer,
+	ext.SpanKindClient:   trace.SpanKindClient,
+	ext.SpanKindProducer: trace.SpanKindProducer,
+	ext.SpanKindConsumer: trace.SpanKindConsumer,
+	ext.SpanKindInternal: trace.SpanKindInternal,
+}
+
+// otelSpan adapta um span do OpenTelemetry à interface tracer.Span.
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s otelSpan) SetTag(key string, value interface{}) {
+	if key == ext.Error {
+		switch v := value.(type) {
+		case error:
+			s.span.RecordError(v)
+			s.span.SetStatus(codes.Error, v.Error())
+		case bool:
+			if v {
+				s.span.SetStatus(codes.Error, "")
+			}
+		}
+		return
+	}
+	s.span.SetAttributes(attributeOf(key, value))
+}
+
+func (s otelSpan) SetOperationName(name string) {
+	s.span.SetName(name)
+}
+
+func (s otelSpan) BaggageItem(string) string {
+	return ""
+}
+
+func (s otelSpan) SetBaggageItem(string, string) {}
+
+func (s otelSpan) Finish(opts ...tracer.FinishOption) {
+	var cfg ddtrace.FinishConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.Error != nil {
+		s.span.RecordError(cfg.Error, trace.WithStackTrace(!cfg.NoDebugStack))
+		s.span.SetStatus(codes.Error, cfg.Error.Error())
+	}
+
+	var endOpts []trace.SpanEndOption
+	if !cfg.FinishTime.IsZero() {
+		endOpts = append(endOpts, trace.WithTimestamp(cfg.FinishTime))
+	}
+	s.span.End(endOpts...)
+}
+
+// AddEvent registra um evento no span do OpenTelemetry, permitindo que
+// ddtrace.AddSpanEvent funcione também com esse backend.
+func (s otelSpan) AddEvent(name string, opts ...ddtrace.SpanEventOption) {
+	var cfg ddtrace.SpanEventConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(cfg.Attributes))
+	for k, v := range cfg.Attributes {
+		attrs = append(attrs, attributeOf(k, v))
+	}
+	eventOpts := []trace.EventOption{trace.WithAttributes(attrs...)}
+	if !cfg.Time.IsZero() {
+		eventOpts = append(eventOpts, trace.WithTimestamp(cfg.Time))
+	}
+	s.span.AddEvent(name, eventOpts...)
+}
+
+func (s otelSpan) Context() ddtrace.SpanContext {
+	return otelSpanContext{sc: s.span.SpanContext()}
+}
+
+// otelSpanContext adapta o SpanContext do OpenTelemetry. Os IDs de 64 bits
+// correspondem aos 64 bits menos significativos dos IDs do OpenTelemetry,
+// o mesmo critério usado pelo Datadog na correlação com traces W3C.
+type otelSpanContext struct {
+	sc trace.SpanContext
+}
+
+func (c otelSpanContext) SpanID() uint64 {
+	id := c.sc.SpanID()
+	return binary.BigEndian.Uint64(id[:])
+}
+
+func (c otelSpanContext) TraceID() uint64 {
+	id := c.sc.TraceID()
+	return binary.BigEndian.Uint64(id[8:])
+}
+
+func (c otelSpanContext) ForeachBaggageItem(func(k, v string) bool) {}
+
+// attributesOf converte as tags do span em atributos do OpenTelemetry.
+func attributesOf(tags map[string]interface{}) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(tags))
+	for key, value := range tags {
+		attrs = append(attrs, attributeOf(key, value))
+	}
+	return attrs
+}
+
+// attributeOf converte uma tag em atributo, preservando os tipos suportados.
+func attributeOf(key string, value interface{}) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	case error:
+		return attribute.String(key, v.Error())
+	default:
+		return attribute.String(key, fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,50 @@
+package dd
+
+import (
+	"sync/atomic"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/profiler"
+)
+
+// profiling indica se o profiler contínuo foi iniciado.
+var profiling atomic.Bool
+
+// ProfilingEnabled informa se o profiler contínuo deve ser iniciado por Load,
+// conforme a variável de ambiente DD_PROFILING_ENABLED.
+func ProfilingEnabled() bool {
+	return envBool("DD_PROFILING_ENABLED")
+}
+
+// StartProfiler inicia o profiler contínuo do Datadog coletando perfis de CPU,
+// heap, goroutines e mutex. Perfis de bloqueio podem ser incluídos com
+// DD_PROFILING_BLOCK_ENABLED=true, pois têm custo maior.
+func StartProfiler(service string, env string, version string) error {
+	types := []profiler.ProfileType{
+		profiler.CPUProfile,
+		profiler.HeapProfile,
+		profiler.GoroutineProfile,
+		profiler.MutexProfile,
+	}
+	if envBool("DD_PROFILING_BLOCK_ENABLED") {
+		types = append(types, profiler.BlockProfile)
+	}
+
+	err := profiler.Start(
+		profiler.WithService(service),
+		profiler.WithEnv(env),
+		profiler.WithVersion(version),
+		profiler.WithProfileTypes(types...),
+	)
+	if err != nil {
+		return err
+	}
+	profiling.Store(true)
+	return nil
+}
+
+// StopProfiler encerra o profiler contínuo, se estiver ativo.
+func StopProfiler() {
+	if profiling.CompareAndSwap(true, false) {
+		profiler.Stop()
+	}
+}
@@ -0,0 +1,35 @@
+package dd
+
+import (
+	"context"
+	"net/http"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// InjectHeaders grava nos cabeçalhos o contexto do span ativo em ctx, para que o
+// serviço chamado continue o mesmo trace. Sem span ativo, os cabeçalhos não são alterados.
+func InjectHeaders(ctx context.Context, header http.Header) error {
+	return current().Inject(ctx, header)
+}
+
+// ExtractContext lê dos cabeçalhos o contexto de trace propagado pelo serviço de
+// origem. O resultado é usado como pai do novo span:
+//
+//	sc, err := dd.ExtractContext(r.Header)
+//	span, ctx := dd.StartSpan(ctx, "consumer", tracer.ChildOf(sc))
+//
+// Quando não há contexto propagado, devolve tracer.ErrSpanContextNotFound.
+func ExtractContext(header http.Header) (ddtrace.SpanContext, error) {
+	return current().Extract(header)
+}
+
+// StartSpanFromHeaders inicia um span continuando o trace propagado nos
+// cabeçalhos ou, se não houver, um novo trace.
+func StartSpanFromHeaders(ctx context.Context, header http.Header, name string, opts ...tracer.StartSpanOption) (tracer.Span, context.Context) {
+	if sc, err := ExtractContext(header); err == nil {
+		opts = append(opts, tracer.ChildOf(sc))
+	}
+	return StartSpan(ctx, name, opts...)
+}
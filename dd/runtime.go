@@ -0,0 +1,87 @@
+package dd
+
+import (
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+)
+
+// runtimeMetricsInterval é o intervalo entre as coletas de métricas do runtime.
+const runtimeMetricsInterval = 10 * time.Second
+
+var (
+	runtimeMu sync.Mutex
+	// runtimeStop encerra a coleta iniciada por EnableRuntimeMetrics.
+	runtimeStop chan struct{}
+)
+
+// EnableRuntimeMetrics passa a emitir, via Metrics, gauges do runtime Go a cada
+// 10 segundos: heap, pausas de GC, goroutines e threads. As métricas recebem o
+// namespace e as tags do Load. Chamadas repetidas não iniciam nova coleta; a
+// coleta é encerrada por Stop. Alternativamente, WithRuntimeMetrics habilita as
+// métricas de runtime nativas do tracer.
+func EnableRuntimeMetrics() {
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+
+	if runtimeStop != nil {
+		return
+	}
+	runtimeStop = make(chan struct{})
+	go collectRuntimeMetrics(runtimeStop)
+}
+
+// disableRuntimeMetrics encerra a coleta de métricas do runtime, se ativa.
+func disableRuntimeMetrics() {
+	runtimeMu.Lock()
+	defer runtimeMu.Unlock()
+
+	if runtimeStop != nil {
+		close(runtimeStop)
+		runtimeStop = nil
+	}
+}
+
+// collectRuntimeMetrics emite as métricas periodicamente até stop ser fechado.
+func collectRuntimeMetrics(stop chan struct{}) {
+	ticker := time.NewTicker(runtimeMetricsInterval)
+	defer ticker.Stop()
+
+	var lastNumGC uint32
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			lastNumGC = emitRuntimeMetrics(lastNumGC)
+		}
+	}
+}
+
+// emitRuntimeMetrics emite uma coleta e devolve o total de ciclos de GC observados.
+func emitRuntimeMetrics(lastNumGC uint32) uint32 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	m := Metrics()
+	m.Gauge("runtime.go.heap_alloc", float64(stats.HeapAlloc))
+	m.Gauge("runtime.go.heap_inuse", float64(stats.HeapInuse))
+	m.Gauge("runtime.go.heap_objects", float64(stats.HeapObjects))
+	m.Gauge("runtime.go.sys", float64(stats.Sys))
+	m.Gauge("runtime.go.num_gc", float64(stats.NumGC))
+	m.Gauge("runtime.go.gc_cpu_fraction", stats.GCCPUFraction)
+	m.Gauge("runtime.go.goroutines", float64(runtime.NumGoroutine()))
+	m.Gauge("runtime.go.threads", float64(pprof.Lookup("threadcreate").Count()))
+
+	// PauseNs é um buffer circular com as últimas 256 pausas.
+	count := stats.NumGC - lastNumGC
+	if count > uint32(len(stats.PauseNs)) {
+		count = uint32(len(stats.PauseNs))
+	}
+	for i := uint32(0); i < count; i++ {
+		pause := stats.PauseNs[(stats.NumGC-i+255)%256]
+		m.Histogram("runtime.go.gc_pause_ns", float64(pause))
+	}
+	return stats.NumGC
+}
@@ -0,0 +1,57 @@
+package dd
+
+import (
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// ignoredRoutes guarda as rotas que não geram spans no GinMiddleware.
+var ignoredRoutes atomic.Value
+
+// WithRouteSampleRate define a taxa de amostragem (entre 0 e 1) dos traces de
+// uma rota HTTP, identificada pelo template (ex.: "/payments" ou "/users/:id").
+// Aceita curingas no estilo glob ("/payments/*"). Regras mais específicas devem
+// ser informadas primeiro, pois a primeira que casar é aplicada.
+//
+//	dd.Load(service, env, version,
+//		dd.WithRouteSampleRate("/payments*", 1),
+//		dd.WithRouteSampleRate("/healthcheck", 0.05),
+//	)
+func WithRouteSampleRate(route string, rate float64) Option {
+	return WithSamplingRules(tracer.TagsResourceRule(nil, "* "+route, "", "", rate))
+}
+
+// WithOperationSampleRate define a taxa de amostragem dos traces cuja operação
+// raiz casa com operation (ex.: "kafka.consume"), aceitando curingas no estilo glob.
+func WithOperationSampleRate(operation string, rate float64) Option {
+	return WithSamplingRules(tracer.TagsResourceRule(nil, "", operation, "", rate))
+}
+
+// WithIgnoredRoutes remove completamente dos traces as rotas informadas (ex.:
+// "/healthcheck", "/readiness"): o GinMiddleware não cria spans para elas.
+func WithIgnoredRoutes(routes ...string) Option {
+	return func(c *config) {
+		c.ignoredRoutes = append(c.ignoredRoutes, routes...)
+	}
+}
+
+// setIgnoredRoutes registra as rotas ignoradas pelo GinMiddleware.
+func setIgnoredRoutes(routes []string) {
+	set := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		set[route] = true
+	}
+	ignoredRoutes.Store(set)
+}
+
+// routeIgnored informa se a requisição pertence a uma rota ignorada, comparando
+// o template da rota e o caminho requisitado.
+func routeIgnored(c *gin.Context) bool {
+	set, _ := ignoredRoutes.Load().(map[string]bool)
+	if len(set) == 0 {
+		return false
+	}
+	return set[c.FullPath()] || set[c.Request.URL.Path]
+}
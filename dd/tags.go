@@ -0,0 +1,47 @@
+package dd
+
+import (
+	"context"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// contextTagsKey é a chave do contexto onde as tags propagadas são armazenadas.
+type contextTagsKey struct{}
+
+// WithTag devolve um contexto que carrega a tag informada (ex.: user id, tenant,
+// correlation id). A tag é aplicada ao span ativo no contexto e a todos os spans
+// criados a partir dele por StartSpan, Trace e StartAutoSpan, sem precisar
+// anotar cada span manualmente.
+func WithTag(ctx context.Context, key string, value interface{}) context.Context {
+	parent := ContextTags(ctx)
+	tags := make(map[string]interface{}, len(parent)+1)
+	for k, v := range parent {
+		tags[k] = v
+	}
+	tags[key] = value
+
+	if span, ok := SpanFromContext(ctx); ok {
+		span.SetTag(key, value)
+	}
+	return context.WithValue(ctx, contextTagsKey{}, tags)
+}
+
+// ContextTags devolve as tags acumuladas no contexto por WithTag.
+func ContextTags(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+	tags, _ := ctx.Value(contextTagsKey{}).(map[string]interface{})
+	return tags
+}
+
+// contextTagOptions converte as tags do contexto em opções de início de span.
+func contextTagOptions(ctx context.Context) []tracer.StartSpanOption {
+	tags := ContextTags(ctx)
+	opts := make([]tracer.StartSpanOption, 0, len(tags))
+	for key, value := range tags {
+		opts = append(opts, tracer.Tag(key, value))
+	}
+	return opts
+}
@@ -0,0 +1,62 @@
+package dd
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// Trace executa fn dentro de um span chamado operation. O contexto repassado a
+// fn carrega o novo span; o erro devolvido é registrado no span com a stack
+// trace e o span é finalizado ao término, inclusive em caso de panic.
+//
+//	err := dd.Trace(ctx, "billing.charge", func(ctx context.Context) error {
+//		return charge(ctx, order)
+//	})
+func Trace(ctx context.Context, operation string, fn func(ctx context.Context) error, opts ...tracer.StartSpanOption) (err error) {
+	span, ctx := StartSpan(ctx, operation, opts...)
+	defer func() {
+		if r := recover(); r != nil {
+			SetSpanError(span, fmt.Errorf("panic: %v", r))
+			span.Finish()
+			panic(r)
+		}
+		SetSpanError(span, err)
+		span.Finish()
+	}()
+
+	return fn(ctx)
+}
+
+// StartAutoSpan inicia um span cujo nome de operação e resource são derivados da
+// função chamadora (ex.: "users.Repository.Find"), evitando nomes divergentes
+// entre handlers e repositórios.
+//
+//	span, ctx := dd.StartAutoSpan(ctx)
+//	defer dd.FinishSpan(span)
+func StartAutoSpan(ctx context.Context, opts ...tracer.StartSpanOption) (tracer.Span, context.Context) {
+	name := callerName(2)
+	return StartSpan(ctx, name, append([]tracer.StartSpanOption{tracer.ResourceName(name)}, opts...)...)
+}
+
+// callerName devolve o nome curto da função skip níveis acima na pilha, no
+// formato "pacote.Tipo.Método", sem o caminho do módulo e sem ponteiros.
+func callerName(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown"
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "unknown"
+	}
+
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.NewReplacer("(*", "", "(", "", ")", "").Replace(name)
+}
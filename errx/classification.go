@@ -0,0 +1,46 @@
+package errx
+
+// WithAlert indica se o erro deve acionar alertas (page). Erros de negócio
+// esperados podem usar WithAlert(false) para não acordar ninguém.
+func (e *AppError) WithAlert(alert bool) *AppError {
+	e.alert = &alert
+	return e
+}
+
+// WithSLOImpact indica se o erro consome o orçamento de erro (SLO) do serviço.
+func (e *AppError) WithSLOImpact(impact bool) *AppError {
+	e.sloImpact = &impact
+	return e
+}
+
+// ShouldAlert informa se o erro deve acionar alertas. Sem classificação
+// explícita, erros 5xx e erros que não são AppError alertam.
+func ShouldAlert(err error) bool {
+	if err == nil {
+		return false
+	}
+	appErr, ok := asAppError(err)
+	if !ok {
+		return true
+	}
+	if appErr.alert != nil {
+		return *appErr.alert
+	}
+	return ToHTTPCode(appErr.Code) >= 500
+}
+
+// AffectsSLO informa se o erro consome o orçamento de erro. Sem classificação
+// explícita, erros 5xx e erros que não são AppError contam contra o SLO.
+func AffectsSLO(err error) bool {
+	if err == nil {
+		return false
+	}
+	appErr, ok := asAppError(err)
+	if !ok {
+		return true
+	}
+	if appErr.sloImpact != nil {
+		return *appErr.sloImpact
+	}
+	return ToHTTPCode(appErr.Code) >= 500
+}
@@ -0,0 +1,122 @@
+package errx
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// subCodePattern é o formato aceito para sub-códigos: prefixo do domínio e número (ex.: "PAY-4012").
+var subCodePattern = regexp.MustCompile(`^[A-Z][A-Z0-9]*-[0-9]+$`)
+
+// Definition descreve um sub-código do catálogo, usado pelo atendimento para
+// rastrear o erro exato visto pelo cliente.
+type Definition struct {
+	// ID é o sub-código no formato PREFIXO-NÚMERO (ex.: "PAY-4012").
+	ID string
+	// Code é a categoria do erro; quando vazio, é derivado de Status.
+	Code Code
+	// Status é o status HTTP da resposta; quando zero, é derivado de Code.
+	Status int
+	// Description explica o erro para quem consulta o catálogo.
+	Description string
+}
+
+// codeCatalog guarda os sub-códigos registrados e os problemas encontrados
+// no registro, reportados por ValidateCodes.
+var codeCatalog = struct {
+	sync.RWMutex
+	defs     map[string]Definition
+	problems []error
+}{defs: map[string]Definition{}}
+
+// RegisterCodes registra sub-códigos no catálogo. Pode ser chamado em init();
+// IDs inválidos ou duplicados não interrompem o registro, mas são reportados
+// por ValidateCodes na inicialização do serviço.
+//
+//	func init() {
+//		errx.RegisterCodes(errx.Definition{ID: "PAY-4012", Code: errx.CONFLICT, Description: "pagamento já processado"})
+//	}
+func RegisterCodes(defs ...Definition) {
+	codeCatalog.Lock()
+	defer codeCatalog.Unlock()
+
+	for _, def := range defs {
+		if !subCodePattern.MatchString(def.ID) {
+			codeCatalog.problems = append(codeCatalog.problems, fmt.Errorf("errx: invalid sub-code %q", def.ID))
+			continue
+		}
+		if def.Code == "" && def.Status == 0 {
+			codeCatalog.problems = append(codeCatalog.problems, fmt.Errorf("errx: sub-code %s: code or status is required", def.ID))
+			continue
+		}
+		if def.Status != 0 && (def.Status < 400 || def.Status > 599) {
+			codeCatalog.problems = append(codeCatalog.problems, fmt.Errorf("errx: sub-code %s: invalid status %d", def.ID, def.Status))
+			continue
+		}
+		if def.Code == "" {
+			def.Code = StatusToCode(def.Status)
+		}
+		if def.Status == 0 {
+			def.Status = ToHTTPCode(def.Code)
+		}
+		if existing, ok := codeCatalog.defs[def.ID]; ok {
+			if existing != def {
+				codeCatalog.problems = append(codeCatalog.problems, fmt.Errorf("errx: sub-code %s registered twice with different definitions", def.ID))
+			}
+			continue
+		}
+		codeCatalog.defs[def.ID] = def
+	}
+}
+
+// ValidateCodes devolve os problemas encontrados no registro do catálogo
+// (IDs inválidos ou colisões), ou nil quando o catálogo é consistente.
+func ValidateCodes() error {
+	codeCatalog.RLock()
+	defer codeCatalog.RUnlock()
+
+	return errors.Join(codeCatalog.problems...)
+}
+
+// LookupCode devolve a definição registrada para o sub-código.
+func LookupCode(id string) (Definition, bool) {
+	codeCatalog.RLock()
+	defer codeCatalog.RUnlock()
+
+	def, ok := codeCatalog.defs[id]
+	return def, ok
+}
+
+// Codes devolve o catálogo de sub-códigos ordenado por ID.
+func Codes() []Definition {
+	codeCatalog.RLock()
+	defer codeCatalog.RUnlock()
+
+	out := make([]Definition, 0, len(codeCatalog.defs))
+	for _, def := range codeCatalog.defs {
+		out = append(out, def)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// WithSubCode associa um sub-código do catálogo ao erro. Quando o erro ainda
+// não tem Code, herda o Code da definição registrada.
+func (e *AppError) WithSubCode(id string) *AppError {
+	e.SubCode = id
+	if def, ok := LookupCode(id); ok {
+		e.WithCode(def.Code)
+	}
+	return e
+}
+
+// GetSubCode extrai o sub-código da AppError, se houver.
+func GetSubCode(err error) string {
+	if appErr, ok := asAppError(err); ok {
+		return appErr.SubCode
+	}
+	return ""
+}
@@ -0,0 +1,28 @@
+package errx
+
+import (
+	"context"
+	"errors"
+)
+
+// FromContextErr converte o erro de um contexto encerrado em AppError:
+// context.DeadlineExceeded vira TIMEOUT (504) e context.Canceled vira
+// CLIENT_CLOSED (499). A causa continua acessível por errors.Is. Outros erros
+// são devolvidos sem alteração.
+//
+//	if err := ctx.Err(); err != nil {
+//		return errx.FromContextErr(err)
+//	}
+func FromContextErr(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case IsAppError(err):
+		return err
+	case errors.Is(err, context.DeadlineExceeded):
+		return New("request deadline exceeded").WithCode(TIMEOUT).WithError(err)
+	case errors.Is(err, context.Canceled):
+		return New("request canceled by client").WithCode(CLIENT_CLOSED).WithError(err)
+	}
+	return err
+}
@@ -0,0 +1,134 @@
+package errx
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// HTTPEncoder converte o payload padronizado de erro no corpo da resposta
+// HTTP, permitindo manter formatos esperados por consumidores antigos.
+type HTTPEncoder interface {
+	// ContentType devolve o Content-Type do corpo gerado.
+	ContentType() string
+	// Encode devolve o corpo serializável para o status e o payload do erro.
+	Encode(status int, payload *ShowLogger) interface{}
+}
+
+var (
+	// DefaultEncoder gera o envelope padrão {"error": {...}}.
+	DefaultEncoder HTTPEncoder = defaultEncoder{}
+	// LegacyEncoder gera o formato antigo {"codigo", "mensagem", "detalhes"}.
+	LegacyEncoder HTTPEncoder = legacyEncoder{}
+	// ProblemEncoder gera o formato RFC 7807 (application/problem+json).
+	ProblemEncoder HTTPEncoder = problemEncoder{}
+)
+
+// httpEncoder é o encoder usado por EncodeHttpLogger e AbortWithError.
+var httpEncoder atomic.Pointer[HTTPEncoder]
+
+// SetHTTPEncoder define o formato das respostas de erro do serviço; nil
+// restaura o DefaultEncoder.
+func SetHTTPEncoder(enc HTTPEncoder) {
+	if enc == nil {
+		enc = DefaultEncoder
+	}
+	httpEncoder.Store(&enc)
+}
+
+// GetHTTPEncoder devolve o encoder configurado.
+func GetHTTPEncoder() HTTPEncoder {
+	if enc := httpEncoder.Load(); enc != nil {
+		return *enc
+	}
+	return DefaultEncoder
+}
+
+// EncoderByName devolve o encoder pelo nome ("default", "legacy" ou
+// "problem"), útil para escolher o formato via configuração.
+func EncoderByName(name string) (HTTPEncoder, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "default":
+		return DefaultEncoder, true
+	case "legacy":
+		return LegacyEncoder, true
+	case "problem", "rfc7807":
+		return ProblemEncoder, true
+	}
+	return nil, false
+}
+
+// EncodeHttpLogger é a variante de PrintHttpLoggerLocalized que aplica o
+// encoder configurado, devolvendo o status, o Content-Type e o corpo.
+func EncodeHttpLogger(err error, locale, correlationID string) (int, string, interface{}) {
+	status, payload := PrintHttpLoggerLocalized(err, locale)
+	contentType, body := encodeHttp(status, payload, correlationID)
+	return status, contentType, body
+}
+
+// encodeHttp codifica o payload com o HTTPEncoder configurado.
+func encodeHttp(status int, payload *ShowLogger, correlationID string) (string, interface{}) {
+	if payload == nil {
+		payload = &ShowLogger{Code: INTERNAL, Message: "internal server error"}
+	}
+	payload.CorrelationID = correlationID
+
+	enc := GetHTTPEncoder()
+	return enc.ContentType(), enc.Encode(status, payload)
+}
+
+type defaultEncoder struct{}
+
+func (defaultEncoder) ContentType() string { return "application/json; charset=utf-8" }
+
+func (defaultEncoder) Encode(_ int, payload *ShowLogger) interface{} {
+	return ginPayload{Error: payload}
+}
+
+// legacyPayload é o formato de erro usado antes da padronização do envelope.
+type legacyPayload struct {
+	Codigo   Code                   `json:"codigo"`
+	Mensagem string                 `json:"mensagem"`
+	Detalhes map[string]interface{} `json:"detalhes,omitempty"`
+}
+
+type legacyEncoder struct{}
+
+func (legacyEncoder) ContentType() string { return "application/json; charset=utf-8" }
+
+func (legacyEncoder) Encode(_ int, payload *ShowLogger) interface{} {
+	return legacyPayload{
+		Codigo:   payload.Code,
+		Mensagem: payload.Message,
+		Detalhes: payload.Details,
+	}
+}
+
+// problemPayload segue a RFC 7807, com code, sub_code, details e correlation_id como membros de extensão.
+type problemPayload struct {
+	Type          string                 `json:"type"`
+	Title         string                 `json:"title"`
+	Status        int                    `json:"status"`
+	Detail        string                 `json:"detail,omitempty"`
+	Code          Code                   `json:"code"`
+	SubCode       string                 `json:"sub_code,omitempty"`
+	Details       map[string]interface{} `json:"details,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+}
+
+type problemEncoder struct{}
+
+func (problemEncoder) ContentType() string { return "application/problem+json" }
+
+func (problemEncoder) Encode(status int, payload *ShowLogger) interface{} {
+	return problemPayload{
+		Type:          "about:blank",
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        payload.Message,
+		Code:          payload.Code,
+		SubCode:       payload.SubCode,
+		Details:       payload.Details,
+		CorrelationID: payload.CorrelationID,
+	}
+}
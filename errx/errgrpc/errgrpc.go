@@ -0,0 +1,156 @@
+// Package errgrpc converte os erros da errx em status do gRPC, preservando a
+// mensagem, o Code e os detalhes, de modo que serviços gRPC respondam erros
+// equivalentes aos da API HTTP.
+package errgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ToGRPCCode converte um Code da errx no código gRPC correspondente.
+func ToGRPCCode(code errx.Code) codes.Code {
+	switch code {
+	case errx.BAD_REQUEST:
+		return codes.InvalidArgument
+	case errx.UNAUTHORIZED:
+		return codes.Unauthenticated
+	case errx.FORBIDDEN:
+		return codes.PermissionDenied
+	case errx.NOT_FOUND:
+		return codes.NotFound
+	case errx.METHOD_NOT_ALLOWED:
+		return codes.Unimplemented
+	case errx.CONFLICT:
+		return codes.AlreadyExists
+	case errx.UNAVAILABLE:
+		return codes.Unavailable
+	case errx.TIMEOUT:
+		return codes.DeadlineExceeded
+	case errx.CLIENT_CLOSED:
+		return codes.Canceled
+	case errx.TOO_MANY_REQUESTS, errx.PAYLOAD_TOO_LARGE:
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
+
+// ToStatus converte err em um erro de status do gRPC. Erros que já são status
+// são mantidos; AppErrors usam o código de ToGRPCCode e levam Code e Details
+// em um google.protobuf.Struct anexado ao status; cancelamento e prazo do
+// contexto viram Canceled e DeadlineExceeded; os demais viram Internal, sem
+// expor a mensagem original.
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	appErr := errx.GetAppError(err)
+	if appErr == nil {
+		return status.Error(codes.Internal, "internal server error")
+	}
+
+	st := status.New(ToGRPCCode(appErr.Code), appErr.Message)
+	fields := map[string]interface{}{"code": string(errx.GetCode(err))}
+	for key, value := range appErr.Details {
+		// Valores sem representação em google.protobuf.Value são enviados como texto.
+		if _, valueErr := structpb.NewValue(value); valueErr != nil {
+			value = fmt.Sprint(value)
+		}
+		fields[key] = value
+	}
+	if details, detailErr := structpb.NewStruct(fields); detailErr == nil {
+		if withDetails, detailErr := st.WithDetails(details); detailErr == nil {
+			st = withDetails
+		}
+	}
+	return st.Err()
+}
+
+// IsServerError informa se o código gRPC indica falha do servidor (e não do
+// cliente), como os status 5xx no HTTP.
+func IsServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal,
+		codes.Unavailable, codes.DataLoss, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// FromGRPCCode converte um código gRPC no Code da errx correspondente.
+func FromGRPCCode(code codes.Code) errx.Code {
+	switch code {
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return errx.BAD_REQUEST
+	case codes.Unauthenticated:
+		return errx.UNAUTHORIZED
+	case codes.PermissionDenied:
+		return errx.FORBIDDEN
+	case codes.NotFound:
+		return errx.NOT_FOUND
+	case codes.Unimplemented:
+		return errx.METHOD_NOT_ALLOWED
+	case codes.AlreadyExists, codes.Aborted:
+		return errx.CONFLICT
+	case codes.Unavailable, codes.ResourceExhausted:
+		return errx.UNAVAILABLE
+	case codes.DeadlineExceeded:
+		return errx.TIMEOUT
+	case codes.Canceled:
+		return errx.CLIENT_CLOSED
+	default:
+		return errx.INTERNAL
+	}
+}
+
+// FromStatus converte um erro de status do gRPC em AppError, recuperando o
+// Code e os detalhes anexados por ToStatus quando o servidor também usa este
+// pacote. Erros que não são status são devolvidos sem alteração.
+func FromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	appErr := errx.New(st.Message()).WithError(err)
+	for _, detail := range st.Details() {
+		fields, ok := detail.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+		for key, value := range fields.AsMap() {
+			if key == "code" {
+				if code, ok := value.(string); ok {
+					appErr.WithCode(errx.Code(code))
+				}
+				continue
+			}
+			appErr.WithDetails(map[string]interface{}{key: value})
+		}
+	}
+	return appErr.
+		WithCode(FromGRPCCode(st.Code())).
+		WithDetails(map[string]interface{}{"grpc_code": st.Code().String()})
+}
@@ -13,12 +13,18 @@ import (
 type Code string
 
 const (
-	INTERNAL     Code = "INTERNAL"
-	BAD_REQUEST  Code = "BAD_REQUEST"
-	UNAUTHORIZED Code = "UNAUTHORIZED"
-	FORBIDDEN    Code = "FORBIDDEN"
-	NOT_FOUND    Code = "NOT_FOUND"
-	CONFLICT     Code = "CONFLICT"
+	INTERNAL           Code = "INTERNAL"
+	BAD_REQUEST        Code = "BAD_REQUEST"
+	UNAUTHORIZED       Code = "UNAUTHORIZED"
+	FORBIDDEN          Code = "FORBIDDEN"
+	NOT_FOUND          Code = "NOT_FOUND"
+	METHOD_NOT_ALLOWED Code = "METHOD_NOT_ALLOWED"
+	CONFLICT           Code = "CONFLICT"
+	UNAVAILABLE        Code = "UNAVAILABLE"
+	TIMEOUT            Code = "TIMEOUT"
+	CLIENT_CLOSED      Code = "CLIENT_CLOSED"
+	TOO_MANY_REQUESTS  Code = "TOO_MANY_REQUESTS"
+	PAYLOAD_TOO_LARGE  Code = "PAYLOAD_TOO_LARGE"
 )
 
 var (
@@ -39,15 +45,32 @@ type AppError struct {
 	Caller string
 	// Details contém informações adicionais úteis para diagnóstico.
 	Details map[string]interface{}
+	// SubCode é o sub-código do catálogo (ex.: "PAY-4012"), ver RegisterCodes.
+	SubCode string
+	// Origin indica onde a falha se originou (INTERNAL, DOWNSTREAM, CLIENT).
+	Origin Origin
+	// Dependency identifica a dependência que falhou quando Origin é DOWNSTREAM.
+	Dependency string
+
+	// alert e sloImpact classificam o erro para alertas e orçamento de erro;
+	// quando nil, vale o padrão derivado do Code (ver ShouldAlert e AffectsSLO).
+	alert     *bool
+	sloImpact *bool
 }
 
 // ShowLogger define a estrutura padronizada para exibição/serialização
 // segura do erro em logs e respostas, evitando expor tipos inesperados.
 type ShowLogger struct {
-	Message string                 `json:"message"`
-	Code    Code                   `json:"code"`
-	Caller  string                 `json:"caller,omitempty"`
-	Details map[string]interface{} `json:"details,omitempty"`
+	Message       string                 `json:"message"`
+	Code          Code                   `json:"code"`
+	SubCode       string                 `json:"sub_code,omitempty"`
+	Caller        string                 `json:"caller,omitempty"`
+	Details       map[string]interface{} `json:"details,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	Origin        Origin                 `json:"origin,omitempty"`
+	Dependency    string                 `json:"dependency,omitempty"`
+	Alert         *bool                  `json:"alert,omitempty"`
+	SLOImpact     *bool                  `json:"slo_impact,omitempty"`
 }
 
 // New cria uma nova AppError com a mensagem fornecida.
@@ -89,6 +112,23 @@ func (e *AppError) WithError(err error) *AppError {
 			e.Caller = inner.Caller
 		}
 
+		if e.SubCode == "" && inner.SubCode != "" {
+			e.SubCode = inner.SubCode
+		}
+
+		if e.Origin == "" && inner.Origin != "" {
+			e.Origin = inner.Origin
+			e.Dependency = inner.Dependency
+		}
+
+		if e.alert == nil {
+			e.alert = inner.alert
+		}
+
+		if e.sloImpact == nil {
+			e.sloImpact = inner.sloImpact
+		}
+
 		if len(inner.Details) > 0 {
 			if e.Details == nil {
 				e.Details = make(map[string]interface{}, len(inner.Details))
@@ -206,8 +246,20 @@ func ToHTTPCode(code Code) int {
 		return 403
 	case NOT_FOUND:
 		return 404
+	case METHOD_NOT_ALLOWED:
+		return 405
 	case CONFLICT:
 		return 409
+	case UNAVAILABLE:
+		return 503
+	case TIMEOUT:
+		return 504
+	case CLIENT_CLOSED:
+		return 499
+	case TOO_MANY_REQUESTS:
+		return 429
+	case PAYLOAD_TOO_LARGE:
+		return 413
 	default:
 		return 500
 	}
@@ -216,6 +268,12 @@ func ToHTTPCode(code Code) int {
 
 // StatusToCode converte um status HTTP para o Code de erro correspondente.
 func StatusToCode(status int) Code {
+	switch status {
+	case 503:
+		return UNAVAILABLE
+	case 504:
+		return TIMEOUT
+	}
 	if status >= 500 {
 		return INTERNAL
 	}
@@ -227,8 +285,16 @@ func StatusToCode(status int) Code {
 		return FORBIDDEN
 	case 404:
 		return NOT_FOUND
+	case 405:
+		return METHOD_NOT_ALLOWED
 	case 409:
 		return CONFLICT
+	case 413:
+		return PAYLOAD_TOO_LARGE
+	case 429:
+		return TOO_MANY_REQUESTS
+	case 499:
+		return CLIENT_CLOSED
 	default:
 		return BAD_REQUEST
 	}
@@ -241,11 +307,17 @@ func PrintLogger(err error) *ShowLogger {
 		return nil
 	}
 	appErr := GetAppError(err)
+	alert, sloImpact := ShouldAlert(err), AffectsSLO(err)
 	return &ShowLogger{
-		Message: appErr.Error(),
-		Code:    appErr.Code,
-		Caller:  appErr.Caller,
-		Details: appErr.Details,
+		Message:    appErr.Error(),
+		Code:       appErr.Code,
+		SubCode:    appErr.SubCode,
+		Caller:     appErr.Caller,
+		Details:    appErr.Details,
+		Origin:     GetOrigin(err),
+		Dependency: appErr.Dependency,
+		Alert:      &alert,
+		SLOImpact:  &sloImpact,
 	}
 }
 
@@ -255,8 +327,13 @@ func PrintHttpLogger(err error) (int, *ShowLogger) {
 		return 500, nil
 	}
 	appErr := GetAppError(err)
-	return ToHTTPCode(appErr.Code), &ShowLogger{
+	status := ToHTTPCode(appErr.Code)
+	if def, ok := LookupCode(appErr.SubCode); ok {
+		status = def.Status
+	}
+	return status, &ShowLogger{
 		Code:    appErr.Code,
+		SubCode: appErr.SubCode,
 		Message: appErr.Error(),
 		Details: appErr.Details,
 	}
@@ -0,0 +1,68 @@
+package errx
+
+import (
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// correlationHeader é o cabeçalho de resposta com o correlation id da requisição.
+const correlationHeader = "x-itau-correlation-id"
+
+// ginLocale resolve o locale da requisição usado para traduzir as mensagens.
+var ginLocale atomic.Pointer[func(c *gin.Context) string]
+
+// SetGinLocale define como AbortWithError obtém o locale da requisição; o
+// pacote server registra o locale resolvido pelo seu middleware.
+func SetGinLocale(resolve func(c *gin.Context) string) {
+	ginLocale.Store(&resolve)
+}
+
+// ginPayload é o corpo de erro padrão das respostas HTTP.
+type ginPayload struct {
+	Error *ShowLogger `json:"error"`
+}
+
+// AbortWithError registra o erro no contexto do Gin, interrompe a cadeia de
+// handlers e responde com o status do Code e o payload no formato do
+// HTTPEncoder configurado, com a mensagem traduzida e o correlation id. Erros
+// que não são AppError são respondidos como INTERNAL, sem expor a causa ao
+// cliente.
+//
+//	if err != nil {
+//		errx.AbortWithError(c, err)
+//		return
+//	}
+func AbortWithError(c *gin.Context, err error) {
+	AbortWithStatus(c, 0, err)
+}
+
+// AbortWithStatus é como AbortWithError, mas responde com o status informado em
+// vez do status do Code (ex.: um 502 injetado em testes, que o Code mapearia
+// para 500). Com status zero, usa o status do Code.
+func AbortWithStatus(c *gin.Context, status int, err error) {
+	if err == nil {
+		return
+	}
+
+	_ = c.Error(err)
+
+	// Cancelamentos e prazos esgotados respondem 499 e 504, não 500.
+	err = FromContextErr(err)
+	if !IsAppError(err) {
+		err = New("internal server error").WithCode(INTERNAL)
+	}
+
+	locale := ""
+	if resolve := ginLocale.Load(); resolve != nil {
+		locale = (*resolve)(c)
+	}
+
+	codeStatus, payload := PrintHttpLoggerLocalized(err, locale)
+	if status == 0 {
+		status = codeStatus
+	}
+	contentType, body := encodeHttp(status, payload, c.Writer.Header().Get(correlationHeader))
+	c.Header("Content-Type", contentType)
+	c.AbortWithStatusJSON(status, body)
+}
@@ -0,0 +1,62 @@
+package errx
+
+import "sync"
+
+// catalog guarda as traduções registradas, indexadas por locale e chave.
+// A chave pode ser a mensagem original da AppError ou o seu Code.
+var catalog = struct {
+	sync.RWMutex
+	messages map[string]map[string]string
+}{messages: map[string]map[string]string{}}
+
+// RegisterTranslations registra (mesclando) as traduções de um locale. As chaves
+// podem ser a Message original do erro ou o Code (ex.: "NOT_FOUND"), usado como
+// mensagem genérica quando não há tradução específica.
+func RegisterTranslations(locale string, messages map[string]string) {
+	catalog.Lock()
+	defer catalog.Unlock()
+
+	if catalog.messages[locale] == nil {
+		catalog.messages[locale] = make(map[string]string, len(messages))
+	}
+	for k, v := range messages {
+		catalog.messages[locale][k] = v
+	}
+}
+
+// Translate devolve a tradução de key no locale informado e se ela existe.
+func Translate(locale, key string) (string, bool) {
+	catalog.RLock()
+	defer catalog.RUnlock()
+
+	msg, ok := catalog.messages[locale][key]
+	return msg, ok
+}
+
+// Localize devolve a mensagem da AppError traduzida para o locale, buscando
+// primeiro pela Message e depois pelo Code. Sem tradução, devolve err.Error().
+func Localize(err error, locale string) string {
+	appErr := GetAppError(err)
+	if appErr == nil {
+		return err.Error()
+	}
+
+	if msg, ok := Translate(locale, appErr.Message); ok {
+		return msg
+	}
+	if msg, ok := Translate(locale, string(appErr.Code)); ok {
+		return msg
+	}
+	return appErr.Error()
+}
+
+// PrintHttpLoggerLocalized é a variante de PrintHttpLogger com a mensagem traduzida para o locale.
+func PrintHttpLoggerLocalized(err error, locale string) (int, *ShowLogger) {
+	status, payload := PrintHttpLogger(err)
+	if payload == nil || locale == "" {
+		return status, payload
+	}
+
+	payload.Message = Localize(err, locale)
+	return status, payload
+}
@@ -0,0 +1,59 @@
+package errx
+
+// Origin classifica a causa do erro para separar falhas da própria aplicação
+// de indisponibilidades de dependências e de erros do cliente.
+type Origin string
+
+const (
+	ORIGIN_INTERNAL   Origin = "INTERNAL"
+	ORIGIN_DOWNSTREAM Origin = "DOWNSTREAM"
+	ORIGIN_CLIENT     Origin = "CLIENT"
+)
+
+// WithOrigin define a origem do erro.
+func (e *AppError) WithOrigin(origin Origin) *AppError {
+	e.Origin = origin
+	return e
+}
+
+// WithDependency marca o erro como falha da dependência informada.
+func (e *AppError) WithDependency(dependency string) *AppError {
+	e.Origin = ORIGIN_DOWNSTREAM
+	e.Dependency = dependency
+	return e
+}
+
+// Downstream encapsula err como falha da dependência informada. O Code de err
+// é herdado quando houver; caso contrário, o erro é UNAVAILABLE.
+//
+//	if err != nil {
+//		return errx.Downstream("payments-api", err)
+//	}
+func Downstream(dependency string, err error) *AppError {
+	appErr := New(dependency + " request failed").WithError(err).WithCode(UNAVAILABLE)
+	return appErr.WithDependency(dependency)
+}
+
+// GetOrigin devolve a origem do erro. Sem origem explícita, erros 4xx são
+// atribuídos ao cliente e os demais à própria aplicação.
+func GetOrigin(err error) Origin {
+	appErr, ok := asAppError(err)
+	if !ok {
+		return ORIGIN_INTERNAL
+	}
+	if appErr.Origin != "" {
+		return appErr.Origin
+	}
+	if status := ToHTTPCode(appErr.Code); status >= 400 && status < 500 {
+		return ORIGIN_CLIENT
+	}
+	return ORIGIN_INTERNAL
+}
+
+// GetDependency devolve a dependência associada ao erro, se houver.
+func GetDependency(err error) string {
+	if appErr, ok := asAppError(err); ok {
+		return appErr.Dependency
+	}
+	return ""
+}
@@ -0,0 +1,48 @@
+package errx
+
+import (
+	"errors"
+	"strings"
+)
+
+// FieldError descreve a violação de uma regra de validação em um campo.
+type FieldError struct {
+	// Field é o caminho do campo, com os nomes usados no JSON (ex.: "address.zip").
+	Field string `json:"field"`
+	// Rule é a regra violada (ex.: "required", "cpf").
+	Rule string `json:"rule"`
+	// Param é o parâmetro da regra, quando houver (ex.: "10" em "max=10").
+	Param string `json:"param,omitempty"`
+	// Message é a mensagem legível da violação.
+	Message string `json:"message"`
+}
+
+// ValidationErrors reúne as violações de validação de uma entrada.
+type ValidationErrors []FieldError
+
+// Error implementa a interface error, listando as violações.
+func (v ValidationErrors) Error() string {
+	parts := make([]string, 0, len(v))
+	for _, fe := range v {
+		parts = append(parts, fe.Field+": "+fe.Message)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// NewValidation cria uma AppError BAD_REQUEST com as violações encadeadas e
+// expostas em Details["violations"], como na validação OpenAPI do server.
+func NewValidation(violations ValidationErrors) *AppError {
+	return New("validation failed").
+		WithCode(BAD_REQUEST).
+		WithError(violations).
+		WithDetails(map[string]interface{}{"violations": violations})
+}
+
+// GetValidationErrors extrai as violações de validação contidas em err, se houver.
+func GetValidationErrors(err error) (ValidationErrors, bool) {
+	var violations ValidationErrors
+	if errors.As(err, &violations) {
+		return violations, true
+	}
+	return nil, false
+}
@@ -0,0 +1,189 @@
+// Package events implementa o padrão transactional outbox: os eventos são
+// gravados na tabela de outbox dentro da mesma transação da regra de negócio e
+// um relay em segundo plano os publica na fila, com novas tentativas. Assim, o
+// evento só é publicado se a transação for confirmada, sem escrita dupla.
+//
+//	err := database.WithTx(ctx, func(tx *sql.Tx) error {
+//		if err := orders.Insert(ctx, tx, order); err != nil {
+//			return err
+//		}
+//		event, err := events.New("orders", "order.created", order.ID, order)
+//		if err != nil {
+//			return err
+//		}
+//		return outbox.Add(ctx, tx, event)
+//	})
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nathanribeiroo/module-dep-projects/db"
+)
+
+// outboxTable é a tabela padrão dos eventos pendentes.
+const outboxTable = "outbox_events"
+
+// Event é um evento a ser publicado.
+type Event struct {
+	// ID identifica o evento e é enviado no atributo event_id, permitindo que os
+	// consumidores descartem entregas repetidas.
+	ID string
+	// Topic é o destino do evento (tópico do Kafka ou fila do SQS registrada no relay).
+	Topic string
+	// Type descreve o evento (ex.: "order.created") e é enviado no atributo event_type.
+	Type string
+	// Key agrupa eventos relacionados (partição no Kafka, MessageGroupId no SQS FIFO).
+	Key string
+	// Payload é o corpo publicado.
+	Payload []byte
+	// Attributes são metadados adicionais da mensagem.
+	Attributes map[string]string
+	// CreatedAt é o instante de criação do evento.
+	CreatedAt time.Time
+}
+
+// New cria um evento com o payload serializado em JSON.
+func New(topic string, eventType string, key string, payload interface{}) (Event, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		ID:        uuid.NewString(),
+		Topic:     topic,
+		Type:      eventType,
+		Key:       key,
+		Payload:   body,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// Outbox grava e lê os eventos pendentes.
+type Outbox struct {
+	db    *db.DB
+	table string
+}
+
+// NewOutbox cria a outbox sobre o banco informado, na tabela outbox_events.
+func NewOutbox(database *db.DB) *Outbox {
+	return &Outbox{db: database, table: outboxTable}
+}
+
+// CreateTable cria a tabela da outbox, se ainda não existir. Alternativamente,
+// inclua o DDL equivalente nas migrações do serviço. Tabelas criadas em versões
+// anteriores precisam da coluna claimed_until (TIMESTAMP NULL), usada pelo relay.
+func (o *Outbox) CreateTable(ctx context.Context) error {
+	payloadType := "BYTEA"
+	if o.db.Driver() == db.MySQL {
+		payloadType = "LONGBLOB"
+	}
+
+	_, err := o.db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+o.table+` (
+		id VARCHAR(64) PRIMARY KEY,
+		topic VARCHAR(255) NOT NULL,
+		event_type VARCHAR(255) NOT NULL,
+		event_key VARCHAR(255) NOT NULL,
+		payload `+payloadType+` NOT NULL,
+		attributes TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		published_at TIMESTAMP NULL,
+		attempts INT NOT NULL DEFAULT 0,
+		last_error TEXT NULL,
+		claimed_until TIMESTAMP NULL
+	)`)
+	return err
+}
+
+// Add grava os eventos na outbox usando a transação da regra de negócio.
+func (o *Outbox) Add(ctx context.Context, tx *sql.Tx, events ...Event) error {
+	query := o.db.Rebind("INSERT INTO " + o.table + " (id, topic, event_type, event_key, payload, attributes, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)")
+	for _, e := range events {
+		if e.ID == "" {
+			e.ID = uuid.NewString()
+		}
+		if e.CreatedAt.IsZero() {
+			e.CreatedAt = time.Now().UTC()
+		}
+		attributes, err := json.Marshal(e.Attributes)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, query, e.ID, e.Topic, e.Type, e.Key, e.Payload, string(attributes), e.CreatedAt); err != nil {
+			return fmt.Errorf("events: outbox insert: %w", err)
+		}
+	}
+	return nil
+}
+
+// claim reserva por ttl, em uma transação curta, até limit eventos pendentes,
+// que as demais instâncias ignoram até a publicação ou o fim da reserva (ex.:
+// queda da instância). A publicação acontece fora da transação.
+func (o *Outbox) claim(ctx context.Context, limit int, maxAttempts int, ttl time.Duration) ([]Event, error) {
+	var events []Event
+	err := o.db.WithTx(ctx, func(tx *sql.Tx) error {
+		now := time.Now().UTC()
+		var err error
+		events, err = o.pending(ctx, tx, now, limit, maxAttempts)
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		args := make([]interface{}, 0, len(events)+1)
+		args = append(args, now.Add(ttl))
+		for _, e := range events {
+			args = append(args, e.ID)
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(events)), ", ")
+		_, err = tx.ExecContext(ctx, o.db.Rebind("UPDATE "+o.table+" SET claimed_until = ? WHERE id IN ("+placeholders+")"), args...)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("events: outbox claim: %w", err)
+	}
+	return events, nil
+}
+
+// pending lê, com lock, até limit eventos não publicados, abaixo do limite de
+// tentativas e sem reserva vigente. Instâncias concorrentes ignoram as linhas
+// já travadas.
+func (o *Outbox) pending(ctx context.Context, tx *sql.Tx, now time.Time, limit int, maxAttempts int) ([]Event, error) {
+	rows, err := tx.QueryContext(ctx, o.db.Rebind("SELECT id, topic, event_type, event_key, payload, attributes, created_at FROM "+o.table+
+		" WHERE published_at IS NULL AND attempts < ? AND (claimed_until IS NULL OR claimed_until < ?)"+
+		" ORDER BY created_at LIMIT ? FOR UPDATE SKIP LOCKED"), maxAttempts, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var e Event
+		var attributes string
+		if err := rows.Scan(&e.ID, &e.Topic, &e.Type, &e.Key, &e.Payload, &attributes, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		_ = json.Unmarshal([]byte(attributes), &e.Attributes)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// markPublished registra a publicação do evento e libera a reserva.
+func (o *Outbox) markPublished(ctx context.Context, id string) error {
+	_, err := o.db.ExecContext(ctx, o.db.Rebind("UPDATE "+o.table+" SET published_at = ?, attempts = attempts + 1, last_error = NULL, claimed_until = NULL WHERE id = ?"), time.Now().UTC(), id)
+	return err
+}
+
+// markFailed registra a falha de publicação e libera a reserva para nova tentativa.
+func (o *Outbox) markFailed(ctx context.Context, id string, cause error) error {
+	_, err := o.db.ExecContext(ctx, o.db.Rebind("UPDATE "+o.table+" SET attempts = attempts + 1, last_error = ?, claimed_until = NULL WHERE id = ?"), cause.Error(), id)
+	return err
+}
@@ -0,0 +1,164 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"github.com/nathanribeiroo/module-dep-projects/queue"
+)
+
+// RelayOptions configura a publicação dos eventos da outbox.
+type RelayOptions struct {
+	// Producers associa cada Topic de evento ao produtor que o publica.
+	Producers map[string]queue.Producer
+	// Interval é a espera entre as leituras quando não há eventos pendentes (padrão: 1s).
+	Interval time.Duration
+	// BatchSize é o número de eventos lidos por vez (padrão: 100).
+	BatchSize int
+	// MaxAttempts é o número de tentativas de publicação de um evento; após
+	// esgotá-las, o evento permanece na tabela com o último erro (padrão: 10).
+	MaxAttempts int
+	// ClaimTimeout é por quanto tempo um lote lido fica reservado para a
+	// instância; vencido o prazo (ex.: queda durante a publicação), os eventos
+	// não marcados voltam a ser lidos (padrão: 1min).
+	ClaimTimeout time.Duration
+}
+
+// Relay publica periodicamente os eventos pendentes da outbox. Cada evento é
+// marcado como publicado após a confirmação do broker; em caso de falha
+// intermediária, ele pode ser publicado novamente, por isso os consumidores
+// devem descartar repetições pelo atributo event_id.
+type Relay struct {
+	outbox *Outbox
+	opts   RelayOptions
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewRelay cria o relay da outbox.
+func NewRelay(outbox *Outbox, opts RelayOptions) *Relay {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 10
+	}
+	if opts.ClaimTimeout <= 0 {
+		opts.ClaimTimeout = time.Minute
+	}
+	return &Relay{outbox: outbox, opts: opts}
+}
+
+// Run publica os eventos pendentes até ctx ser cancelado.
+func (r *Relay) Run(ctx context.Context) error {
+	for {
+		published, err := r.PublishPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			logx.Ctx(ctx).Error("Failed to relay outbox events", "error", err)
+		}
+
+		// Com um lote cheio, há provavelmente mais eventos pendentes.
+		if published == r.opts.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.opts.Interval):
+		}
+	}
+}
+
+// PublishPending publica um lote de eventos pendentes e devolve quantos foram
+// publicados. O lote é reservado em uma transação curta e publicado fora dela,
+// sem manter locks durante as chamadas ao broker; cada evento é marcado logo
+// após a própria publicação.
+func (r *Relay) PublishPending(ctx context.Context) (int, error) {
+	events, err := r.outbox.claim(ctx, r.opts.BatchSize, r.opts.MaxAttempts, r.opts.ClaimTimeout)
+	if err != nil {
+		return 0, err
+	}
+
+	// As marcações são gravadas mesmo com o relay sendo interrompido.
+	markCtx := context.WithoutCancel(ctx)
+	published := 0
+	for _, e := range events {
+		if err := r.publish(ctx, e); err != nil {
+			logx.Ctx(ctx).Warn("Failed to publish outbox event", "event_id", e.ID, "topic", e.Topic, "error", err)
+			if err := r.outbox.markFailed(markCtx, e.ID, err); err != nil {
+				return published, fmt.Errorf("events: outbox mark failed: %w", err)
+			}
+			continue
+		}
+		if err := r.outbox.markPublished(markCtx, e.ID); err != nil {
+			return published, fmt.Errorf("events: outbox mark published: %w", err)
+		}
+		published++
+	}
+	return published, nil
+}
+
+// publish envia o evento pelo produtor do tópico.
+func (r *Relay) publish(ctx context.Context, e Event) error {
+	producer, ok := r.opts.Producers[e.Topic]
+	if !ok {
+		return fmt.Errorf("no producer for topic %s", e.Topic)
+	}
+
+	attributes := make(map[string]string, len(e.Attributes)+2)
+	for key, value := range e.Attributes {
+		attributes[key] = value
+	}
+	attributes["event_id"] = e.ID
+	attributes["event_type"] = e.Type
+
+	return producer.Publish(ctx, queue.Message{Key: e.Key, Body: e.Payload, Attributes: attributes})
+}
+
+// Start inicia o relay em segundo plano; compatível com server.OnStart.
+func (r *Relay) Start(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return errors.New("events: relay already started")
+	}
+
+	ctx, r.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		_ = r.Run(ctx)
+	}()
+	return nil
+}
+
+// Stop interrompe o relay e aguarda o lote em andamento; compatível com server.OnStop.
+func (r *Relay) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel = nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,89 @@
+package featureflag
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultClient é o Client usado pelas funções do pacote quando o contexto não traz um.
+var defaultClient atomic.Pointer[Client]
+
+// SetDefault define o Client usado pelas funções do pacote.
+func SetDefault(c *Client) {
+	defaultClient.Store(c)
+}
+
+// Default devolve o Client definido em SetDefault, ou nil.
+func Default() *Client {
+	return defaultClient.Load()
+}
+
+type (
+	clientKey struct{}
+	targetKey struct{}
+)
+
+// WithTarget associa ao contexto o alvo usado na avaliação das flags.
+func WithTarget(ctx context.Context, target Target) context.Context {
+	return context.WithValue(ctx, targetKey{}, target)
+}
+
+// TargetFromContext devolve o alvo associado ao contexto, se houver.
+func TargetFromContext(ctx context.Context) Target {
+	target, _ := ctx.Value(targetKey{}).(Target)
+	return target
+}
+
+// WithClient associa ao contexto o Client usado pelas funções do pacote.
+func WithClient(ctx context.Context, c *Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, c)
+}
+
+// FromContext devolve o Client do contexto ou, na ausência, o Client padrão.
+func FromContext(ctx context.Context) *Client {
+	if c, ok := ctx.Value(clientKey{}).(*Client); ok {
+		return c
+	}
+	return Default()
+}
+
+// Bool avalia a flag booleana com o Client do contexto.
+func Bool(ctx context.Context, flag string, def bool) bool {
+	return FromContext(ctx).Bool(ctx, flag, def)
+}
+
+// String avalia a flag de texto com o Client do contexto.
+func String(ctx context.Context, flag string, def string) string {
+	return FromContext(ctx).String(ctx, flag, def)
+}
+
+// Int avalia a flag inteira com o Client do contexto.
+func Int(ctx context.Context, flag string, def int) int {
+	return FromContext(ctx).Int(ctx, flag, def)
+}
+
+// Float avalia a flag numérica com o Client do contexto.
+func Float(ctx context.Context, flag string, def float64) float64 {
+	return FromContext(ctx).Float(ctx, flag, def)
+}
+
+// JSON decodifica a flag estruturada em dst com o Client do contexto.
+func JSON(ctx context.Context, flag string, dst interface{}) bool {
+	return FromContext(ctx).JSON(ctx, flag, dst)
+}
+
+// Middleware associa o Client e o alvo da requisição ao contexto, de modo que
+// os handlers avaliem as flags com featureflag.Bool(c.Request.Context(), ...).
+// target pode ser nil quando não há segmentação.
+func Middleware(client *Client, target func(c *gin.Context) Target) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := WithClient(c.Request.Context(), client)
+		if target != nil {
+			ctx = WithTarget(ctx, target(c))
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
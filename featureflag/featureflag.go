@@ -0,0 +1,185 @@
+// Package featureflag avalia feature flags a partir de provedores locais
+// (variáveis de ambiente, arquivo) ou remotos (documento de flags via HTTP),
+// com getters tipados, valores padrão e segmentação pelo alvo da requisição.
+//
+//	flags := featureflag.New(featureflag.Env("FEATURE_"))
+//	featureflag.SetDefault(flags)
+//
+//	server.N().Middlewares(featureflag.Middleware(flags, func(c *gin.Context) featureflag.Target {
+//		return featureflag.Target{Key: c.GetHeader("X-User-Id")}
+//	}))
+//
+//	if featureflag.Bool(ctx, "new-checkout", false) {
+//		...
+//	}
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// ErrNotFound indica que o provedor não conhece a flag.
+var ErrNotFound = errors.New("featureflag: flag not found")
+
+// Target identifica para quem a flag é avaliada (usuário, tenant etc.).
+type Target struct {
+	// Key identifica o alvo e define o bucket do rollout percentual.
+	Key string
+	// Attributes são atributos usados nas regras (ex.: "country": "BR").
+	Attributes map[string]string
+}
+
+// Provider resolve o valor de uma flag para o alvo informado, devolvendo
+// ErrNotFound quando a flag não existe.
+type Provider interface {
+	Value(ctx context.Context, flag string, target Target) (interface{}, error)
+}
+
+// ProviderFunc adapta uma função à interface Provider.
+type ProviderFunc func(ctx context.Context, flag string, target Target) (interface{}, error)
+
+// Value implementa Provider.
+func (f ProviderFunc) Value(ctx context.Context, flag string, target Target) (interface{}, error) {
+	return f(ctx, flag, target)
+}
+
+// Client avalia flags consultando os provedores na ordem informada.
+type Client struct {
+	providers []Provider
+}
+
+// New cria um Client; o primeiro provedor que conhecer a flag define o valor.
+func New(providers ...Provider) *Client {
+	return &Client{providers: providers}
+}
+
+// Value devolve o valor bruto da flag para o alvo do contexto.
+func (c *Client) Value(ctx context.Context, flag string) (interface{}, error) {
+	target := TargetFromContext(ctx)
+	for _, p := range c.providers {
+		value, err := p.Value(ctx, flag, target)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		return value, err
+	}
+	return nil, ErrNotFound
+}
+
+// Bool devolve a flag como booleano, ou def se ausente ou inválida.
+func (c *Client) Bool(ctx context.Context, flag string, def bool) bool {
+	value, ok := c.lookup(ctx, flag)
+	if !ok {
+		return def
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return invalidValue(ctx, flag, value, def)
+}
+
+// String devolve a flag como texto, ou def se ausente.
+func (c *Client) String(ctx context.Context, flag string, def string) string {
+	value, ok := c.lookup(ctx, flag)
+	if !ok {
+		return def
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+// Int devolve a flag como inteiro, ou def se ausente ou inválida.
+func (c *Client) Int(ctx context.Context, flag string, def int) int {
+	value, ok := c.lookup(ctx, flag)
+	if !ok {
+		return def
+	}
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+	return invalidValue(ctx, flag, value, def)
+}
+
+// Float devolve a flag como número, ou def se ausente ou inválida.
+func (c *Client) Float(ctx context.Context, flag string, def float64) float64 {
+	value, ok := c.lookup(ctx, flag)
+	if !ok {
+		return def
+	}
+	switch v := value.(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return invalidValue(ctx, flag, value, def)
+}
+
+// JSON decodifica a flag em dst (valores estruturados ou texto JSON),
+// devolvendo false se ausente ou inválida.
+func (c *Client) JSON(ctx context.Context, flag string, dst interface{}) bool {
+	value, ok := c.lookup(ctx, flag)
+	if !ok {
+		return false
+	}
+
+	data, isString := value.(string)
+	raw := []byte(data)
+	if !isString {
+		var err error
+		if raw, err = json.Marshal(value); err != nil {
+			return false
+		}
+	}
+	return json.Unmarshal(raw, dst) == nil
+}
+
+// lookup consulta a flag, registrando falhas dos provedores.
+func (c *Client) lookup(ctx context.Context, flag string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	value, err := c.Value(ctx, flag)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			logx.Ctx(ctx).Warn("Failed to evaluate feature flag", "flag", flag, "error", err)
+		}
+		return nil, false
+	}
+	return value, true
+}
+
+// invalidValue registra um valor incompatível com o tipo pedido e devolve o padrão.
+func invalidValue[T any](ctx context.Context, flag string, value interface{}, def T) T {
+	logx.Ctx(ctx).Warn("Feature flag has unexpected type", "flag", flag, "value", value)
+	return def
+}
@@ -0,0 +1,50 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Env devolve um provedor que lê as flags de variáveis de ambiente: a flag
+// "new-checkout" com prefixo "FEATURE_" é lida de FEATURE_NEW_CHECKOUT. Os
+// valores são texto, convertidos pelos getters tipados; não há segmentação.
+func Env(prefix string) Provider {
+	replacer := strings.NewReplacer("-", "_", ".", "_", "/", "_")
+	return ProviderFunc(func(_ context.Context, flag string, _ Target) (interface{}, error) {
+		value, ok := os.LookupEnv(prefix + strings.ToUpper(replacer.Replace(flag)))
+		if !ok {
+			return nil, ErrNotFound
+		}
+		return value, nil
+	})
+}
+
+// File devolve um provedor com as definições (veja Flag) lidas de um arquivo
+// YAML (.yaml, .yml) ou JSON, no formato {"nome-da-flag": definição}.
+func File(path string) (Provider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	flags, err := parseFlags(data, filepath.Ext(path) == ".yaml" || filepath.Ext(path) == ".yml")
+	if err != nil {
+		return nil, fmt.Errorf("featureflag: %s: %w", path, err)
+	}
+	return Static(flags), nil
+}
+
+// parseFlags decodifica um documento de definições.
+func parseFlags(data []byte, isYAML bool) (map[string]Flag, error) {
+	flags := map[string]Flag{}
+	if isYAML {
+		return flags, yaml.Unmarshal(data, &flags)
+	}
+	return flags, json.Unmarshal(data, &flags)
+}
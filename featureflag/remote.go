@@ -0,0 +1,123 @@
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// HTTPOptions configura o provedor remoto.
+type HTTPOptions struct {
+	// URL é o endereço do documento JSON de definições (veja Flag).
+	URL string
+	// Header são cabeçalhos enviados em cada consulta (ex.: a chave do SDK).
+	Header http.Header
+	// Interval é o intervalo de atualização das definições (padrão: 30s).
+	Interval time.Duration
+	// Client é o cliente HTTP usado nas consultas (padrão: timeout de 10s).
+	Client *http.Client
+}
+
+// HTTPProvider baixa periodicamente o documento de definições e avalia as
+// flags localmente, sem uma chamada remota por avaliação (como os SDKs do
+// LaunchDarkly e do ConfigCat). Se uma atualização falhar, as últimas
+// definições válidas continuam em uso.
+type HTTPProvider struct {
+	flagSet
+	opts HTTPOptions
+
+	mu   sync.Mutex
+	etag string
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewHTTP cria o provedor remoto, baixa as definições iniciais e inicia as
+// atualizações em segundo plano até Close.
+func NewHTTP(ctx context.Context, opts HTTPOptions) (*HTTPProvider, error) {
+	if opts.Interval <= 0 {
+		opts.Interval = 30 * time.Second
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	p := &HTTPProvider{opts: opts, stop: make(chan struct{})}
+	if err := p.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	go p.poll()
+	return p, nil
+}
+
+// Refresh baixa as definições imediatamente.
+func (p *HTTPProvider) Refresh(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.opts.URL, nil)
+	if err != nil {
+		return err
+	}
+	for key, values := range p.opts.Header {
+		req.Header[key] = values
+	}
+	if p.etag != "" {
+		req.Header.Set("If-None-Match", p.etag)
+	}
+
+	resp, err := p.opts.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("featureflag: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return nil
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("featureflag: unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("featureflag: %w", err)
+	}
+	flags, err := parseFlags(data, false)
+	if err != nil {
+		return fmt.Errorf("featureflag: %w", err)
+	}
+
+	p.set(flags)
+	p.etag = resp.Header.Get("ETag")
+	return nil
+}
+
+// Close interrompe as atualizações.
+func (p *HTTPProvider) Close() {
+	p.once.Do(func() { close(p.stop) })
+}
+
+// poll atualiza as definições a cada intervalo.
+func (p *HTTPProvider) poll() {
+	ticker := time.NewTicker(p.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			if err := p.Refresh(context.Background()); err != nil {
+				logx.L().Warn("Failed to refresh feature flags", "error", err)
+			}
+		}
+	}
+}
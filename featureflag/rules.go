@@ -0,0 +1,129 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Flag é a definição de uma flag usada pelos provedores de arquivo e HTTP.
+// A avaliação segue a ordem: alvo específico, regras, rollout e valor padrão.
+// Nos documentos, um valor simples (ex.: `new-checkout: true`) equivale a
+// uma definição com apenas Value; valores estruturados devem usar "value".
+type Flag struct {
+	// Value é o valor quando nenhuma outra condição se aplica.
+	Value interface{} `json:"value" yaml:"value"`
+	// Targets define valores para chaves de alvo específicas.
+	Targets map[string]interface{} `json:"targets,omitempty" yaml:"targets,omitempty"`
+	// Rules define valores conforme os atributos do alvo; vale a primeira que casar.
+	Rules []Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// Rollout entrega um valor a uma porcentagem estável dos alvos.
+	Rollout *Rollout `json:"rollout,omitempty" yaml:"rollout,omitempty"`
+}
+
+// Rule casa quando o atributo do alvo está entre os valores informados.
+type Rule struct {
+	Attribute string      `json:"attribute" yaml:"attribute"`
+	In        []string    `json:"in" yaml:"in"`
+	Value     interface{} `json:"value" yaml:"value"`
+}
+
+// Rollout entrega Value a Percentage% dos alvos, escolhidos pelo hash da chave do alvo.
+type Rollout struct {
+	Percentage float64     `json:"percentage" yaml:"percentage"`
+	Value      interface{} `json:"value" yaml:"value"`
+}
+
+// flagDefinition evita a recursão nos métodos de decodificação.
+type flagDefinition Flag
+
+// UnmarshalJSON aceita tanto a definição completa quanto um valor simples.
+func (f *Flag) UnmarshalJSON(data []byte) error {
+	var def flagDefinition
+	if err := json.Unmarshal(data, &def); err == nil {
+		*f = Flag(def)
+		return nil
+	}
+	*f = Flag{}
+	return json.Unmarshal(data, &f.Value)
+}
+
+// UnmarshalYAML aceita tanto a definição completa quanto um valor simples.
+func (f *Flag) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		var def flagDefinition
+		if err := node.Decode(&def); err != nil {
+			return err
+		}
+		*f = Flag(def)
+		return nil
+	}
+	*f = Flag{}
+	return node.Decode(&f.Value)
+}
+
+// evaluate resolve o valor da flag para o alvo.
+func (f Flag) evaluate(name string, target Target) interface{} {
+	if target.Key != "" {
+		if value, ok := f.Targets[target.Key]; ok {
+			return value
+		}
+	}
+
+	for _, rule := range f.Rules {
+		attr, ok := target.Attributes[rule.Attribute]
+		if !ok {
+			continue
+		}
+		for _, candidate := range rule.In {
+			if attr == candidate {
+				return rule.Value
+			}
+		}
+	}
+
+	if f.Rollout != nil && target.Key != "" && bucket(name, target.Key) < f.Rollout.Percentage {
+		return f.Rollout.Value
+	}
+	return f.Value
+}
+
+// bucket distribui o alvo de forma estável entre 0 e 100 para a flag.
+func bucket(flag string, key string) float64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(flag + ":" + key))
+	return float64(h.Sum32()%10000) / 100
+}
+
+// flagSet é um conjunto de definições substituível atomicamente.
+type flagSet struct {
+	flags atomic.Pointer[map[string]Flag]
+}
+
+// set substitui as definições.
+func (s *flagSet) set(flags map[string]Flag) {
+	s.flags.Store(&flags)
+}
+
+// Value implementa Provider sobre as definições atuais.
+func (s *flagSet) Value(_ context.Context, name string, target Target) (interface{}, error) {
+	flags := s.flags.Load()
+	if flags == nil {
+		return nil, ErrNotFound
+	}
+	f, ok := (*flags)[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return f.evaluate(name, target), nil
+}
+
+// Static devolve um provedor com definições fixas, útil em testes e valores embutidos.
+func Static(flags map[string]Flag) Provider {
+	s := &flagSet{}
+	s.set(flags)
+	return s
+}
@@ -0,0 +1,230 @@
+// Package grpcclient cria conexões gRPC com o mesmo tratamento dado às
+// dependências HTTP: timeout por chamada, novas tentativas em falhas
+// transitórias, circuit breaker, propagação do correlation id e do trace do
+// Datadog, e conversão dos status gRPC em erros da errx.
+//
+//	conn, err := grpcclient.Dial("payments:9090", grpcclient.Options{
+//		Timeout:    2 * time.Second,
+//		MaxRetries: 2,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer conn.Close()
+//
+//	client := paymentsv1.NewPaymentsClient(conn)
+package grpcclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/errx/errgrpc"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"github.com/nathanribeiroo/module-dep-projects/resilience"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// correlationHeader é a chave de metadata do correlation id, a mesma do cabeçalho HTTP.
+const correlationHeader = "x-itau-correlation-id"
+
+// Options configura a conexão.
+type Options struct {
+	// Timeout limita cada chamada unária sem prazo no contexto (padrão: 5s; use -1 para não limitar).
+	Timeout time.Duration
+	// MaxRetries é o número de novas tentativas em códigos repetíveis (padrão: 0).
+	MaxRetries int
+	// Backoff é a espera antes da primeira nova tentativa (padrão: 100ms).
+	Backoff time.Duration
+	// RetryableCodes são os códigos que merecem nova tentativa (padrão: Unavailable).
+	// Inclua códigos como DeadlineExceeded apenas em métodos idempotentes.
+	RetryableCodes []codes.Code
+	// FailureThreshold é o número de falhas consecutivas que abre o circuito
+	// (padrão: 0, sem circuit breaker).
+	FailureThreshold int
+	// Cooldown é o tempo de circuito aberto (padrão: 10s).
+	Cooldown time.Duration
+	// TLS define as credenciais de transporte (padrão: sem TLS).
+	TLS credentials.TransportCredentials
+	// DialOptions são opções adicionais repassadas ao grpc.NewClient.
+	DialOptions []grpc.DialOption
+	// RawErrors mantém os erros como status gRPC, sem convertê-los em errx.
+	RawErrors bool
+}
+
+// Dial cria a conexão com target (ex.: "payments:9090" ou "dns:///payments:9090").
+// A conexão é estabelecida sob demanda, na primeira chamada.
+func Dial(target string, opts Options) (*grpc.ClientConn, error) {
+	if opts.Timeout == 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if len(opts.RetryableCodes) == 0 {
+		opts.RetryableCodes = []codes.Code{codes.Unavailable}
+	}
+
+	creds := opts.TLS
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+
+	c := &client{target: target, opts: opts}
+	if opts.FailureThreshold > 0 {
+		c.breaker = resilience.NewCircuitBreaker(resilience.BreakerOptions{
+			Name:             "grpc:" + target,
+			FailureThreshold: opts.FailureThreshold,
+			Cooldown:         opts.Cooldown,
+			IsFailure: func(err error) bool {
+				return errgrpc.IsServerError(status.Code(err))
+			},
+		})
+	}
+
+	dialOpts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(c.unary),
+		grpc.WithChainStreamInterceptor(c.stream),
+	}, opts.DialOptions...)
+	return grpc.NewClient(target, dialOpts...)
+}
+
+// client guarda a configuração usada pelos interceptors da conexão.
+type client struct {
+	target  string
+	opts    Options
+	breaker *resilience.CircuitBreaker
+}
+
+// unary aplica trace, propagação, timeout, retry, circuit breaker e conversão de erros.
+func (c *client) unary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+	span, ctx := c.startSpan(ctx, method)
+	ctx = outgoing(ctx)
+
+	var policies []resilience.Policy
+	if c.opts.MaxRetries > 0 {
+		policies = append(policies, resilience.NewRetry(resilience.RetryOptions{
+			Name:       "grpc:" + c.target,
+			MaxRetries: c.opts.MaxRetries,
+			Backoff:    c.opts.Backoff,
+			Jitter:     true,
+			Retryable:  c.retryable,
+			OnRetry: func(ctx context.Context, attempt int, err error) {
+				logx.Ctx(ctx).Warn("Retrying gRPC call", "method", method, "attempt", attempt, "error", err)
+			},
+		}))
+	}
+	if c.breaker != nil {
+		policies = append(policies, c.breaker)
+	}
+
+	err := resilience.Do(ctx, func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok && c.opts.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, c.opts.Timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, callOpts...)
+	}, policies...)
+
+	c.finishSpan(span, err)
+	return c.convert(err)
+}
+
+// stream aplica trace, propagação e circuit breaker na abertura do stream.
+func (c *client) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+	span, ctx := c.startSpan(ctx, method)
+	ctx = outgoing(ctx)
+
+	if c.breaker != nil && !c.breaker.Allow() {
+		err := status.Error(codes.Unavailable, "circuit open")
+		c.finishSpan(span, err)
+		return nil, c.convert(err)
+	}
+
+	stream, err := streamer(ctx, desc, cc, method, callOpts...)
+	if c.breaker != nil {
+		if err != nil && errgrpc.IsServerError(status.Code(err)) {
+			c.breaker.Failure()
+		} else {
+			c.breaker.Success()
+		}
+	}
+	// O span do stream cobre apenas a abertura; a duração total depende do uso pelo chamador.
+	c.finishSpan(span, err)
+	return stream, c.convert(err)
+}
+
+// retryable informa se o status gRPC está entre os códigos repetíveis.
+func (c *client) retryable(err error) bool {
+	code := status.Code(err)
+	for _, retryable := range c.opts.RetryableCodes {
+		if code == retryable {
+			return true
+		}
+	}
+	return false
+}
+
+// convert transforma o status em AppError, salvo com RawErrors.
+func (c *client) convert(err error) error {
+	if err == nil || c.opts.RawErrors {
+		return err
+	}
+	return errgrpc.FromStatus(err)
+}
+
+// startSpan inicia o span da chamada quando o tracer está ativo.
+func (c *client) startSpan(ctx context.Context, method string) (tracer.Span, context.Context) {
+	if !dd.Enabled() {
+		return nil, ctx
+	}
+	return dd.StartSpan(ctx, "grpc.client",
+		tracer.ResourceName(method),
+		tracer.SpanType(ext.AppTypeRPC),
+		tracer.Tag(ext.SpanKind, ext.SpanKindClient),
+		tracer.Tag(ext.RPCSystem, "grpc"),
+		tracer.Tag(ext.GRPCFullMethod, method),
+		tracer.Tag(ext.PeerService, c.target),
+	)
+}
+
+// finishSpan encerra o span com o código da chamada.
+func (c *client) finishSpan(span tracer.Span, err error) {
+	if span == nil {
+		return
+	}
+	code := status.Code(err)
+	span.SetTag("grpc.code", code.String())
+	if err != nil && errgrpc.IsServerError(code) {
+		dd.SetSpanError(span, err)
+	}
+	span.Finish()
+}
+
+// outgoing propaga o correlation id e o contexto de trace na metadata da chamada.
+func outgoing(ctx context.Context) context.Context {
+	pairs := []string{}
+	if id := logx.CorrelationID(ctx); id != "" {
+		pairs = append(pairs, correlationHeader, id)
+	}
+
+	carrier := map[string]string{}
+	if dd.Enabled() {
+		_ = dd.InjectMap(ctx, carrier)
+	}
+	for key, value := range carrier {
+		pairs = append(pairs, key, value)
+	}
+
+	if len(pairs) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
@@ -0,0 +1,248 @@
+// Package grpcserver expõe serviços gRPC com a mesma ergonomia do pacote
+// server: configuração fluente, interceptors internos (correlation id, trace do
+// Datadog, log, recuperação de panics e conversão de erros da errx em status),
+// serviço de health, reflection opcional e encerramento gracioso, inclusive
+// junto com o servidor HTTP.
+//
+//	grpcserver.N().
+//		Register(func(s *grpc.Server) {
+//			paymentsv1.RegisterPaymentsServer(s, handler)
+//		}).
+//		HealthCheck("postgres", database.Check).
+//		Reflection(true).
+//		Run("9090")
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"github.com/nathanribeiroo/module-dep-projects/server"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// defaultShutdownTimeout é o tempo máximo padrão para o encerramento gracioso.
+const defaultShutdownTimeout = 10 * time.Second
+
+// ServiceMount registra serviços no servidor gRPC.
+type ServiceMount func(s *grpc.Server)
+
+// Server é o ponto central de configuração e execução do servidor gRPC.
+type Server struct {
+	grpc       *grpc.Server
+	options    []grpc.ServerOption
+	unary      []grpc.UnaryServerInterceptor
+	stream     []grpc.StreamServerInterceptor
+	services   []ServiceMount
+	reflection bool
+	noTracing  bool
+	health     *healthService
+
+	startHooks      []server.Hook
+	stopHooks       []server.Hook
+	shutdownTimeout time.Duration
+}
+
+// N devolve uma instância limpa de Server pronta para ser configurada fluentemente.
+func N() *Server {
+	return &Server{health: newHealthService()}
+}
+
+// Register injeta funções de registro de serviços gerados pelo protoc.
+func (s *Server) Register(services ...ServiceMount) *Server {
+	s.services = append(s.services, services...)
+	return s
+}
+
+// UnaryInterceptors registra interceptors unários aplicados após os internos.
+func (s *Server) UnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) *Server {
+	s.unary = append(s.unary, interceptors...)
+	return s
+}
+
+// StreamInterceptors registra interceptors de streaming aplicados após os internos.
+func (s *Server) StreamInterceptors(interceptors ...grpc.StreamServerInterceptor) *Server {
+	s.stream = append(s.stream, interceptors...)
+	return s
+}
+
+// Options repassa opções adicionais ao grpc.NewServer (ex.: credenciais TLS, limites de mensagem).
+func (s *Server) Options(opts ...grpc.ServerOption) *Server {
+	s.options = append(s.options, opts...)
+	return s
+}
+
+// Reflection habilita o serviço de reflection, usado por ferramentas como grpcurl.
+func (s *Server) Reflection(enabled bool) *Server {
+	s.reflection = enabled
+	return s
+}
+
+// DisableTracing impede a criação dos spans do Datadog pelas chamadas gRPC.
+func (s *Server) DisableTracing() *Server {
+	s.noTracing = true
+	return s
+}
+
+// HealthCheck registra uma verificação executada pelo serviço grpc.health.v1.Health;
+// se alguma falhar, o serviço responde NOT_SERVING.
+func (s *Server) HealthCheck(name string, check func(ctx context.Context) error) *Server {
+	s.health.add(name, check)
+	return s
+}
+
+// OnStart registra hooks executados, na ordem de registro, antes de o servidor
+// começar a aceitar conexões. Um erro em qualquer hook impede a inicialização.
+func (s *Server) OnStart(hooks ...server.Hook) *Server {
+	s.startHooks = append(s.startHooks, hooks...)
+	return s
+}
+
+// OnStop registra hooks executados após o encerramento gracioso do servidor,
+// na ordem inversa de registro.
+func (s *Server) OnStop(hooks ...server.Hook) *Server {
+	s.stopHooks = append(s.stopHooks, hooks...)
+	return s
+}
+
+// ShutdownTimeout define quanto tempo o servidor aguarda as chamadas em
+// andamento antes de interrompê-las no encerramento.
+func (s *Server) ShutdownTimeout(timeout time.Duration) *Server {
+	s.shutdownTimeout = timeout
+	return s
+}
+
+// GRPC devolve o *grpc.Server configurado, construindo-o se necessário
+// (útil em testes com bufconn).
+func (s *Server) GRPC() *grpc.Server {
+	if s.grpc == nil {
+		s.build()
+	}
+	return s.grpc
+}
+
+// Run expõe o servidor gRPC na porta TCP informada e o encerra graciosamente
+// ao receber SIGINT ou SIGTERM.
+func (s *Server) Run(addr string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := s.runHooks(ctx, s.startHooks); err != nil {
+		logx.L().Error("Failed to start gRPC server", "error", err)
+		return
+	}
+
+	if err := s.Start(ctx, addr); err != nil {
+		logx.L().Error("Failed to start gRPC server", "error", err)
+		return
+	}
+
+	<-ctx.Done()
+	logx.L().Info("gRPC server is shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.timeout())
+	defer cancel()
+	_ = s.Stop(shutdownCtx)
+
+	for i := len(s.stopHooks) - 1; i >= 0; i-- {
+		if err := s.stopHooks[i](shutdownCtx); err != nil {
+			logx.L().Error("Stop hook failed", "error", err)
+		}
+	}
+}
+
+// Attach executa o servidor gRPC junto com o servidor HTTP: ele é iniciado
+// nos hooks de início do HTTP e encerrado graciosamente nos hooks de parada,
+// compartilhando sinais e prazo de encerramento.
+//
+//	grpcserver.N().Register(mount).Attach(server.N().Routes(routes), "9090").Run("8080")
+func (s *Server) Attach(httpServer *server.Server, addr string) *server.Server {
+	return httpServer.
+		OnStart(s.startHooks...).
+		OnStart(func(ctx context.Context) error { return s.Start(ctx, addr) }).
+		OnStop(s.stopHooks...).
+		OnStop(s.Stop)
+}
+
+// Start abre o listener e atende as chamadas em segundo plano.
+func (s *Server) Start(_ context.Context, addr string) error {
+	lis, err := net.Listen("tcp", ":"+addr)
+	if err != nil {
+		return err
+	}
+
+	srv := s.GRPC()
+	go func() {
+		logx.L().Info("gRPC server is running", "addr", lis.Addr().String())
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			logx.L().Error("Failed to serve gRPC", "error", err)
+		}
+	}()
+	return nil
+}
+
+// Stop marca o serviço como NOT_SERVING e aguarda as chamadas em andamento;
+// ao fim do prazo de ctx, as chamadas restantes são interrompidas.
+func (s *Server) Stop(ctx context.Context) error {
+	if s.grpc == nil {
+		return nil
+	}
+	s.health.shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		return ctx.Err()
+	}
+}
+
+// build cria o *grpc.Server com os interceptors internos e registra os serviços.
+func (s *Server) build() {
+	opts := append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(s.unaryInterceptors()...),
+		grpc.ChainStreamInterceptor(s.streamInterceptors()...),
+	}, s.options...)
+
+	s.grpc = grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+	if s.reflection {
+		reflection.Register(s.grpc)
+	}
+	for _, mount := range s.services {
+		mount(s.grpc)
+	}
+}
+
+// runHooks executa os hooks em ordem, interrompendo no primeiro erro.
+func (s *Server) runHooks(ctx context.Context, hooks []server.Hook) error {
+	for _, hook := range hooks {
+		if err := hook(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// timeout devolve o prazo de encerramento configurado ou o padrão.
+func (s *Server) timeout() time.Duration {
+	if s.shutdownTimeout <= 0 {
+		return defaultShutdownTimeout
+	}
+	return s.shutdownTimeout
+}
@@ -0,0 +1,71 @@
+package grpcserver
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthCheckTimeout limita a duração de cada verificação do serviço de health.
+const healthCheckTimeout = 5 * time.Second
+
+// healthService implementa grpc.health.v1.Health executando as verificações
+// registradas a cada consulta, como o /healthcheck do servidor HTTP.
+type healthService struct {
+	healthpb.UnimplementedHealthServer
+
+	checks   map[string]func(ctx context.Context) error
+	draining atomic.Bool
+}
+
+// newHealthService cria o serviço sem verificações.
+func newHealthService() *healthService {
+	return &healthService{checks: map[string]func(ctx context.Context) error{}}
+}
+
+// add registra uma verificação.
+func (h *healthService) add(name string, check func(ctx context.Context) error) {
+	h.checks[name] = check
+}
+
+// shutdown passa a responder NOT_SERVING, retirando a instância do balanceamento.
+func (h *healthService) shutdown() {
+	h.draining.Store(true)
+}
+
+// Check implementa healthpb.HealthServer. O nome do serviço é ignorado: a
+// resposta reflete todas as verificações registradas.
+func (h *healthService) Check(ctx context.Context, _ *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if h.draining.Load() || !h.healthy(ctx) {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// healthy executa as verificações em paralelo.
+func (h *healthService) healthy(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		healthy atomic.Bool
+	)
+	healthy.Store(true)
+	for name, check := range h.checks {
+		wg.Add(1)
+		go func(name string, check func(ctx context.Context) error) {
+			defer wg.Done()
+			if err := check(ctx); err != nil {
+				logx.Ctx(ctx).Warn("gRPC health check failed", "check", name, "error", err)
+				healthy.Store(false)
+			}
+		}(name, check)
+	}
+	wg.Wait()
+	return healthy.Load()
+}
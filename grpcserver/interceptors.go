@@ -0,0 +1,148 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/errx/errgrpc"
+	"github.com/nathanribeiroo/module-dep-projects/idgen"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// correlationHeader é a chave de metadata do correlation id, a mesma do cabeçalho HTTP.
+const correlationHeader = "x-itau-correlation-id"
+
+// unaryInterceptors devolve a cadeia interna das chamadas unárias, na ordem:
+// correlation id, trace, log, conversão de erros e recuperação de panics.
+func (s *Server) unaryInterceptors() []grpc.UnaryServerInterceptor {
+	return append([]grpc.UnaryServerInterceptor{
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+			ctx = s.incoming(ctx)
+
+			span, ctx := s.startSpan(ctx, info.FullMethod)
+			start := time.Now()
+			defer func() {
+				s.finish(ctx, span, info.FullMethod, start, err)
+			}()
+
+			defer func() {
+				if r := recover(); r != nil {
+					err = errgrpc.ToStatus(panicError(r))
+				}
+			}()
+
+			resp, err = handler(ctx, req)
+			return resp, errgrpc.ToStatus(err)
+		},
+	}, s.unary...)
+}
+
+// streamInterceptors devolve a cadeia interna das chamadas com streaming.
+func (s *Server) streamInterceptors() []grpc.StreamServerInterceptor {
+	return append([]grpc.StreamServerInterceptor{
+		func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+			ctx := s.incoming(ss.Context())
+
+			span, ctx := s.startSpan(ctx, info.FullMethod)
+			start := time.Now()
+			defer func() {
+				s.finish(ctx, span, info.FullMethod, start, err)
+			}()
+
+			defer func() {
+				if r := recover(); r != nil {
+					err = errgrpc.ToStatus(panicError(r))
+				}
+			}()
+
+			return errgrpc.ToStatus(handler(srv, &contextStream{ServerStream: ss, ctx: ctx}))
+		},
+	}, s.stream...)
+}
+
+// incoming registra o correlation id recebido (ou gera um novo) no contexto e
+// o devolve ao cliente no header da resposta.
+func (s *Server) incoming(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	id := ""
+	if values := md.Get(correlationHeader); len(values) > 0 {
+		id = values[0]
+	}
+	if id == "" {
+		id = idgen.UUIDv7()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(correlationHeader, id))
+	return logx.WithCorrelationID(ctx, id)
+}
+
+// startSpan inicia o span da chamada, continuando o trace propagado na metadata.
+func (s *Server) startSpan(ctx context.Context, method string) (tracer.Span, context.Context) {
+	if s.noTracing || !dd.Enabled() {
+		return nil, ctx
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	carrier := make(map[string]string, len(md))
+	for key, values := range md {
+		if len(values) > 0 {
+			carrier[key] = values[0]
+		}
+	}
+
+	opts := []tracer.StartSpanOption{
+		tracer.ResourceName(method),
+		tracer.SpanType(ext.AppTypeRPC),
+		tracer.Tag(ext.SpanKind, ext.SpanKindServer),
+		tracer.Tag(ext.RPCSystem, "grpc"),
+		tracer.Tag(ext.GRPCFullMethod, method),
+	}
+	if sc, err := dd.ExtractMap(carrier); err == nil {
+		opts = append(opts, tracer.ChildOf(sc))
+	}
+	return dd.StartSpan(ctx, "grpc.server", opts...)
+}
+
+// finish encerra o span e registra o log da chamada.
+func (s *Server) finish(ctx context.Context, span tracer.Span, method string, start time.Time, err error) {
+	code := status.Code(err)
+	if span != nil {
+		span.SetTag("grpc.code", code.String())
+		if err != nil && errgrpc.IsServerError(code) {
+			dd.SetSpanError(span, err)
+		}
+		span.Finish()
+	}
+
+	logx.Ctx(ctx).Info("grpc request",
+		"method", method,
+		"code", code.String(),
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+}
+
+// panicError converte o valor recuperado de um panic em uma AppError INTERNAL.
+func panicError(recovered interface{}) error {
+	logx.L().Error("Recovered from panic in gRPC handler", "panic", fmt.Sprint(recovered), "stack", string(debug.Stack()))
+	return errx.New("internal server error").WithCode(errx.INTERNAL)
+}
+
+// contextStream substitui o contexto do stream pelo contexto enriquecido.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context implementa grpc.ServerStream.
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,59 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/server"
+)
+
+// Attach integra o registro ao servidor: as verificações críticas passam a
+// compor o /healthcheck (respondendo do cache), as verificações rodam em
+// segundo plano durante a vida do servidor e o relatório completo, com as
+// dependências não críticas, é publicado em GET /healthcheck/details.
+func (r *Registry) Attach(s *server.Server) *server.Server {
+	for _, name := range r.Names() {
+		result, _ := r.Result(name)
+		if !result.Critical {
+			continue
+		}
+		s.HealthCheck(name, r.Cached(name))
+	}
+
+	return s.
+		OnStart(r.Start).
+		OnStop(r.Stop).
+		Routes(func(router gin.IRouter) {
+			router.GET("/healthcheck/details", r.Handler())
+		})
+}
+
+// Cached devolve uma verificação compatível com server.HealthCheck que
+// responde com o último resultado da verificação informada.
+func (r *Registry) Cached(name string) func(ctx context.Context) error {
+	return func(context.Context) error {
+		result, ok := r.Result(name)
+		switch {
+		case !ok:
+			return errors.New("health: unknown check " + name)
+		case result.Status == StatusDown:
+			return errors.New(result.Error)
+		}
+		return nil
+	}
+}
+
+// Handler responde com o relatório em cache: 200 quando o serviço está up ou
+// degradado e 503 quando uma dependência crítica falhou.
+func (r *Registry) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := r.Report()
+		status := http.StatusOK
+		if report.Status == StatusDown {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, report)
+	}
+}
@@ -0,0 +1,297 @@
+// Package health mantém um registro das dependências do serviço (banco, cache,
+// filas, APIs externas), verifica cada uma periodicamente em segundo plano e
+// guarda o último resultado. Assim, os endpoints de saúde respondem do cache,
+// sem sobrecarregar as dependências a cada requisição, e o estado é enviado ao
+// Datadog como service check.
+//
+//	registry := health.New(health.Options{Interval: 15 * time.Second})
+//	registry.Register("postgres", health.CheckerFunc(database.Check))
+//	registry.Register("billing-api", billingPing, health.NonCritical())
+//
+//	registry.Attach(server.N()).Run("8080")
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// Status é o estado de uma verificação ou do serviço.
+type Status string
+
+const (
+	// StatusUp indica que a dependência respondeu.
+	StatusUp Status = "up"
+	// StatusDown indica que uma dependência crítica falhou.
+	StatusDown Status = "down"
+	// StatusDegraded indica que apenas dependências não críticas falharam.
+	StatusDegraded Status = "degraded"
+	// StatusUnknown indica que a verificação ainda não foi executada.
+	StatusUnknown Status = "unknown"
+)
+
+// Checker verifica uma dependência, devolvendo erro se ela estiver indisponível.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapta uma função à interface Checker (ex.: db.(*DB).Check).
+type CheckerFunc func(ctx context.Context) error
+
+// Check implementa Checker.
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Result é o último resultado de uma verificação.
+type Result struct {
+	Status    Status        `json:"status"`
+	Error     string        `json:"error,omitempty"`
+	Critical  bool          `json:"critical"`
+	Duration  time.Duration `json:"duration"`
+	CheckedAt time.Time     `json:"checked_at,omitempty"`
+}
+
+// Report é o estado consolidado do serviço.
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Options configura o registro.
+type Options struct {
+	// Interval é o intervalo entre as verificações em segundo plano (padrão: 30s).
+	Interval time.Duration
+	// Timeout limita cada verificação (padrão: 5s).
+	Timeout time.Duration
+	// ServiceCheck é o nome do service check enviado ao Datadog, com a tag
+	// check:<nome> (padrão: "<serviço>.dependency"). Use "-" para não enviar.
+	ServiceCheck string
+}
+
+// CheckOption personaliza uma verificação registrada.
+type CheckOption func(*check)
+
+// NonCritical marca a dependência como não crítica: a falha dela deixa o
+// serviço degradado, mas não indisponível.
+func NonCritical() CheckOption {
+	return func(c *check) {
+		c.critical = false
+	}
+}
+
+// check é uma verificação registrada com o seu último resultado.
+type check struct {
+	name     string
+	checker  Checker
+	critical bool
+	result   Result
+}
+
+// Registry guarda as verificações e os seus resultados.
+type Registry struct {
+	opts Options
+
+	mu     sync.RWMutex
+	checks []*check
+
+	runMu  sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New cria um registro vazio.
+func New(opts Options) *Registry {
+	if opts.Interval <= 0 {
+		opts.Interval = 30 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	return &Registry{opts: opts}
+}
+
+// Register adiciona uma verificação, crítica por padrão.
+func (r *Registry) Register(name string, checker Checker, opts ...CheckOption) *Registry {
+	c := &check{name: name, checker: checker, critical: true}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.result = Result{Status: StatusUnknown, Critical: c.critical}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, c)
+	return r
+}
+
+// Start executa as verificações imediatamente e depois a cada intervalo, em
+// segundo plano; compatível com server.OnStart.
+func (r *Registry) Start(ctx context.Context) error {
+	r.runMu.Lock()
+	defer r.runMu.Unlock()
+
+	if r.cancel != nil {
+		return errors.New("health: registry already started")
+	}
+
+	r.CheckNow(ctx)
+
+	ctx, r.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.opts.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.CheckNow(ctx)
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop interrompe as verificações em segundo plano; compatível com server.OnStop.
+func (r *Registry) Stop(ctx context.Context) error {
+	r.runMu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel = nil
+	r.runMu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CheckNow executa todas as verificações em paralelo e atualiza os resultados.
+func (r *Registry) CheckNow(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := append([]*check(nil), r.checks...)
+	r.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c *check) {
+			defer wg.Done()
+			r.run(ctx, c)
+		}(c)
+	}
+	wg.Wait()
+	return r.Report()
+}
+
+// Report devolve os últimos resultados, sem executar as verificações.
+func (r *Registry) Report() Report {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]Result, len(r.checks))}
+	for _, c := range r.checks {
+		report.Checks[c.name] = c.result
+		switch {
+		case c.result.Status != StatusDown:
+		case c.critical:
+			report.Status = StatusDown
+		case report.Status == StatusUp:
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+// Result devolve o último resultado da verificação informada.
+func (r *Registry) Result(name string) (Result, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, c := range r.checks {
+		if c.name == name {
+			return c.result, true
+		}
+	}
+	return Result{}, false
+}
+
+// Names devolve os nomes das verificações registradas, em ordem alfabética.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.checks))
+	for _, c := range r.checks {
+		names = append(names, c.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// run executa uma verificação, grava o resultado e o publica no Datadog.
+func (r *Registry) run(ctx context.Context, c *check) {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.checker.Check(ctx)
+	result := Result{Status: StatusUp, Critical: c.critical, Duration: time.Since(start), CheckedAt: start}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	previous := c.result.Status
+	c.result = result
+	r.mu.Unlock()
+
+	if err != nil && previous != StatusDown {
+		logx.Ctx(ctx).Warn("Health check failed", "check", c.name, "critical", c.critical, "error", err)
+	}
+	if err == nil && previous == StatusDown {
+		logx.Ctx(ctx).Info("Health check recovered", "check", c.name)
+	}
+	r.publish(c.name, result)
+}
+
+// publish envia o resultado como service check e a duração como métrica.
+func (r *Registry) publish(name string, result Result) {
+	tags := []string{"check:" + name}
+	dd.Metrics().Timing("health.check.duration", result.Duration, tags...)
+
+	serviceCheck := r.opts.ServiceCheck
+	if serviceCheck == "-" {
+		return
+	}
+	if serviceCheck == "" {
+		serviceCheck = dd.ServiceName() + ".dependency"
+	}
+
+	status := dd.CheckOK
+	switch {
+	case result.Status != StatusDown:
+	case result.Critical:
+		status = dd.CheckCritical
+	default:
+		status = dd.CheckWarning
+	}
+	dd.Metrics().ServiceCheck(serviceCheck, status, result.Error, tags...)
+}
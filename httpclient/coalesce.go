@@ -0,0 +1,107 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"golang.org/x/sync/singleflight"
+)
+
+// inflight agrupa as requisições GET idênticas em andamento. A chave inclui a
+// configuração do cliente (veja coalesceKey), para que só clientes equivalentes
+// compartilhem respostas.
+var inflight singleflight.Group
+
+// coalescedResponse é o resultado compartilhado entre as requisições agrupadas.
+type coalescedResponse struct {
+	body   []byte
+	status int
+}
+
+// Coalesce agrupa as requisições GET idênticas (mesma URL e mesmos cabeçalhos)
+// feitas ao mesmo tempo em uma única chamada ao destino, cuja resposta é
+// entregue a todas, contendo picos de acesso a recursos cacheáveis. Só são
+// agrupadas requisições de clientes com as mesmas chaves fixadas, timeout e
+// política de retry; clientes com hooks agrupam apenas as próprias requisições.
+// A chamada compartilhada não é cancelada pelo contexto de quem a iniciou:
+// cada requisição aguarda até o próprio contexto terminar.
+func (h *HttpClient) Coalesce() *HttpClient {
+	h.coalesce = true
+	return h
+}
+
+// sendCoalesced envia a requisição, compartilhando a chamada com as idênticas em andamento.
+func sendCoalesced(h *HttpClient, request *http.Request) ([]byte, int, error) {
+	key := h.coalesceKey() + requestSignature(request)
+	// A chamada mantém os valores do contexto (trace), mas não o cancelamento.
+	detached := request.Clone(context.WithoutCancel(request.Context()))
+	ch := inflight.DoChan(key, func() (interface{}, error) {
+		body, status, err := sendClient(h, detached)
+		return coalescedResponse{body: body, status: status}, err
+	})
+
+	select {
+	case result := <-ch:
+		if result.Shared {
+			dd.Metrics().Incr("httpclient.request.coalesced", "host:"+request.URL.Host)
+		}
+		resp := result.Val.(coalescedResponse)
+		// Cada chamador recebe a sua cópia, pois o corpo pode ser alterado por quem o lê.
+		return bytes.Clone(resp.body), resp.status, result.Err
+	case <-request.Context().Done():
+		status, err := classifyError(request, request.Context().Err())
+		return nil, status, err
+	}
+}
+
+// coalesceKey identifica a configuração do cliente que altera o resultado da
+// chamada: chaves fixadas, timeout, retry, FailOnExhaustedStatus e limite de
+// descompressão. Com hooks ou handlers de status, o próprio cliente entra na
+// chave, pois os de outro cliente não seriam chamados.
+func (h *HttpClient) coalesceKey() string {
+	hosts := make([]string, 0, len(h.pins))
+	for host := range h.pins {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var key strings.Builder
+	fmt.Fprintf(&key, "%d|%d|%t|%t|%d|", h.timeout, h.retryCount, h.retryNonIdempotent, h.failOnExhausted, h.maxDecompressed)
+	for _, host := range hosts {
+		pins := make([]string, 0, len(h.pins[host]))
+		for pin := range h.pins[host] {
+			pins = append(pins, pin)
+		}
+		sort.Strings(pins)
+		fmt.Fprintf(&key, "%s=%s;", host, strings.Join(pins, ","))
+	}
+	if len(h.hooks) > 0 || len(h.statusHandlers) > 0 || len(h.familyHandlers) > 0 {
+		fmt.Fprintf(&key, "|%p", h)
+	}
+	return key.String() + "|"
+}
+
+// requestSignature identifica a requisição pelo método, URL e cabeçalhos.
+func requestSignature(request *http.Request) string {
+	keys := make([]string, 0, len(request.Header))
+	for key := range request.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	sum := sha256.New()
+	sum.Write([]byte(request.Method + " " + request.URL.String() + "\n"))
+	for _, key := range keys {
+		for _, value := range request.Header[key] {
+			sum.Write([]byte(key + ": " + value + "\n"))
+		}
+	}
+	return hex.EncodeToString(sum.Sum(nil))
+}
@@ -0,0 +1,56 @@
+package httpclient
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// defaultMaxDecompressedSize é o limite padrão do corpo descompactado (32 MiB).
+const defaultMaxDecompressedSize = 32 << 20
+
+// ErrDecompressedTooLarge indica que o corpo descompactado ultrapassou o limite
+// configurado, protegendo o serviço de respostas infladas (zip bombs).
+var ErrDecompressedTooLarge = errors.New("httpclient: decompressed response too large")
+
+// SetMaxDecompressedSize define o limite, em bytes, do corpo de respostas gzip
+// após a descompactação (padrão: 32 MiB; use -1 para não limitar).
+func (h *HttpClient) SetMaxDecompressedSize(limit int64) *HttpClient {
+	h.maxDecompressed = limit
+	return h
+}
+
+// responseBody devolve o leitor do corpo: respostas gzip são descompactadas
+// (se o transport ainda não o fez) e limitadas a maxDecompressed bytes.
+func responseBody(h *HttpClient, resp *http.Response) (io.Reader, func() error, error) {
+	body := io.Reader(resp.Body)
+	compressed := resp.Uncompressed
+
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("httpclient: gzip response: %w", err)
+		}
+		body, compressed = gz, true
+	}
+
+	limit := h.maxDecompressed
+	if limit == 0 {
+		limit = defaultMaxDecompressedSize
+	}
+	if !compressed || limit < 0 {
+		return body, func() error { return nil }, nil
+	}
+
+	limited := &io.LimitedReader{R: body, N: limit + 1}
+	check := func() error {
+		if limited.N <= 0 {
+			return fmt.Errorf("%w: limit is %d bytes", ErrDecompressedTooLarge, limit)
+		}
+		return nil
+	}
+	return limited, check, nil
+}
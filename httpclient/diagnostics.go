@@ -0,0 +1,87 @@
+package httpclient
+
+import (
+	"errors"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// maxBodySnapshot limita o trecho do corpo da última resposta incluído no log.
+const maxBodySnapshot = 1024
+
+// attemptSnapshot resume uma tentativa para o diagnóstico de falhas.
+type attemptSnapshot struct {
+	Attempt    int    `json:"attempt"`
+	Status     int    `json:"status,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// newAttemptSnapshot registra o resultado de uma tentativa.
+func newAttemptSnapshot(attempt int, status int, duration time.Duration, err error) attemptSnapshot {
+	snapshot := attemptSnapshot{Attempt: attempt, DurationMs: duration.Milliseconds()}
+	var appErr *errx.AppError
+	switch {
+	case errors.As(err, &appErr):
+		// Apenas a mensagem da errx: a causa pode trazer a URL do destino.
+		snapshot.Error = appErr.Message
+	case err != nil:
+		snapshot.Error = err.Error()
+	default:
+		snapshot.Status = status
+	}
+	return snapshot
+}
+
+// exhaustedError descreve a falha após esgotar as novas tentativas: o status
+// da última resposta e a duração de cada tentativa. A URL e o início do corpo
+// da última resposta vão apenas para o log, pois os Details podem chegar ao
+// cliente da API.
+func exhaustedError(request *http.Request, status int, body []byte, attempts []attemptSnapshot, cause error) error {
+	details := map[string]interface{}{
+		"method":   request.Method,
+		"attempts": attempts,
+	}
+
+	var statusErr *retryableStatusError
+	code := errx.UNAVAILABLE
+	if errx.IsAppError(cause) {
+		code = errx.GetCode(cause)
+		if kind, ok := errx.GetDetails(cause)["kind"]; ok {
+			details["kind"] = kind
+		}
+	}
+	logArgs := []any{"url", request.URL.Redacted(), "attempts", len(attempts)}
+	if errors.As(cause, &statusErr) {
+		code = errx.StatusToCode(status)
+		details["last_status"] = status
+		logArgs = append(logArgs, "last_status", status, "last_body", truncateBody(body))
+		cause = nil
+	}
+	logx.Ctx(request.Context()).Warn("http request failed after retries", logArgs...)
+
+	appErr := errx.New("http request failed after retries").
+		WithCode(code).
+		WithDependency(request.URL.Host).
+		WithDetails(details)
+	if cause != nil {
+		appErr = appErr.WithError(cause)
+	}
+	return appErr
+}
+
+// truncateBody devolve até maxBodySnapshot bytes do corpo, sem cortar caracteres UTF-8.
+func truncateBody(body []byte) string {
+	if len(body) <= maxBodySnapshot {
+		return string(body)
+	}
+	cut := maxBodySnapshot
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return string(body[:cut]) + "...(truncated)"
+}
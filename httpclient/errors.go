@@ -0,0 +1,76 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// Tipos de falha de transporte, enviados em Details["kind"].
+const (
+	KindTimeout           = "timeout"
+	KindConnectionRefused = "connection_refused"
+	KindConnectionReset   = "connection_reset"
+	KindDNS               = "dns"
+	KindTLS               = "tls"
+	KindNetwork           = "network"
+)
+
+// classifyError converte a falha de transporte em erro da errx: timeouts viram
+// TIMEOUT (504) e as demais falhas, UNAVAILABLE (503), com o tipo em
+// Details["kind"]. A causa original continua acessível por errors.Is/As. O
+// cancelamento do contexto é devolvido sem conversão.
+func classifyError(request *http.Request, err error) (int, error) {
+	if errors.Is(err, context.Canceled) {
+		return 500, err
+	}
+
+	kind, code, message := errorKind(err)
+	appErr := errx.New(message).
+		WithCode(code).
+		WithError(err).
+		WithDependency(request.URL.Host).
+		WithDetails(map[string]interface{}{
+			"kind":   kind,
+			"method": request.Method,
+			"host":   request.URL.Host,
+		})
+	return errx.ToHTTPCode(code), appErr
+}
+
+// errorKind identifica o tipo da falha, o código da errx e a mensagem.
+func errorKind(err error) (string, errx.Code, string) {
+	var (
+		dnsErr     *net.DNSError
+		netErr     net.Error
+		certErr    *tls.CertificateVerificationError
+		recordErr  tls.RecordHeaderError
+		alertErr   tls.AlertError
+		authErr    x509.UnknownAuthorityError
+		hostErr    x509.HostnameError
+		invalidErr x509.CertificateInvalidError
+	)
+
+	switch {
+	case errors.As(err, &dnsErr) && !dnsErr.IsTimeout:
+		return KindDNS, errx.UNAVAILABLE, "http request failed: dns lookup failed"
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return KindTimeout, errx.TIMEOUT, "http request timed out"
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return KindConnectionRefused, errx.UNAVAILABLE, "http request failed: connection refused"
+	case errors.Is(err, syscall.ECONNRESET):
+		return KindConnectionReset, errx.UNAVAILABLE, "http request failed: connection reset"
+	case errors.Is(err, ErrCertificatePinMismatch),
+		errors.As(err, &certErr), errors.As(err, &recordErr), errors.As(err, &alertErr),
+		errors.As(err, &authErr), errors.As(err, &hostErr), errors.As(err, &invalidErr):
+		return KindTLS, errx.UNAVAILABLE, "http request failed: tls handshake failed"
+	default:
+		return KindNetwork, errx.UNAVAILABLE, "http request failed"
+	}
+}
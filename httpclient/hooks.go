@@ -0,0 +1,140 @@
+package httpclient
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// RequestInfo descreve uma tentativa de requisição, entregue aos hooks.
+type RequestInfo struct {
+	Method string
+	// URL é a URL com a senha ocultada.
+	URL  string
+	Host string
+	// Attempt é o número da tentativa (1 na primeira).
+	Attempt  int
+	Status   int
+	Duration time.Duration
+	// Err é a falha de rede ou de leitura, se houver; respostas com status de
+	// erro não são consideradas falhas aqui.
+	Err error
+	// Labels são os rótulos definidos com WithLabel.
+	Labels map[string]string
+}
+
+// Hook recebe o resultado de cada tentativa de requisição.
+type Hook func(ctx context.Context, info RequestInfo)
+
+var (
+	hooksMu sync.RWMutex
+	// globalHooks são chamados para as requisições de todos os clientes.
+	globalHooks []Hook
+)
+
+// RegisterHook registra um hook chamado a cada tentativa de qualquer cliente,
+// além do log e das métricas padrão (ex.: para um painel de dependências).
+func RegisterHook(hook Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	globalHooks = append(globalHooks, hook)
+}
+
+// WithLabel associa um rótulo à requisição (ex.: WithLabel("operation", "get-balance")).
+// Os rótulos são enviados aos hooks e incluídos nos logs, nas tags das métricas
+// e nos spans, dispensando extrair a operação da URL nos painéis.
+func (h *HttpClient) WithLabel(key, value string) *HttpClient {
+	if h.labels == nil {
+		h.labels = map[string]string{}
+	}
+	h.labels[key] = value
+	return h
+}
+
+// OnRequest registra um hook chamado a cada tentativa deste cliente.
+func (h *HttpClient) OnRequest(hook Hook) *HttpClient {
+	h.hooks = append(h.hooks, hook)
+	return h
+}
+
+// emit registra a tentativa no log e nas métricas e a entrega aos hooks.
+func emit(ctx context.Context, h *HttpClient, info RequestInfo) {
+	logRequest(ctx, info)
+	recordMetrics(info)
+
+	hooksMu.RLock()
+	hooks := append(append([]Hook(nil), globalHooks...), h.hooks...)
+	hooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		hook(ctx, info)
+	}
+}
+
+// logRequest registra a tentativa: falhas como erro e as demais em debug.
+func logRequest(ctx context.Context, info RequestInfo) {
+	args := []any{
+		"method", info.Method,
+		"url", info.URL,
+		"attempt", info.Attempt,
+	}
+	for _, key := range sortedKeys(info.Labels) {
+		args = append(args, slog.String("label."+key, info.Labels[key]))
+	}
+
+	if info.Err != nil {
+		logx.Ctx(ctx).Error("http request failed", append(args, "error", info.Err)...)
+		return
+	}
+	logx.Ctx(ctx).Debug("http request", append(args,
+		"status", info.Status,
+		"duration_ms", info.Duration.Milliseconds(),
+	)...)
+}
+
+// recordMetrics publica a duração e o resultado da tentativa.
+func recordMetrics(info RequestInfo) {
+	tags := []string{"host:" + info.Host, "method:" + info.Method}
+	for _, key := range sortedKeys(info.Labels) {
+		tags = append(tags, key+":"+info.Labels[key])
+	}
+
+	if info.Err != nil {
+		dd.Metrics().Incr("httpclient.request.error", tags...)
+		return
+	}
+	dd.Metrics().Timing("httpclient.request.duration", info.Duration, append(tags, "status:"+strconv.Itoa(info.Status))...)
+}
+
+// requestSpanOptions monta as opções do span de uma tentativa, com os rótulos como tags.
+func requestSpanOptions(method string, host string, url string, labels map[string]string) []tracer.StartSpanOption {
+	opts := []tracer.StartSpanOption{
+		tracer.ResourceName(method + " " + host),
+		tracer.SpanType(ext.SpanTypeHTTP),
+		tracer.Tag(ext.SpanKind, ext.SpanKindClient),
+		tracer.Tag(ext.HTTPMethod, method),
+		tracer.Tag(ext.HTTPURL, url),
+	}
+	for key, value := range labels {
+		opts = append(opts, tracer.Tag("label."+key, value))
+	}
+	return opts
+}
+
+// sortedKeys devolve as chaves em ordem, para logs e tags estáveis.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
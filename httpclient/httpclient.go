@@ -1,9 +1,17 @@
 package httpclient
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/resilience"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
 )
 
 // Status codes que merecem retry
@@ -16,16 +24,57 @@ var retryableStatus = map[int]bool{
 	http.StatusGatewayTimeout:      true, // 504
 }
 
+// Métodos idempotentes, repetidos automaticamente. POST e PATCH só são
+// repetidos com AllowNonIdempotentRetry ou com uma chave de idempotência.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// idempotencyKeyHeader é o cabeçalho da chave de idempotência enviada por SetIdempotencyKey.
+const idempotencyKeyHeader = "Idempotency-Key"
+
 type OptionsHttpclient struct {
 	RetryCount int
 	Timeout    int
 }
 
+// TokenSource fornece o token Bearer de cada requisição (ex.: auth.TokenSource).
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
 type HttpClient struct {
+	ctx        context.Context
 	url        string
 	headers    map[string]string
+	tokens     TokenSource
 	retryCount int
-	timeout    int
+	// soapVersion é a versão do envelope enviado por SendSOAP.
+	soapVersion SOAPVersion
+	// labels identificam a requisição nos logs, métricas, spans e hooks (veja WithLabel).
+	labels map[string]string
+	// hooks recebem o resultado de cada tentativa (veja OnRequest).
+	hooks []Hook
+	// pins são as chaves públicas fixadas por host (veja PinCertificates).
+	pins      map[string]map[string]bool
+	transport http.RoundTripper
+	// maxDecompressed limita o corpo descompactado (veja SetMaxDecompressedSize).
+	maxDecompressed int64
+	// statusHandlers e familyHandlers tratam as respostas (veja OnStatus).
+	statusHandlers map[int]ResponseHandler
+	familyHandlers map[int]ResponseHandler
+	// coalesce agrupa GETs idênticos concorrentes (veja Coalesce).
+	coalesce bool
+	// retryNonIdempotent permite repetir POST e PATCH (veja AllowNonIdempotentRetry).
+	retryNonIdempotent bool
+	// failOnExhausted devolve erro ao esgotar as tentativas em status repetível (veja FailOnExhaustedStatus).
+	failOnExhausted bool
+	timeout         int
 }
 
 func NewHttpClient(ops OptionsHttpclient) *HttpClient {
@@ -33,6 +82,7 @@ func NewHttpClient(ops OptionsHttpclient) *HttpClient {
 	header["Content-Type"] = "application/json"
 
 	return &HttpClient{
+		ctx:        context.Background(),
 		headers:    header,
 		retryCount: ops.RetryCount,
 		timeout:    ops.Timeout,
@@ -44,6 +94,12 @@ func (h *HttpClient) SetUrl(url string) *HttpClient {
 	return h
 }
 
+// SetContext define o contexto da requisição, usado para propagar o trace do Datadog.
+func (h *HttpClient) SetContext(ctx context.Context) *HttpClient {
+	h.ctx = ctx
+	return h
+}
+
 func (h *HttpClient) SetHeader(key, value string) *HttpClient {
 	h.headers[key] = value
 	return h
@@ -54,22 +110,81 @@ func (h *HttpClient) SetBearerToken(token string) *HttpClient {
 	return h
 }
 
+// SetIdempotencyKey envia a chave no cabeçalho Idempotency-Key, o que também
+// permite repetir POST e PATCH, já que o servidor descarta as duplicatas.
+func (h *HttpClient) SetIdempotencyKey(key string) *HttpClient {
+	h.headers[idempotencyKeyHeader] = key
+	return h
+}
+
+// AllowNonIdempotentRetry permite repetir POST e PATCH mesmo sem chave de
+// idempotência, para operações que o destino trata como idempotentes
+// (ex.: consultas via SOAP).
+func (h *HttpClient) AllowNonIdempotentRetry() *HttpClient {
+	h.retryNonIdempotent = true
+	return h
+}
+
+// FailOnExhaustedStatus faz com que, esgotadas as novas tentativas em um status
+// repetível (500, 502, 503, 504...), a chamada devolva um erro da errx com o
+// código do status e o resumo das tentativas. Sem ela, a última resposta é
+// devolvida com erro nil, e cabe ao chamador conferir o status. Os tratamentos
+// de OnStatus e OnServerError têm precedência: com eles, a última resposta é
+// entregue ao tratamento.
+func (h *HttpClient) FailOnExhaustedStatus() *HttpClient {
+	h.failOnExhausted = true
+	return h
+}
+
+// SetTokenSource obtém da fonte informada o token Bearer enviado em cada requisição.
+func (h *HttpClient) SetTokenSource(tokens TokenSource) *HttpClient {
+	h.tokens = tokens
+	return h
+}
+
 func (h *HttpClient) SendGet() ([]byte, int, error) {
-	req, err := http.NewRequest("GET", h.url, nil)
+	req, err := h.newRequest("GET", nil)
 
 	if err != nil {
 		return nil, 500, err
 	}
 
-	setHeaderInNewRequest(h.headers, req)
-
-	response, statusCode, err := sendClient(h, req)
+	send := sendClient
+	if h.coalesce {
+		send = sendCoalesced
+	}
+	response, statusCode, err := send(h, req)
 
 	if err != nil {
 		return nil, statusCode, err
 	}
 
-	return response, statusCode, err
+	return h.route(response, statusCode, nil)
+}
+
+// newRequest monta a requisição com os cabeçalhos configurados e o token da
+// TokenSource, quando houver. O trace é propagado a cada tentativa, em doRequest.
+func (h *HttpClient) newRequest(method string, body []byte) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(h.ctx, method, h.url, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	setHeaderInNewRequest(h.headers, req)
+
+	if h.tokens != nil {
+		token, err := h.tokens.Token(h.ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
 }
 
 func (h *HttpClient) SendPost() {
@@ -83,16 +198,147 @@ func setHeaderInNewRequest(headers map[string]string, h *http.Request) {
 }
 
 func sendClient(h *HttpClient, request *http.Request) ([]byte, int, error) {
+	return sendClientWith(h, request, nil)
+}
+
+// sendClientWith envia a requisição como sendClient; final, quando informado,
+// marca respostas com status repetível que não devem ser repetidas (ex.: um
+// Fault SOAP, que chega como 500 mas é uma falha de negócio).
+func sendClientWith(h *HttpClient, request *http.Request, final func(body []byte, status int) bool) ([]byte, int, error) {
+	if err := h.checkPinnedScheme(request); err != nil {
+		return nil, 500, err
+	}
+	client := &http.Client{Timeout: time.Duration(h.timeout) * time.Second, Transport: h.httpTransport()}
+
+	var (
+		body   []byte
+		status int
+	)
+
+	// Falhas de rede e os status de retryableStatus são repetidos até RetryCount
+	// vezes, apenas quando repetir a requisição não duplica efeitos colaterais.
+	maxRetries := h.retryCount
+	if maxRetries <= 0 || !canRetry(h, request) {
+		maxRetries = -1
+	}
+	retry := resilience.NewRetry(resilience.RetryOptions{
+		Name:       "httpclient:" + request.URL.Host,
+		MaxRetries: maxRetries,
+		Backoff:    200 * time.Millisecond,
+		Jitter:     true,
+		Retryable:  retryableError,
+	})
+
+	var attempts []attemptSnapshot
+	err := retry.Execute(request.Context(), func(ctx context.Context) error {
+		// O corpo é consumido a cada tentativa e precisa ser recriado nas seguintes.
+		if resilience.Attempt(ctx) > 1 && request.GetBody != nil {
+			var err error
+			if request.Body, err = request.GetBody(); err != nil {
+				return err
+			}
+		}
+
+		start := time.Now()
+		var err error
+		body, status, err = doRequest(h, client, request, resilience.Attempt(ctx))
+		attempts = append(attempts, newAttemptSnapshot(resilience.Attempt(ctx), status, time.Since(start), err))
+		if err == nil && retryableStatus[status] && (final == nil || !final(body, status)) {
+			return &retryableStatusError{status: status}
+		}
+		return err
+	})
+
+	// Esgotadas as novas tentativas, o erro descreve cada tentativa. Em status
+	// repetível, a última resposta só vira erro com FailOnExhaustedStatus e
+	// quando não há tratamento registrado para o status (veja OnStatus).
+	var statusErr *retryableStatusError
+	isStatus := errors.As(err, &statusErr)
+	if err != nil && maxRetries > 0 && len(attempts) > 1 && request.Context().Err() == nil &&
+		(!isStatus || h.failOnExhausted && !h.handles(status)) {
+		return body, status, exhaustedError(request, status, body, attempts, err)
+	}
+
+	if isStatus {
+		return body, status, nil
+	}
+	if err != nil {
+		return nil, status, err
+	}
+	return body, status, nil
+}
+
+// retryableError repete falhas transitórias, mas não o cancelamento, a
+// divergência de certificado nem a resposta grande demais, que não se resolvem
+// com uma nova tentativa.
+func retryableError(err error) bool {
+	return !errors.Is(err, context.Canceled) &&
+		!errors.Is(err, ErrCertificatePinMismatch) &&
+		!errors.Is(err, ErrDecompressedTooLarge)
+}
+
+// canRetry indica se a requisição pode ser repetida sem duplicar efeitos colaterais.
+func canRetry(h *HttpClient, request *http.Request) bool {
+	return idempotentMethods[request.Method] ||
+		h.retryNonIdempotent ||
+		request.Header.Get(idempotencyKeyHeader) != "" ||
+		request.Header.Get("X-Idempotency-Key") != ""
+}
+
+// retryableStatusError sinaliza à política de retry uma resposta com status repetível.
+type retryableStatusError struct {
+	status int
+}
+
+func (e *retryableStatusError) Error() string {
+	return "retryable status " + strconv.Itoa(e.status)
+}
+
+// doRequest executa uma tentativa da requisição em um span do Datadog, lê a
+// resposta e entrega o resultado aos hooks.
+func doRequest(h *HttpClient, client *http.Client, request *http.Request, attempt int) ([]byte, int, error) {
+	span, ctx := dd.StartSpan(request.Context(), "http.request", requestSpanOptions(request.Method, request.URL.Host, request.URL.Redacted(), h.labels)...)
+	request = request.WithContext(ctx)
+	_ = dd.InjectHeaders(ctx, request.Header)
+
+	start := time.Now()
+	body, status, err := readResponse(h, client, request)
 
-	client := &http.Client{Timeout: time.Duration(h.timeout) * time.Second}
+	dd.SetSpanTag(span, ext.HTTPCode, status)
+	dd.SetSpanError(span, err)
+	dd.FinishSpan(span)
 
+	emit(ctx, h, RequestInfo{
+		Method:   request.Method,
+		URL:      request.URL.Redacted(),
+		Host:     request.URL.Host,
+		Attempt:  attempt,
+		Status:   status,
+		Duration: time.Since(start),
+		Err:      err,
+		Labels:   h.labels,
+	})
+	return body, status, err
+}
+
+// readResponse envia a requisição e lê o corpo da resposta.
+func readResponse(h *HttpClient, client *http.Client, request *http.Request) ([]byte, int, error) {
 	resp, err := client.Do(request)
 	if err != nil {
-		return nil, 500, err
+		status, err := classifyError(request, err)
+		return nil, status, err
 	}
 	defer resp.Body.Close()
 
-	bodyBytes, err := io.ReadAll(resp.Body)
+	reader, checkSize, err := responseBody(h, resp)
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+
+	bodyBytes, err := io.ReadAll(reader)
+	if err == nil {
+		err = checkSize()
+	}
 	if err != nil {
 		return nil, resp.StatusCode, err
 	}
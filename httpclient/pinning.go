@@ -0,0 +1,112 @@
+package httpclient
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrCertificatePinMismatch indica que o servidor apresentou uma cadeia de
+// certificados sem nenhuma das chaves públicas fixadas para o host.
+var ErrCertificatePinMismatch = errors.New("httpclient: certificate pin mismatch")
+
+// PinCertificates fixa as chaves públicas esperadas para o host, como hashes
+// SHA-256 do SubjectPublicKeyInfo em base64 (com ou sem o prefixo "sha256/",
+// o formato do HPKP; veja SPKIHash). A conexão falha, sem novas tentativas, se
+// nenhum certificado da cadeia tiver uma das chaves, e requisições sem TLS para
+// o host são recusadas. Inclua também a chave de reserva do parceiro para não
+// interromper a integração na rotação do certificado.
+func (h *HttpClient) PinCertificates(host string, spkiHashes ...string) *HttpClient {
+	if h.pins == nil {
+		h.pins = map[string]map[string]bool{}
+	}
+	host = strings.ToLower(host)
+	if h.pins[host] == nil {
+		h.pins[host] = map[string]bool{}
+	}
+	for _, hash := range spkiHashes {
+		h.pins[host][strings.TrimPrefix(hash, "sha256/")] = true
+	}
+	h.transport = nil
+	return h
+}
+
+// SPKIHash calcula o hash usado em PinCertificates para o certificado.
+func SPKIHash(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// httpTransport devolve o transport do cliente: o padrão, ou um transport
+// próprio que verifica as chaves fixadas, criado uma vez por cliente.
+func (h *HttpClient) httpTransport() http.RoundTripper {
+	if len(h.pins) == 0 {
+		return http.DefaultTransport
+	}
+	if h.transport != nil {
+		return h.transport
+	}
+
+	pinned := pinnedTransport{base: http.DefaultTransport, hosts: map[string]*http.Transport{}}
+	for host, pins := range h.pins {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion:       tls.VersionTLS12,
+			VerifyConnection: verifyPins(host, pins),
+		}
+		pinned.hosts[host] = transport
+	}
+	h.transport = pinned
+	return pinned
+}
+
+// pinnedTransport encaminha as requisições de hosts com chaves fixadas para um
+// transport exclusivo do host. A verificação fica no VerifyConnection do TLS,
+// que roda em todo handshake (inclusive no túnel via proxy), e não depende do
+// ServerName, que fica vazio quando o host é um IP.
+type pinnedTransport struct {
+	base  http.RoundTripper
+	hosts map[string]*http.Transport
+}
+
+func (t pinnedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if transport, ok := t.hosts[strings.ToLower(request.URL.Hostname())]; ok {
+		return transport.RoundTrip(request)
+	}
+	return t.base.RoundTrip(request)
+}
+
+// verifyPins confere, após a validação normal da cadeia, as chaves fixadas para o host.
+func verifyPins(host string, pins map[string]bool) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if !matchesPin(cs, pins) {
+			return fmt.Errorf("%w for %s", ErrCertificatePinMismatch, host)
+		}
+		return nil
+	}
+}
+
+// matchesPin indica se algum certificado das cadeias verificadas tem uma das chaves fixadas.
+func matchesPin(cs tls.ConnectionState, pins map[string]bool) bool {
+	for _, chain := range cs.VerifiedChains {
+		for _, cert := range chain {
+			if pins[SPKIHash(cert)] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkPinnedScheme recusa requisições sem TLS para hosts com chaves fixadas.
+func (h *HttpClient) checkPinnedScheme(request *http.Request) error {
+	if _, ok := h.pins[strings.ToLower(request.URL.Hostname())]; ok && request.URL.Scheme != "https" {
+		return fmt.Errorf("%w: %s requires https", ErrCertificatePinMismatch, request.URL.Hostname())
+	}
+	return nil
+}
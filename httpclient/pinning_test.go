@@ -0,0 +1,62 @@
+package httpclient
+
+import (
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPinCertificates(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	host := strings.Split(strings.TrimPrefix(server.URL, "https://"), ":")[0]
+
+	tests := []struct {
+		name     string
+		url      string
+		pins     []string
+		wantErr  bool
+		wantHits int32
+	}{
+		{name: "matching pin", url: server.URL, pins: []string{SPKIHash(server.Certificate())}, wantHits: 1},
+		{name: "matching pin with prefix", url: server.URL, pins: []string{"sha256/" + SPKIHash(server.Certificate())}, wantHits: 1},
+		{name: "backup pin only", url: server.URL, pins: []string{"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="}, wantErr: true},
+		{name: "plain http", url: strings.Replace(server.URL, "https://", "http://", 1), pins: []string{SPKIHash(server.Certificate())}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits.Store(0)
+
+			client := NewHttpClient(OptionsHttpclient{RetryCount: 2, Timeout: 5}).
+				SetUrl(tt.url).
+				PinCertificates(host, tt.pins...)
+			// O certificado do httptest é autoassinado: a cadeia precisa ser
+			// válida para que o pin seja conferido.
+			client.httpTransport().(pinnedTransport).hosts[host].TLSClientConfig.RootCAs = roots
+
+			_, status, err := client.SendGet()
+			if tt.wantErr {
+				if !errors.Is(err, ErrCertificatePinMismatch) {
+					t.Fatalf("SendGet error = %v, want ErrCertificatePinMismatch", err)
+				}
+			} else if err != nil || status != http.StatusOK {
+				t.Fatalf("SendGet = %d, %v; want 200", status, err)
+			}
+			if got := hits.Load(); got != tt.wantHits {
+				t.Fatalf("server received %d requests, want %d", got, tt.wantHits)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package httpclient
+
+// ResponseHandler trata a resposta de um status ou família de status. O erro
+// devolvido passa a ser o erro da chamada (ex.: errx NOT_FOUND em um 404).
+type ResponseHandler func(body []byte, status int) error
+
+// OnStatus registra o tratamento de um status específico, que tem precedência
+// sobre os tratamentos por família. Os tratamentos recebem a última resposta
+// também quando as novas tentativas se esgotam em um status repetível.
+//
+//	body, _, err := httpclient.NewHttpClient(opts).
+//		SetUrl(url).
+//		OnStatus(404, func([]byte, int) error {
+//			return errx.New("account not found").WithCode(errx.NOT_FOUND)
+//		}).
+//		OnServerError(func(body []byte, status int) error {
+//			return errx.New("ledger unavailable").WithCode(errx.UNAVAILABLE)
+//		}).
+//		SendGet()
+func (h *HttpClient) OnStatus(status int, handler ResponseHandler) *HttpClient {
+	if h.statusHandlers == nil {
+		h.statusHandlers = map[int]ResponseHandler{}
+	}
+	h.statusHandlers[status] = handler
+	return h
+}
+
+// OnSuccess registra o tratamento das respostas 2xx.
+func (h *HttpClient) OnSuccess(handler ResponseHandler) *HttpClient {
+	return h.onFamily(2, handler)
+}
+
+// OnRedirect registra o tratamento das respostas 3xx.
+func (h *HttpClient) OnRedirect(handler ResponseHandler) *HttpClient {
+	return h.onFamily(3, handler)
+}
+
+// OnClientError registra o tratamento das respostas 4xx.
+func (h *HttpClient) OnClientError(handler ResponseHandler) *HttpClient {
+	return h.onFamily(4, handler)
+}
+
+// OnServerError registra o tratamento das respostas 5xx.
+func (h *HttpClient) OnServerError(handler ResponseHandler) *HttpClient {
+	return h.onFamily(5, handler)
+}
+
+// onFamily registra o tratamento da família de status (2 para 2xx etc.).
+func (h *HttpClient) onFamily(family int, handler ResponseHandler) *HttpClient {
+	if h.familyHandlers == nil {
+		h.familyHandlers = map[int]ResponseHandler{}
+	}
+	h.familyHandlers[family] = handler
+	return h
+}
+
+// handles indica se há tratamento registrado para o status ou a sua família.
+func (h *HttpClient) handles(status int) bool {
+	_, ok := h.statusHandlers[status]
+	if !ok {
+		_, ok = h.familyHandlers[status/100]
+	}
+	return ok
+}
+
+// route entrega a resposta ao tratamento do status ou da família. Sem resposta
+// (falha de transporte) ou sem tratamento registrado, o resultado não muda.
+func (h *HttpClient) route(body []byte, status int, err error) ([]byte, int, error) {
+	if err != nil {
+		return body, status, err
+	}
+
+	handler, ok := h.statusHandlers[status]
+	if !ok {
+		handler, ok = h.familyHandlers[status/100]
+	}
+	if !ok {
+		return body, status, nil
+	}
+	return body, status, handler(body, status)
+}
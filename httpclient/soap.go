@@ -0,0 +1,172 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// SOAPVersion é a versão do protocolo SOAP usada por SendSOAP.
+type SOAPVersion int
+
+// Versões suportadas.
+const (
+	SOAP11 SOAPVersion = iota
+	SOAP12
+)
+
+// Namespaces dos envelopes de cada versão.
+const (
+	soap11Namespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	soap12Namespace = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+// SetSOAPVersion define a versão do envelope enviado por SendSOAP (padrão: SOAP11).
+func (h *HttpClient) SetSOAPVersion(version SOAPVersion) *HttpClient {
+	h.soapVersion = version
+	return h
+}
+
+// SendSOAP envia envelope dentro do Body de um envelope SOAP, com o cabeçalho
+// SOAPAction (1.1) ou o parâmetro action do Content-Type (1.2). O envelope é
+// serializado com encoding/xml, exceto []byte e string, enviados como estão.
+// Devolve o conteúdo do Body da resposta, pronto para xml.Unmarshal; um Fault
+// é devolvido como erro da errx com o código, a mensagem e o detalhe da falha,
+// e nunca é repetido, mesmo com novas tentativas habilitadas.
+func (h *HttpClient) SendSOAP(action string, envelope any) ([]byte, int, error) {
+	payload, err := soapPayload(envelope)
+	if err != nil {
+		return nil, 500, err
+	}
+
+	namespace := soap11Namespace
+	if h.soapVersion == SOAP12 {
+		namespace = soap12Namespace
+	}
+
+	var body bytes.Buffer
+	body.WriteString(xml.Header)
+	body.WriteString(`<soap:Envelope xmlns:soap="` + namespace + `"><soap:Body>`)
+	body.Write(payload)
+	body.WriteString(`</soap:Body></soap:Envelope>`)
+
+	req, err := h.newRequest("POST", body.Bytes())
+	if err != nil {
+		return nil, 500, err
+	}
+	if h.soapVersion == SOAP12 {
+		req.Header.Set("Content-Type", `application/soap+xml; charset=utf-8; action="`+action+`"`)
+	} else {
+		req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+		req.Header.Set("SOAPAction", `"`+action+`"`)
+	}
+
+	response, statusCode, err := sendClientWith(h, req, isSOAPFault)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	var parsed soapResponse
+	if err := xml.Unmarshal(response, &parsed); err != nil {
+		return response, statusCode, errx.New("invalid soap response").
+			WithCode(errx.StatusToCode(statusCode)).
+			WithError(err)
+	}
+	if parsed.Body.Fault != nil {
+		return nil, statusCode, parsed.Body.Fault.toError(action, statusCode)
+	}
+	return h.route(parsed.Body.Content, statusCode, nil)
+}
+
+// isSOAPFault indica se a resposta é um Fault. No SOAP 1.1 todo Fault chega
+// como 500; repeti-lo reenviaria a operação, duplicando os seus efeitos.
+func isSOAPFault(body []byte, _ int) bool {
+	var parsed soapResponse
+	return xml.Unmarshal(body, &parsed) == nil && parsed.Body.Fault != nil
+}
+
+// soapPayload serializa o conteúdo do Body.
+func soapPayload(envelope any) ([]byte, error) {
+	switch v := envelope.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return xml.Marshal(v)
+	}
+}
+
+// soapResponse é o envelope de resposta, nas versões 1.1 e 1.2.
+type soapResponse struct {
+	Body struct {
+		Content []byte     `xml:",innerxml"`
+		Fault   *soapFault `xml:"Fault"`
+	} `xml:"Body"`
+}
+
+// soapFault reúne os campos do Fault das versões 1.1 e 1.2.
+type soapFault struct {
+	// SOAP 1.1
+	FaultCode   string   `xml:"faultcode"`
+	FaultString string   `xml:"faultstring"`
+	FaultDetail innerXML `xml:"detail"`
+	// SOAP 1.2
+	Code struct {
+		Value   string `xml:"Value"`
+		Subcode struct {
+			Value string `xml:"Value"`
+		} `xml:"Subcode"`
+	} `xml:"Code"`
+	Reason struct {
+		Text []string `xml:"Text"`
+	} `xml:"Reason"`
+	Detail innerXML `xml:"Detail"`
+}
+
+// innerXML captura o conteúdo bruto de um elemento.
+type innerXML struct {
+	Content string `xml:",innerxml"`
+}
+
+// toError converte o Fault em erro da errx. Falhas atribuídas ao cliente
+// (Client/Sender) viram BAD_REQUEST; as demais seguem o status da resposta.
+func (f *soapFault) toError(action string, statusCode int) error {
+	code, message, detail := f.FaultCode, f.FaultString, f.FaultDetail.Content
+	if code == "" {
+		code = f.Code.Value
+		if f.Code.Subcode.Value != "" {
+			code += "/" + f.Code.Subcode.Value
+		}
+		message = strings.Join(f.Reason.Text, "; ")
+		detail = f.Detail.Content
+	}
+	if message == "" {
+		message = "soap fault"
+	}
+
+	errCode := errx.StatusToCode(statusCode)
+	local := code
+	if i := strings.LastIndex(local, ":"); i >= 0 {
+		local = local[i+1:]
+	}
+	if strings.HasPrefix(local, "Client") || strings.HasPrefix(local, "Sender") {
+		errCode = errx.BAD_REQUEST
+	} else if statusCode < 400 {
+		errCode = errx.INTERNAL
+	}
+
+	details := map[string]interface{}{
+		"soap_action": action,
+		"fault_code":  code,
+		"status":      statusCode,
+	}
+	if detail = strings.TrimSpace(detail); detail != "" {
+		details["fault_detail"] = detail
+	}
+	return errx.New(message).WithCode(errCode).WithDetails(details)
+}
@@ -0,0 +1,117 @@
+// Package idgen gera identificadores ordenáveis pelo tempo de criação (UUIDv7
+// e ULID) e IDs tipados com prefixo, como "pay_01HZX3K6Q2T8V7M9N4B5C6D7E8",
+// que indicam a entidade a que pertencem e mantêm a ordenação nos índices.
+//
+//	id := idgen.New("pay") // pay_01HZX3K6Q2T8V7M9N4B5C6D7E8
+//
+//	if err := idgen.Validate(c.Param("id"), "pay"); err != nil {
+//		server.Fail(c, err)
+//		return
+//	}
+package idgen
+
+import (
+	"crypto/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/oklog/ulid/v2"
+)
+
+// separator separa o prefixo do ULID nos IDs tipados.
+const separator = "_"
+
+var (
+	// entropyMu protege a fonte monotônica, que não é segura para uso concorrente.
+	entropyMu sync.Mutex
+	// entropy garante ULIDs crescentes mesmo quando gerados no mesmo milissegundo.
+	entropy = ulid.Monotonic(rand.Reader, 0)
+)
+
+// UUIDv7 devolve um UUID versão 7 (RFC 9562), ordenável pelo tempo de criação.
+func UUIDv7() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// ULID devolve um ULID de 26 caracteres, crescente dentro do processo.
+func ULID() string {
+	entropyMu.Lock()
+	defer entropyMu.Unlock()
+	return ulid.MustNew(ulid.Timestamp(time.Now()), entropy).String()
+}
+
+// New devolve um ID tipado no formato "<prefixo>_<ULID>". O prefixo deve ser
+// curto, em minúsculas e sem "_" (ex.: "pay", "cus").
+func New(prefix string) string {
+	return prefix + separator + ULID()
+}
+
+// Parse separa o prefixo e o ULID de um ID tipado, validando o formato.
+func Parse(id string) (string, ulid.ULID, error) {
+	prefix, raw, ok := strings.Cut(id, separator)
+	if !ok || prefix == "" {
+		return "", ulid.ULID{}, invalid(id, "missing prefix")
+	}
+
+	value, err := ulid.ParseStrict(raw)
+	if err != nil {
+		return "", ulid.ULID{}, invalid(id, err.Error())
+	}
+	return prefix, value, nil
+}
+
+// Validate verifica se id é um ID tipado válido com o prefixo esperado,
+// devolvendo uma AppError BAD_REQUEST caso contrário.
+func Validate(id string, prefix string) error {
+	got, _, err := Parse(id)
+	if err != nil {
+		return err
+	}
+	if got != prefix {
+		return invalid(id, "expected prefix "+prefix)
+	}
+	return nil
+}
+
+// Time devolve o instante de criação codificado em um ID tipado, ULID ou UUIDv7.
+func Time(id string) (time.Time, error) {
+	if strings.Contains(id, separator) {
+		_, value, err := Parse(id)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return ulid.Time(value.Time()), nil
+	}
+
+	if value, err := ulid.ParseStrict(id); err == nil {
+		return ulid.Time(value.Time()), nil
+	}
+
+	value, err := uuid.Parse(id)
+	if err != nil || value.Version() != 7 {
+		return time.Time{}, invalid(id, "not a ULID or UUIDv7")
+	}
+	sec, nsec := value.Time().UnixTime()
+	return time.Unix(sec, nsec), nil
+}
+
+// IsUUID informa se s é um UUID válido em qualquer versão.
+func IsUUID(s string) bool {
+	return uuid.Validate(s) == nil
+}
+
+// IsULID informa se s é um ULID válido.
+func IsULID(s string) bool {
+	_, err := ulid.ParseStrict(s)
+	return err == nil
+}
+
+// invalid monta o erro de ID inválido.
+func invalid(id string, reason string) error {
+	return errx.New("invalid id").
+		WithCode(errx.BAD_REQUEST).
+		WithDetails(map[string]interface{}{"id": id, "reason": reason})
+}
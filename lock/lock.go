@@ -0,0 +1,115 @@
+// Package lock oferece locks distribuídos atrás de uma única interface, com
+// implementações no Redis (estilo Redlock, sobre uma ou mais instâncias
+// independentes), em locks consultivos do banco (Postgres e MySQL) e em
+// memória, para testes e processos únicos.
+//
+//	locker := lock.NewRedis(lock.RedisOptions{}, dd.NewRedisClient(&redis.Options{Addr: addr}))
+//
+//	err := lock.Do(ctx, locker, "invoices:close", 30*time.Second, func(ctx context.Context) error {
+//		return closeInvoices(ctx)
+//	})
+//	if errors.Is(err, lock.ErrNotAcquired) {
+//		return nil // outra instância já está processando
+//	}
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// ErrNotAcquired indica que o lock já pertence a outro dono.
+var ErrNotAcquired = errors.New("lock: not acquired")
+
+// ErrLost indica que o lock expirou ou passou a outro dono antes de ser
+// renovado ou liberado.
+var ErrLost = errors.New("lock: lost")
+
+// Locker adquire locks distribuídos.
+type Locker interface {
+	// Acquire tenta adquirir o lock da chave sem aguardar, devolvendo
+	// ErrNotAcquired se ele pertencer a outro dono. O lock expira após ttl se
+	// não for renovado (nos locks do banco, ao fechar a conexão).
+	Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+}
+
+// Lock é um lock adquirido.
+type Lock interface {
+	// Key devolve a chave do lock.
+	Key() string
+	// Refresh renova o lock por mais ttl, devolvendo ErrLost se ele já expirou.
+	Refresh(ctx context.Context, ttl time.Duration) error
+	// Release libera o lock, devolvendo ErrLost se ele já expirou.
+	Release(ctx context.Context) error
+}
+
+// minTTL é o menor ttl aceito por Do, para que a renovação a cada ttl/3 tenha
+// ao menos a resolução de milissegundos do Redis.
+const minTTL = 3 * time.Millisecond
+
+// Do executa fn enquanto detém o lock da chave, renovando-o a cada ttl/3.
+// Se a renovação falhar, o contexto de fn é cancelado, pois outro dono pode
+// ter assumido o lock. Devolve ErrNotAcquired quando o lock pertence a outro
+// dono e um erro, sem adquirir o lock, quando ttl é menor que 3ms.
+func Do(ctx context.Context, locker Locker, key string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	if ttl < minTTL {
+		return fmt.Errorf("lock: ttl must be at least %s, got %s", minTTL, ttl)
+	}
+
+	l, err := locker.Acquire(ctx, key, ttl)
+	if err != nil {
+		if errors.Is(err, ErrNotAcquired) {
+			dd.Metrics().Incr("lock.contended", "lock:"+key)
+		}
+		return err
+	}
+	dd.Metrics().Incr("lock.acquired", "lock:"+key)
+
+	ctx, cancel := context.WithCancel(ctx)
+	refreshed := make(chan struct{})
+	go func() {
+		defer close(refreshed)
+		keepAlive(ctx, cancel, l, ttl)
+	}()
+
+	err = fn(ctx)
+	cancel()
+	<-refreshed
+
+	if releaseErr := l.Release(context.WithoutCancel(ctx)); releaseErr != nil {
+		logx.Ctx(ctx).Warn("Failed to release lock", "lock", key, "error", releaseErr)
+		if err == nil && errors.Is(releaseErr, ErrLost) {
+			err = releaseErr
+		}
+	}
+	return err
+}
+
+// keepAlive renova o lock até ctx ser cancelado, cancelando-o se o lock for perdido.
+func keepAlive(ctx context.Context, cancel context.CancelFunc, l Lock, ttl time.Duration) {
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := l.Refresh(ctx, ttl); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			dd.Metrics().Incr("lock.lost", "lock:"+l.Key())
+			logx.Ctx(ctx).Error("Lock lost while running", "lock", l.Key(), "error", err)
+			cancel()
+			return
+		}
+	}
+}
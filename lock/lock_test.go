@@ -0,0 +1,80 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDo(t *testing.T) {
+	locker := NewMemory()
+
+	held, err := locker.Acquire(context.Background(), "busy", time.Minute)
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	defer held.Release(context.Background())
+
+	tests := []struct {
+		name    string
+		key     string
+		ttl     time.Duration
+		wantRun bool
+		wantErr error
+	}{
+		{name: "free key", key: "free", ttl: time.Second, wantRun: true},
+		{name: "held by another owner", key: "busy", ttl: time.Second, wantErr: ErrNotAcquired},
+		{name: "ttl below the minimum", key: "free", ttl: time.Millisecond},
+		{name: "zero ttl", key: "free"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ran := false
+			err := Do(context.Background(), locker, tt.key, tt.ttl, func(context.Context) error {
+				ran = true
+				return nil
+			})
+
+			if ran != tt.wantRun {
+				t.Fatalf("fn ran = %v, want %v", ran, tt.wantRun)
+			}
+			switch {
+			case tt.wantRun && err != nil:
+				t.Fatalf("Do: %v", err)
+			case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+				t.Fatalf("Do error = %v, want %v", err, tt.wantErr)
+			case !tt.wantRun && err == nil:
+				t.Fatal("Do succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestDoReleasesAndRefreshes(t *testing.T) {
+	locker := NewMemory()
+	ttl := 30 * time.Millisecond
+
+	err := Do(context.Background(), locker, "job", ttl, func(ctx context.Context) error {
+		// Dura várias vezes o ttl: só termina sem cancelamento se o lock for renovado.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(4 * ttl):
+		}
+		if _, err := locker.Acquire(ctx, "job", ttl); !errors.Is(err, ErrNotAcquired) {
+			t.Errorf("Acquire while held error = %v, want ErrNotAcquired", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	l, err := locker.Acquire(context.Background(), "job", ttl)
+	if err != nil {
+		t.Fatalf("Acquire after Do: %v", err)
+	}
+	_ = l.Release(context.Background())
+}
@@ -0,0 +1,79 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory é um Locker em memória, válido apenas dentro do processo (testes e
+// serviços com uma única instância).
+type Memory struct {
+	mu    sync.Mutex
+	locks map[string]memoryEntry
+	seq   uint64
+}
+
+// memoryEntry é o dono e a expiração de um lock em memória.
+type memoryEntry struct {
+	owner   uint64
+	expires time.Time
+}
+
+// NewMemory cria um Locker em memória.
+func NewMemory() *Memory {
+	return &Memory{locks: map[string]memoryEntry{}}
+}
+
+// Acquire implementa Locker.
+func (m *Memory) Acquire(_ context.Context, key string, ttl time.Duration) (Lock, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if entry, ok := m.locks[key]; ok && time.Now().Before(entry.expires) {
+		return nil, ErrNotAcquired
+	}
+	m.seq++
+	m.locks[key] = memoryEntry{owner: m.seq, expires: time.Now().Add(ttl)}
+	return &memoryLock{m: m, key: key, owner: m.seq}, nil
+}
+
+// memoryLock é um lock adquirido em Memory.
+type memoryLock struct {
+	m     *Memory
+	key   string
+	owner uint64
+}
+
+// Key implementa Lock.
+func (l *memoryLock) Key() string { return l.key }
+
+// Refresh implementa Lock.
+func (l *memoryLock) Refresh(_ context.Context, ttl time.Duration) error {
+	l.m.mu.Lock()
+	defer l.m.mu.Unlock()
+
+	if !l.held() {
+		return ErrLost
+	}
+	l.m.locks[l.key] = memoryEntry{owner: l.owner, expires: time.Now().Add(ttl)}
+	return nil
+}
+
+// Release implementa Lock.
+func (l *memoryLock) Release(context.Context) error {
+	l.m.mu.Lock()
+	defer l.m.mu.Unlock()
+
+	if !l.held() {
+		return ErrLost
+	}
+	delete(l.m.locks, l.key)
+	return nil
+}
+
+// held indica se o lock ainda pertence a este dono; exige l.m.mu.
+func (l *memoryLock) held() bool {
+	entry, ok := l.m.locks[l.key]
+	return ok && entry.owner == l.owner && time.Now().Before(entry.expires)
+}
@@ -0,0 +1,173 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/redis/go-redis/v9"
+)
+
+// releaseScript remove a chave apenas se ela ainda pertencer ao dono.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// refreshScript renova a expiração apenas se a chave ainda pertencer ao dono.
+var refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// RedisOptions configura o Locker no Redis.
+type RedisOptions struct {
+	// Namespace prefixa as chaves no formato "<namespace>:lock:<chave>"
+	// (padrão: o serviço informado em dd.Load).
+	Namespace string
+	// DriftFactor é a fração do ttl descontada da validade do lock para
+	// compensar a diferença entre os relógios (padrão: 0.01).
+	DriftFactor float64
+}
+
+// Redis é um Locker sobre uma ou mais instâncias independentes do Redis. Com
+// várias instâncias, segue o algoritmo Redlock: o lock é obtido quando a
+// maioria delas o concede dentro do ttl.
+type Redis struct {
+	clients []redis.UniversalClient
+	opts    RedisOptions
+}
+
+// NewRedis cria o Locker sobre os clientes informados. Para que os comandos
+// apareçam nos traces, crie os clientes com dd.NewRedisClient.
+func NewRedis(opts RedisOptions, clients ...redis.UniversalClient) *Redis {
+	if opts.Namespace == "" {
+		opts.Namespace = dd.ServiceName()
+	}
+	if opts.DriftFactor <= 0 {
+		opts.DriftFactor = 0.01
+	}
+	return &Redis{clients: clients, opts: opts}
+}
+
+// Acquire implementa Locker.
+func (r *Redis) Acquire(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	if len(r.clients) == 0 {
+		return nil, errors.New("lock: redis: no clients")
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	l := &redisLock{r: r, key: key, name: r.opts.Namespace + ":lock:" + key, token: token}
+
+	start := time.Now()
+	acquired, refused := 0, 0
+	var firstErr error
+	for _, client := range r.clients {
+		ok, err := client.SetNX(ctx, l.name, token, ttl).Result()
+		switch {
+		case err != nil:
+			if firstErr == nil {
+				firstErr = err
+			}
+		case ok:
+			acquired++
+		default:
+			refused++
+		}
+	}
+
+	drift := time.Duration(float64(ttl)*r.opts.DriftFactor) + 2*time.Millisecond
+	validity := ttl - time.Since(start) - drift
+	if acquired >= r.quorum() && validity > 0 {
+		return l, nil
+	}
+
+	// Desfaz as aquisições parciais para não bloquear as outras instâncias até o ttl.
+	l.eval(context.WithoutCancel(ctx), releaseScript)
+	// Só é contenção quando a maioria respondeu que a chave tem outro dono;
+	// caso contrário, a falha de comunicação é a causa.
+	if firstErr != nil && refused < r.quorum() {
+		return nil, fmt.Errorf("lock: redis: %w", firstErr)
+	}
+	return nil, ErrNotAcquired
+}
+
+// quorum devolve o número mínimo de instâncias que precisam conceder o lock.
+func (r *Redis) quorum() int {
+	return len(r.clients)/2 + 1
+}
+
+// redisLock é um lock adquirido no Redis.
+type redisLock struct {
+	r     *Redis
+	key   string
+	name  string
+	token string
+}
+
+// Key implementa Lock.
+func (l *redisLock) Key() string { return l.key }
+
+// Refresh implementa Lock.
+func (l *redisLock) Refresh(ctx context.Context, ttl time.Duration) error {
+	ok, err := l.eval(ctx, refreshScript, ttl.Milliseconds())
+	if ok >= l.r.quorum() {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("lock: redis: %w", err)
+	}
+	return ErrLost
+}
+
+// Release implementa Lock.
+func (l *redisLock) Release(ctx context.Context) error {
+	ok, err := l.eval(ctx, releaseScript)
+	if ok > 0 {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("lock: redis: %w", err)
+	}
+	return ErrLost
+}
+
+// eval executa o script em todas as instâncias, devolvendo em quantas a chave
+// pertencia ao dono e o primeiro erro de comunicação.
+func (l *redisLock) eval(ctx context.Context, script *redis.Script, args ...interface{}) (int, error) {
+	args = append([]interface{}{l.token}, args...)
+
+	ok := 0
+	var firstErr error
+	for _, client := range l.r.clients {
+		n, err := script.Run(ctx, client, []string{l.name}, args...).Int64()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if n > 0 {
+			ok++
+		}
+	}
+	return ok, firstErr
+}
+
+// randomToken gera o identificador do dono do lock.
+func randomToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("lock: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,124 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/db"
+)
+
+// DB é um Locker sobre os locks consultivos do banco: pg_try_advisory_lock no
+// Postgres e GET_LOCK no MySQL. Cada lock ocupa uma conexão do pool enquanto
+// estiver adquirido e é liberado pelo banco se a conexão cair, portanto o ttl
+// não se aplica.
+type DB struct {
+	database *db.DB
+}
+
+// NewDB cria o Locker sobre o banco informado.
+func NewDB(database *db.DB) *DB {
+	return &DB{database: database}
+}
+
+// Acquire implementa Locker.
+func (d *DB) Acquire(ctx context.Context, key string, _ time.Duration) (Lock, error) {
+	conn, err := d.database.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lock: db: %w", err)
+	}
+
+	l := &dbLock{conn: conn, driver: d.database.Driver(), key: key}
+	var acquired bool
+	if l.driver == db.MySQL {
+		var result sql.NullInt64
+		err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", l.mysqlName()).Scan(&result)
+		acquired = result.Int64 == 1
+	} else {
+		err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.advisoryKey()).Scan(&acquired)
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("lock: db: %w", err)
+	}
+	if !acquired {
+		_ = conn.Close()
+		return nil, ErrNotAcquired
+	}
+	return l, nil
+}
+
+// dbLock é um lock consultivo preso a uma conexão dedicada.
+type dbLock struct {
+	mu     sync.Mutex
+	conn   *sql.Conn
+	driver string
+	key    string
+}
+
+// Key implementa Lock.
+func (l *dbLock) Key() string { return l.key }
+
+// Refresh implementa Lock; verifica se a conexão que detém o lock continua ativa.
+func (l *dbLock) Refresh(ctx context.Context, _ time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return ErrLost
+	}
+	if err := l.conn.PingContext(ctx); err != nil {
+		return fmt.Errorf("%w: %v", ErrLost, err)
+	}
+	return nil
+}
+
+// Release implementa Lock.
+func (l *dbLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return ErrLost
+	}
+	defer func() {
+		_ = l.conn.Close()
+		l.conn = nil
+	}()
+
+	var released bool
+	var err error
+	if l.driver == db.MySQL {
+		var result sql.NullInt64
+		err = l.conn.QueryRowContext(ctx, "SELECT RELEASE_LOCK(?)", l.mysqlName()).Scan(&result)
+		released = result.Int64 == 1
+	} else {
+		err = l.conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", l.advisoryKey()).Scan(&released)
+	}
+	if err != nil {
+		return fmt.Errorf("lock: db: %w", err)
+	}
+	if !released {
+		return ErrLost
+	}
+	return nil
+}
+
+// advisoryKey converte a chave no inteiro usado pelos locks consultivos do Postgres.
+func (l *dbLock) advisoryKey() int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(l.key))
+	return int64(h.Sum64())
+}
+
+// mysqlName devolve o nome do lock no MySQL, limitado a 64 caracteres.
+func (l *dbLock) mysqlName() string {
+	if len(l.key) <= 64 {
+		return l.key
+	}
+	return "lock:" + strconv.FormatUint(uint64(l.advisoryKey()), 16)
+}
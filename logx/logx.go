@@ -0,0 +1,198 @@
+// Package logx fornece um logger estruturado e com níveis, baseado no slog,
+// com os campos padrão do serviço (service, env, version) e correlação com a
+// requisição (correlation id) e com os traces do Datadog (dd.trace_id e dd.span_id).
+package logx
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+)
+
+// Formatos de saída suportados.
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// Options configura o logger padrão do serviço.
+type Options struct {
+	// Service, Env e Version são incluídos em todas as linhas
+	// (padrão: DD_SERVICE, DD_ENV e DD_VERSION).
+	Service string
+	Env     string
+	Version string
+	// Level é o nível mínimo ("debug", "info", "warn" ou "error"; padrão: LOG_LEVEL ou "info").
+	Level string
+	// Format é "json" ou "console" (padrão: LOG_FORMAT ou "json").
+	Format string
+	// Output é o destino das linhas (padrão: os.Stdout).
+	Output io.Writer
+}
+
+var (
+	// level é compartilhado pelos handlers e pode ser alterado em tempo de execução.
+	level = new(slog.LevelVar)
+	// logger é o logger padrão, criado com os valores das variáveis de ambiente.
+	logger atomic.Pointer[slog.Logger]
+	// serviceAttrs são os campos service, env e version definidos em Init.
+	serviceAttrs atomic.Pointer[[]slog.Attr]
+)
+
+func init() {
+	Init(Options{})
+}
+
+// Init configura o logger padrão. Campos vazios são lidos das variáveis de ambiente.
+func Init(opts Options) {
+	if opts.Service == "" {
+		opts.Service = os.Getenv("DD_SERVICE")
+	}
+	if opts.Env == "" {
+		opts.Env = os.Getenv("DD_ENV")
+	}
+	if opts.Version == "" {
+		opts.Version = os.Getenv("DD_VERSION")
+	}
+	if opts.Level == "" {
+		opts.Level = os.Getenv("LOG_LEVEL")
+	}
+	if opts.Format == "" {
+		opts.Format = os.Getenv("LOG_FORMAT")
+	}
+	if opts.Output == nil {
+		opts.Output = os.Stdout
+	}
+
+	level.Set(ParseLevel(opts.Level))
+
+	handler := NewHandler(opts.Format, opts.Output)
+
+	var attrs []slog.Attr
+	if opts.Service != "" {
+		attrs = append(attrs, slog.String("service", opts.Service))
+	}
+	if opts.Env != "" {
+		attrs = append(attrs, slog.String("env", opts.Env))
+	}
+	if opts.Version != "" {
+		attrs = append(attrs, slog.String("version", opts.Version))
+	}
+
+	serviceAttrs.Store(&attrs)
+	logger.Store(slog.New(handler.WithAttrs(attrs)))
+	dd.SetLogger(logger.Load())
+}
+
+// New cria um logger no formato informado com os campos do serviço (service,
+// env e version) definidos em Init, para saídas próprias como o log de acesso.
+func New(format string, w io.Writer) *slog.Logger {
+	handler := NewHandler(format, w)
+	if attrs := serviceAttrs.Load(); attrs != nil {
+		handler = handler.WithAttrs(*attrs)
+	}
+	return slog.New(handler)
+}
+
+// NewHandler cria um handler no formato informado ("json" ou "console"), que
+// respeita o nível mínimo do logger padrão.
+func NewHandler(format string, w io.Writer) slog.Handler {
+	handlerOpts := &slog.HandlerOptions{Level: level}
+	if strings.EqualFold(format, FormatConsole) {
+		return slog.NewTextHandler(w, handlerOpts)
+	}
+	return slog.NewJSONHandler(w, handlerOpts)
+}
+
+// ParseLevel converte o nome do nível, considerando "info" quando inválido.
+func ParseLevel(name string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(name)); err != nil {
+		return slog.LevelInfo
+	}
+	return l
+}
+
+// SetLevel altera o nível mínimo do logger padrão em tempo de execução.
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// Level devolve o nível mínimo atual.
+func Level() slog.Level {
+	return level.Level()
+}
+
+// L devolve o logger padrão, sem campos de contexto.
+func L() *slog.Logger {
+	return logger.Load()
+}
+
+// Ctx devolve o logger padrão com os campos de correlação do contexto:
+// correlation_id (quando registrado com WithCorrelationID), os campos
+// registrados com WithFields e os campos do Datadog.
+//
+//	logx.Ctx(ctx).Info("order created", "order_id", id)
+func Ctx(ctx context.Context) *slog.Logger {
+	return With(L(), ctx)
+}
+
+// With devolve l com os mesmos campos de correlação adicionados por Ctx.
+func With(l *slog.Logger, ctx context.Context) *slog.Logger {
+	if ctx == nil {
+		return l
+	}
+
+	var args []any
+	if id := CorrelationID(ctx); id != "" {
+		args = append(args, slog.String("correlation_id", id))
+	}
+	args = append(args, fields(ctx)...)
+	for key, value := range dd.LogFields(ctx) {
+		if key == "dd.trace_id" || key == "dd.span_id" {
+			args = append(args, slog.String(key, value))
+		}
+	}
+	if len(args) == 0 {
+		return l
+	}
+	return l.With(args...)
+}
+
+// correlationKey é a chave do contexto onde o correlation id é armazenado.
+type correlationKey struct{}
+
+// WithCorrelationID devolve um contexto que carrega o correlation id da requisição.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationID devolve o correlation id armazenado no contexto, se houver.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationKey{}).(string)
+	return id
+}
+
+// fieldsKey é a chave do contexto onde os campos de WithFields são armazenados.
+type fieldsKey struct{}
+
+// WithFields devolve um contexto cujos logs obtidos por Ctx incluem os campos
+// informados, em pares chave/valor como em slog (ex.: "tenant", id).
+func WithFields(ctx context.Context, args ...any) context.Context {
+	parent := fields(ctx)
+	merged := make([]any, 0, len(parent)+len(args))
+	merged = append(merged, parent...)
+	merged = append(merged, args...)
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// fields devolve os campos registrados no contexto por WithFields.
+func fields(ctx context.Context) []any {
+	args, _ := ctx.Value(fieldsKey{}).([]any)
+	return args
+}
@@ -0,0 +1,145 @@
+// Package notify envia notificações por e-mail (SMTP), webhooks do Slack e do
+// Microsoft Teams e APIs internas de notificação, com mensagens a partir de
+// templates, novas tentativas pelo pacote resilience e métricas de entrega.
+//
+//	mailer := notify.New("smtp", notify.SMTP(notify.SMTPOptions{
+//		Host: "smtp.internal",
+//		Port: 587,
+//		From: "no-reply@example.com",
+//	}), notify.Options{MaxRetries: 2})
+//
+//	msg, err := templates.Render("welcome", customer)
+//	if err != nil {
+//		return err
+//	}
+//	msg.To = []string{customer.Email}
+//	err = mailer.Send(ctx, msg)
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"github.com/nathanribeiroo/module-dep-projects/resilience"
+)
+
+// Message é uma notificação. Cada provedor usa os campos que suporta: o SMTP
+// usa todos; os webhooks, Subject e Text.
+type Message struct {
+	// To são os destinatários (e-mails ou identificadores da API interna).
+	To []string `json:"to,omitempty"`
+	// Subject é o assunto ou título.
+	Subject string `json:"subject,omitempty"`
+	// Text é o corpo em texto simples (ou markdown, nos webhooks).
+	Text string `json:"text,omitempty"`
+	// HTML é o corpo alternativo em HTML.
+	HTML string `json:"html,omitempty"`
+	// Metadata são dados adicionais repassados à API interna.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Notifier entrega mensagens por um canal.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NotifierFunc adapta uma função à interface Notifier.
+type NotifierFunc func(ctx context.Context, msg Message) error
+
+// Send implementa Notifier.
+func (f NotifierFunc) Send(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
+
+// Options configura o Client.
+type Options struct {
+	// MaxRetries é o número de novas tentativas após uma falha de envio (padrão: 0).
+	MaxRetries int
+	// Backoff é a espera antes da primeira nova tentativa (padrão: 1s).
+	Backoff time.Duration
+}
+
+// Client envia mensagens por um Notifier com novas tentativas e métricas
+// (notify.sent, notify.failed e notify.duration, com a tag channel:<nome>).
+type Client struct {
+	name     string
+	notifier Notifier
+	policy   resilience.Policy
+	tags     []string
+}
+
+// New cria o Client; name identifica o canal nas métricas e nos logs.
+func New(name string, notifier Notifier, opts Options) *Client {
+	if opts.Backoff <= 0 {
+		opts.Backoff = time.Second
+	}
+
+	c := &Client{name: name, notifier: notifier, tags: []string{"channel:" + name}}
+	if opts.MaxRetries > 0 {
+		c.policy = resilience.NewRetry(resilience.RetryOptions{
+			Name:       "notify:" + name,
+			MaxRetries: opts.MaxRetries,
+			Backoff:    opts.Backoff,
+			Jitter:     true,
+			Retryable:  retryable,
+		})
+	}
+	return c
+}
+
+// Send entrega a mensagem, repetindo as falhas conforme Options.
+func (c *Client) Send(ctx context.Context, msg Message) error {
+	defer dd.Metrics().Timer("notify.duration", c.tags...)()
+
+	err := resilience.Do(ctx, func(ctx context.Context) error {
+		return c.notifier.Send(ctx, msg)
+	}, c.policies()...)
+	if err != nil {
+		dd.Metrics().Incr("notify.failed", c.tags...)
+		logx.Ctx(ctx).Error("Failed to send notification", "channel", c.name, "subject", msg.Subject, "error", err)
+		return err
+	}
+
+	dd.Metrics().Incr("notify.sent", c.tags...)
+	return nil
+}
+
+// policies devolve as políticas configuradas.
+func (c *Client) policies() []resilience.Policy {
+	if c.policy == nil {
+		return nil
+	}
+	return []resilience.Policy{c.policy}
+}
+
+// retryable repete falhas de rede e respostas 5xx ou 429, mas não mensagens
+// recusadas pelo destino (4xx) nem o cancelamento do contexto.
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	status, ok := errx.GetDetails(err)["status"].(int)
+	if !ok {
+		return true
+	}
+	return status >= 500 || status == http.StatusTooManyRequests
+}
+
+// Multi envia a mensagem por todos os notifiers, devolvendo o primeiro erro
+// após tentar todos (ex.: e-mail e Slack para o mesmo alerta).
+func Multi(notifiers ...Notifier) Notifier {
+	return NotifierFunc(func(ctx context.Context, msg Message) error {
+		var first error
+		for _, n := range notifiers {
+			if err := n.Send(ctx, msg); err != nil && first == nil {
+				first = err
+			}
+		}
+		return first
+	})
+}
@@ -0,0 +1,193 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPOptions configura o envio de e-mails.
+type SMTPOptions struct {
+	// Host e Port identificam o servidor SMTP (porta padrão: 587).
+	Host string
+	Port int
+	// Username e Password autenticam com PLAIN, quando informados.
+	Username string
+	Password string
+	// From é o remetente (ex.: "Pagamentos <no-reply@example.com>").
+	From string
+	// ImplicitTLS usa TLS desde a conexão (porta 465); caso contrário, STARTTLS
+	// é usado quando o servidor oferece.
+	ImplicitTLS bool
+	// Timeout limita a conexão com o servidor (padrão: 10s).
+	Timeout time.Duration
+}
+
+// SMTP devolve um Notifier que envia a mensagem por e-mail, em texto e/ou HTML.
+func SMTP(opts SMTPOptions) Notifier {
+	if opts.Port == 0 {
+		opts.Port = 587
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+
+	return NotifierFunc(func(ctx context.Context, msg Message) error {
+		if len(msg.To) == 0 {
+			return errors.New("notify: smtp: no recipients")
+		}
+		body, err := buildMIME(opts.From, msg)
+		if err != nil {
+			return err
+		}
+		return sendSMTP(ctx, opts, msg.To, body)
+	})
+}
+
+// sendSMTP entrega o e-mail já montado.
+func sendSMTP(ctx context.Context, opts SMTPOptions, to []string, body []byte) error {
+	addr := net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port))
+	dialer := &net.Dialer{Timeout: opts.Timeout}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if opts.ImplicitTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: opts.Host}}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("notify: smtp: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, opts.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("notify: smtp: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && !opts.ImplicitTLS {
+		if err := client.StartTLS(&tls.Config{ServerName: opts.Host}); err != nil {
+			return fmt.Errorf("notify: smtp: %w", err)
+		}
+	}
+	if opts.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", opts.Username, opts.Password, opts.Host)); err != nil {
+			return fmt.Errorf("notify: smtp: %w", err)
+		}
+	}
+
+	from, err := mailAddress(opts.From)
+	if err != nil {
+		return err
+	}
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("notify: smtp: %w", err)
+	}
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("notify: smtp: %s: %w", rcpt, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("notify: smtp: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("notify: smtp: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("notify: smtp: %w", err)
+	}
+	return client.Quit()
+}
+
+// mailAddress extrai o endereço do remetente ("Nome <a@b>" → "a@b").
+func mailAddress(from string) (string, error) {
+	if i := strings.LastIndex(from, "<"); i >= 0 {
+		j := strings.LastIndex(from, ">")
+		if j < i {
+			return "", fmt.Errorf("notify: smtp: invalid sender %q", from)
+		}
+		return from[i+1 : j], nil
+	}
+	return strings.TrimSpace(from), nil
+}
+
+// buildMIME monta o e-mail em multipart/alternative quando há texto e HTML.
+func buildMIME(from string, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if msg.Text != "" && msg.HTML != "" {
+		boundary, err := randomBoundary()
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", boundary)
+		for _, part := range []struct{ contentType, content string }{
+			{"text/plain", msg.Text},
+			{"text/html", msg.HTML},
+		} {
+			fmt.Fprintf(&buf, "--%s\r\n", boundary)
+			if err := writePart(&buf, part.contentType, part.content); err != nil {
+				return nil, err
+			}
+		}
+		fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+		return buf.Bytes(), nil
+	}
+
+	contentType, content := "text/plain", msg.Text
+	if msg.HTML != "" {
+		contentType, content = "text/html", msg.HTML
+	}
+	return buf.Bytes(), writePart(&buf, contentType, content)
+}
+
+// writePart grava o cabeçalho e o conteúdo de uma parte em quoted-printable.
+func writePart(buf *bytes.Buffer, contentType string, content string) error {
+	fmt.Fprintf(buf, "Content-Type: %s; charset=utf-8\r\n", contentType)
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(buf)
+	if _, err := w.Write([]byte(content)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	buf.WriteString("\r\n")
+	return nil
+}
+
+// randomBoundary gera o separador das partes do e-mail.
+func randomBoundary() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "notify-" + hex.EncodeToString(b), nil
+}
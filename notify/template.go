@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Templates renderiza mensagens a partir de arquivos de template. Cada
+// mensagem <nome> é composta por até três arquivos no diretório informado:
+//
+//	<nome>.subject.tmpl  assunto (text/template)
+//	<nome>.txt.tmpl      corpo em texto (text/template)
+//	<nome>.html.tmpl     corpo em HTML (html/template, com escape automático)
+type Templates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// LoadTemplates carrega os templates de dir em fsys (ex.: um embed.FS).
+func LoadTemplates(fsys fs.FS, dir string) (*Templates, error) {
+	t := &Templates{
+		text: texttemplate.New(""),
+		html: htmltemplate.New(""),
+	}
+
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("notify: templates: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".tmpl") {
+			continue
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("notify: templates: %w", err)
+		}
+
+		name = strings.TrimSuffix(name, ".tmpl")
+		if strings.HasSuffix(name, ".html") {
+			_, err = t.html.New(name).Parse(string(content))
+		} else {
+			_, err = t.text.New(name).Parse(string(content))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("notify: templates: %s: %w", name, err)
+		}
+	}
+	return t, nil
+}
+
+// Render monta a mensagem name com data; os destinatários ficam a cargo de
+// quem chama. Devolve erro se nenhum arquivo da mensagem existir.
+func (t *Templates) Render(name string, data interface{}) (Message, error) {
+	var (
+		msg   Message
+		found bool
+		err   error
+	)
+
+	render := func(dst *string, suffix string) {
+		if err != nil {
+			return
+		}
+		var buf bytes.Buffer
+		if suffix == ".html" {
+			tmpl := t.html.Lookup(name + suffix)
+			if tmpl == nil {
+				return
+			}
+			err = tmpl.Execute(&buf, data)
+		} else {
+			tmpl := t.text.Lookup(name + suffix)
+			if tmpl == nil {
+				return
+			}
+			err = tmpl.Execute(&buf, data)
+		}
+		found = true
+		*dst = buf.String()
+	}
+
+	render(&msg.Subject, ".subject")
+	render(&msg.Text, ".txt")
+	render(&msg.HTML, ".html")
+
+	if err != nil {
+		return Message{}, fmt.Errorf("notify: template %s: %w", name, err)
+	}
+	if !found {
+		return Message{}, fmt.Errorf("notify: template %s not found", name)
+	}
+	msg.Subject = strings.TrimSpace(msg.Subject)
+	return msg, nil
+}
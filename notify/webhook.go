@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// WebhookOptions configura os provedores baseados em HTTP.
+type WebhookOptions struct {
+	// URL é o endereço do webhook ou da API de notificação.
+	URL string
+	// Header são cabeçalhos adicionais (ex.: Authorization da API interna).
+	Header map[string]string
+	// Client é o cliente HTTP usado (padrão: timeout de 10s).
+	Client *http.Client
+}
+
+// Slack devolve um Notifier que publica a mensagem em um incoming webhook do
+// Slack, com o assunto em negrito seguido do texto em markdown.
+func Slack(opts WebhookOptions) Notifier {
+	return webhook(opts, func(msg Message) interface{} {
+		text := msg.Text
+		if msg.Subject != "" {
+			text = "*" + msg.Subject + "*\n" + text
+		}
+		return map[string]string{"text": text}
+	})
+}
+
+// Teams devolve um Notifier que publica a mensagem em um incoming webhook do
+// Microsoft Teams (MessageCard).
+func Teams(opts WebhookOptions) Notifier {
+	return webhook(opts, func(msg Message) interface{} {
+		return map[string]string{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"summary":  msg.Subject,
+			"title":    msg.Subject,
+			"text":     msg.Text,
+		}
+	})
+}
+
+// API devolve um Notifier que envia a Message em JSON para uma API interna de
+// notificação (POST em opts.URL).
+func API(opts WebhookOptions) Notifier {
+	return webhook(opts, func(msg Message) interface{} {
+		return msg
+	})
+}
+
+// webhook publica o payload montado por encode via POST.
+func webhook(opts WebhookOptions, encode func(Message) interface{}) Notifier {
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return NotifierFunc(func(ctx context.Context, msg Message) error {
+		body, err := json.Marshal(encode(msg))
+		if err != nil {
+			return fmt.Errorf("notify: webhook: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, opts.URL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("notify: webhook: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range opts.Header {
+			req.Header.Set(k, v)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("notify: webhook: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 300 {
+			snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+			return errx.New("notification webhook rejected the message").
+				WithCode(errx.StatusToCode(resp.StatusCode)).
+				WithDetails(map[string]interface{}{
+					"status": resp.StatusCode,
+					"body":   string(snippet),
+				})
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	})
+}
@@ -0,0 +1,193 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"github.com/nathanribeiroo/module-dep-projects/resilience"
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaProducer publica mensagens em um tópico do Kafka. Mensagens com a mesma
+// Key vão para a mesma partição, preservando a ordem entre elas.
+type KafkaProducer struct {
+	writer *kafka.Writer
+	topic  string
+}
+
+// NewKafkaProducer cria um produtor para o tópico, aguardando a confirmação de
+// todas as réplicas em sincronia.
+func NewKafkaProducer(brokers []string, topic string) *KafkaProducer {
+	return &KafkaProducer{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+		topic: topic,
+	}
+}
+
+// Publish implementa Producer, propagando o trace atual nos headers.
+func (p *KafkaProducer) Publish(ctx context.Context, msgs ...Message) error {
+	carrier := map[string]string{}
+	span, ctx := dd.StartProducerSpan(ctx, "kafka", p.topic, carrier)
+	defer dd.FinishSpan(span)
+
+	records := make([]kafka.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		headers := make([]kafka.Header, 0, len(carrier)+len(msg.Attributes))
+		for key, value := range carrier {
+			headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+		}
+		for key, value := range msg.Attributes {
+			headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+		}
+		records = append(records, kafka.Message{Key: []byte(msg.Key), Value: msg.Body, Headers: headers})
+	}
+
+	err := p.writer.WriteMessages(ctx, records...)
+	dd.SetSpanError(span, err)
+	return err
+}
+
+// Close implementa Producer, enviando as mensagens pendentes.
+func (p *KafkaProducer) Close() error {
+	return p.writer.Close()
+}
+
+// KafkaConsumerOptions configura o consumo de um tópico do Kafka.
+type KafkaConsumerOptions struct {
+	// Brokers são os endereços dos brokers.
+	Brokers []string
+	// Topic é o tópico consumido.
+	Topic string
+	// GroupID é o consumer group; as partições são distribuídas entre as instâncias do grupo.
+	GroupID string
+	// MaxRetries é o número de novas tentativas de uma mensagem com falha antes
+	// de desistir dela (padrão: 3; use -1 para não repetir).
+	MaxRetries int
+	// Backoff é a espera antes da primeira nova tentativa, dobrada a cada tentativa (padrão: 500ms).
+	Backoff time.Duration
+	// OnDeadLetter é chamado com a mensagem descartada após esgotar as tentativas
+	// (ex.: para publicá-la em um tópico de DLQ); o offset só é confirmado depois
+	// dele. Sem OnDeadLetter, o consumidor para sem confirmar a mensagem, que é
+	// entregue novamente quando o consumo for retomado.
+	OnDeadLetter func(msg *Message, err error)
+}
+
+// KafkaConsumer consome um tópico em um consumer group com semântica
+// at-least-once: o offset só é confirmado após o handler concluir. As mensagens
+// de cada consumidor são processadas em ordem, uma por vez; a vazão escala com
+// o número de partições e de instâncias no grupo.
+type KafkaConsumer struct {
+	reader  *kafka.Reader
+	opts    KafkaConsumerOptions
+	handler Handler
+	runner  runner
+}
+
+// NewKafkaConsumer cria um consumidor do tópico com o handler informado.
+func NewKafkaConsumer(opts KafkaConsumerOptions, handler Handler) *KafkaConsumer {
+	if opts.Backoff <= 0 {
+		opts.Backoff = 500 * time.Millisecond
+	}
+
+	return &KafkaConsumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: opts.Brokers,
+			Topic:   opts.Topic,
+			GroupID: opts.GroupID,
+		}),
+		opts:    opts,
+		handler: handler,
+	}
+}
+
+// Start implementa Consumer.
+func (c *KafkaConsumer) Start(ctx context.Context) error {
+	return c.runner.start(ctx, c.Run)
+}
+
+// Stop implementa Consumer.
+func (c *KafkaConsumer) Stop(ctx context.Context) error {
+	return c.runner.stop(ctx)
+}
+
+// Run implementa Consumer. A mensagem em processamento é concluída e confirmada
+// antes do retorno, mesmo após o cancelamento de ctx. Uma mensagem que falha em
+// todas as tentativas sem OnDeadLetter encerra o consumo com erro, sem confirmação.
+func (c *KafkaConsumer) Run(ctx context.Context) error {
+	defer c.reader.Close()
+
+	bg := context.WithoutCancel(ctx)
+	for {
+		record, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		}
+
+		if err := c.handle(bg, record); err != nil {
+			return fmt.Errorf("queue: kafka message %s/%d@%d not processed: %w", record.Topic, record.Partition, record.Offset, err)
+		}
+
+		if err := c.reader.CommitMessages(bg, record); err != nil {
+			logx.Ctx(ctx).Error("Failed to commit Kafka offset", "topic", record.Topic, "partition", record.Partition, "offset", record.Offset, "error", err)
+		}
+	}
+}
+
+// handle processa a mensagem com novas tentativas, chamando OnDeadLetter ao
+// desistir. Devolve o erro apenas quando não há OnDeadLetter, caso em que a
+// mensagem não pode ser confirmada.
+func (c *KafkaConsumer) handle(ctx context.Context, record kafka.Message) error {
+	msg := &Message{
+		Key:        string(record.Key),
+		Body:       record.Value,
+		Attributes: make(map[string]string, len(record.Headers)),
+	}
+	for _, h := range record.Headers {
+		msg.Attributes[h.Key] = string(h.Value)
+	}
+
+	span, ctx := dd.StartConsumerSpan(ctx, "kafka", record.Topic, msg.Attributes)
+	defer dd.FinishSpan(span)
+	dd.SetSpanTag(span, "messaging.kafka.partition", record.Partition)
+	dd.SetSpanTag(span, "messaging.kafka.offset", record.Offset)
+
+	retry := resilience.NewRetry(resilience.RetryOptions{
+		Name:       "kafka:" + record.Topic,
+		MaxRetries: c.opts.MaxRetries,
+		Backoff:    c.opts.Backoff,
+		Retryable:  func(error) bool { return true },
+		OnRetry: func(ctx context.Context, attempt int, err error) {
+			logx.Ctx(ctx).Error("Failed to process Kafka message", "topic", record.Topic, "partition", record.Partition, "offset", record.Offset, "attempt", attempt, "error", err)
+		},
+	})
+
+	// O handler não recebe o contexto da política: o cancelamento do consumidor
+	// não deve interromper uma mensagem já em processamento.
+	err := retry.Execute(context.WithoutCancel(ctx), func(attemptCtx context.Context) error {
+		msg.ReceiveCount = resilience.Attempt(attemptCtx)
+		return runHandler(ctx, c.handler, msg)
+	})
+	if err == nil {
+		return nil
+	}
+	logx.Ctx(ctx).Error("Failed to process Kafka message", "topic", record.Topic, "partition", record.Partition, "offset", record.Offset, "attempt", msg.ReceiveCount, "error", err)
+
+	dd.SetSpanError(span, err)
+	if c.opts.OnDeadLetter == nil {
+		return err
+	}
+	c.opts.OnDeadLetter(msg, err)
+	return nil
+}
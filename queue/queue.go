@@ -0,0 +1,127 @@
+// Package queue abstrai a publicação e o consumo de mensagens (SQS e Kafka) com
+// propagação de trace, concorrência limitada e encerramento gracioso integrado
+// aos hooks do servidor.
+//
+//	consumer := queue.NewSQSConsumer(client, queue.SQSConsumerOptions{QueueURL: url},
+//		queue.Typed(func(ctx context.Context, order Order, msg *queue.Message) error {
+//			return process(ctx, order)
+//		}))
+//	srv.OnStart(consumer.Start).OnStop(consumer.Stop)
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// Message é uma mensagem publicada ou recebida.
+type Message struct {
+	// ID é o identificador atribuído pelo broker (apenas no consumo).
+	ID string
+	// Key agrupa mensagens relacionadas: MessageGroupId em filas FIFO do SQS ou
+	// a chave de particionamento no Kafka.
+	Key string
+	// Body é o conteúdo da mensagem.
+	Body []byte
+	// Attributes são metadados da mensagem (atributos no SQS, headers no Kafka).
+	Attributes map[string]string
+	// ReceiveCount é o número de entregas da mensagem (no Kafka, a tentativa atual).
+	ReceiveCount int
+}
+
+// Handler processa uma mensagem. Devolver nil confirma a mensagem; um erro faz
+// com que ela seja entregue novamente, conforme a política do broker.
+type Handler func(ctx context.Context, msg *Message) error
+
+// Producer publica mensagens.
+type Producer interface {
+	Publish(ctx context.Context, msgs ...Message) error
+	Close() error
+}
+
+// Consumer consome mensagens até o contexto ser cancelado.
+type Consumer interface {
+	// Run consome até ctx ser cancelado, aguardando as mensagens em processamento.
+	Run(ctx context.Context) error
+	// Start inicia o consumo em segundo plano; compatível com server.OnStart.
+	Start(ctx context.Context) error
+	// Stop interrompe o consumo e aguarda as mensagens em processamento até o
+	// prazo de ctx; compatível com server.OnStop.
+	Stop(ctx context.Context) error
+}
+
+// Typed adapta um handler que recebe o corpo da mensagem decodificado de JSON.
+func Typed[T any](fn func(ctx context.Context, value T, msg *Message) error) Handler {
+	return func(ctx context.Context, msg *Message) error {
+		var value T
+		if err := json.Unmarshal(msg.Body, &value); err != nil {
+			return err
+		}
+		return fn(ctx, value, msg)
+	}
+}
+
+// TypedProducer publica valores do tipo T serializados em JSON.
+type TypedProducer[T any] struct {
+	producer Producer
+}
+
+// NewTypedProducer cria um produtor tipado sobre p.
+func NewTypedProducer[T any](p Producer) *TypedProducer[T] {
+	return &TypedProducer[T]{producer: p}
+}
+
+// Publish serializa value e o publica com a chave e os atributos informados.
+func (p *TypedProducer[T]) Publish(ctx context.Context, key string, value T, attributes map[string]string) error {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return p.producer.Publish(ctx, Message{Key: key, Body: body, Attributes: attributes})
+}
+
+// runner implementa Start e Stop sobre a função Run de um consumidor.
+type runner struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan error
+}
+
+// start executa run em segundo plano, desvinculado do cancelamento de ctx.
+func (r *runner) start(ctx context.Context, run func(ctx context.Context) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return errors.New("queue: consumer already started")
+	}
+
+	ctx, r.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	r.done = make(chan error, 1)
+	go func() {
+		r.done <- run(ctx)
+	}()
+	return nil
+}
+
+// stop cancela o consumo e aguarda o término até o prazo de ctx.
+func (r *runner) stop(ctx context.Context) error {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel = nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,357 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// sqsTraceAttribute é o atributo que carrega o contexto de trace, agrupado em um
+// único atributo JSON por causa do limite de 10 atributos por mensagem.
+const sqsTraceAttribute = "_datadog"
+
+// sqsBatchSize é o limite de mensagens por chamada em lote do SQS.
+const sqsBatchSize = 10
+
+// SQSProducer publica mensagens em uma fila SQS.
+type SQSProducer struct {
+	client   *sqs.Client
+	queueURL string
+}
+
+// NewSQSProducer cria um produtor para a fila informada.
+func NewSQSProducer(client *sqs.Client, queueURL string) *SQSProducer {
+	return &SQSProducer{client: client, queueURL: queueURL}
+}
+
+// Publish envia as mensagens em lotes de até 10, propagando o trace atual. Em
+// filas FIFO, Key é usado como MessageGroupId.
+func (p *SQSProducer) Publish(ctx context.Context, msgs ...Message) error {
+	span, ctx := dd.StartProducerSpan(ctx, "sqs", queueName(p.queueURL), nil)
+	defer dd.FinishSpan(span)
+
+	carrier := map[string]string{}
+	_ = dd.InjectMap(ctx, carrier)
+	trace, _ := json.Marshal(carrier)
+
+	for start := 0; start < len(msgs); start += sqsBatchSize {
+		batch := msgs[start:min(start+sqsBatchSize, len(msgs))]
+
+		entries := make([]types.SendMessageBatchRequestEntry, 0, len(batch))
+		for i, msg := range batch {
+			attributes := map[string]types.MessageAttributeValue{
+				sqsTraceAttribute: {DataType: aws.String("String"), StringValue: aws.String(string(trace))},
+			}
+			for key, value := range msg.Attributes {
+				attributes[key] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(value)}
+			}
+
+			entry := types.SendMessageBatchRequestEntry{
+				Id:                aws.String(strconv.Itoa(i)),
+				MessageBody:       aws.String(string(msg.Body)),
+				MessageAttributes: attributes,
+			}
+			if msg.Key != "" {
+				entry.MessageGroupId = aws.String(msg.Key)
+			}
+			entries = append(entries, entry)
+		}
+
+		out, err := p.client.SendMessageBatch(ctx, &sqs.SendMessageBatchInput{
+			QueueUrl: aws.String(p.queueURL),
+			Entries:  entries,
+		})
+		if err != nil {
+			dd.SetSpanError(span, err)
+			return err
+		}
+		if len(out.Failed) > 0 {
+			err := fmt.Errorf("queue: %d messages not sent: %s", len(out.Failed), aws.ToString(out.Failed[0].Message))
+			dd.SetSpanError(span, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// Close implementa Producer.
+func (p *SQSProducer) Close() error {
+	return nil
+}
+
+// SQSConsumerOptions configura o consumo de uma fila SQS.
+type SQSConsumerOptions struct {
+	// QueueURL é a URL da fila.
+	QueueURL string
+	// MaxMessages é o número de mensagens por recebimento, até 10 (padrão: 10).
+	MaxMessages int32
+	// WaitTime é a espera do long polling, até 20s (padrão: 20s).
+	WaitTime time.Duration
+	// VisibilityTimeout é o tempo em que a mensagem fica oculta durante o
+	// processamento; é estendido periodicamente enquanto o handler executa (padrão: 30s).
+	VisibilityTimeout time.Duration
+	// MaxConcurrency limita as mensagens processadas em paralelo (padrão: 10).
+	MaxConcurrency int
+	// MaxReceiveCount é o maxReceiveCount da redrive policy da fila. Quando a
+	// mensagem atinge esse número de entregas e falha, ela segue para a DLQ e
+	// OnDeadLetter é chamado (padrão: desabilitado).
+	MaxReceiveCount int
+	// OnDeadLetter é chamado com a mensagem que será movida para a DLQ.
+	OnDeadLetter func(msg *Message, err error)
+}
+
+// SQSConsumer consome uma fila SQS com long polling, extensão da visibilidade
+// durante o processamento e remoção das mensagens confirmadas em lote.
+type SQSConsumer struct {
+	client  *sqs.Client
+	opts    SQSConsumerOptions
+	handler Handler
+	runner  runner
+}
+
+// NewSQSConsumer cria um consumidor da fila com o handler informado.
+func NewSQSConsumer(client *sqs.Client, opts SQSConsumerOptions, handler Handler) *SQSConsumer {
+	if opts.MaxMessages <= 0 || opts.MaxMessages > sqsBatchSize {
+		opts.MaxMessages = sqsBatchSize
+	}
+	if opts.WaitTime <= 0 || opts.WaitTime > 20*time.Second {
+		opts.WaitTime = 20 * time.Second
+	}
+	if opts.VisibilityTimeout <= 0 {
+		opts.VisibilityTimeout = 30 * time.Second
+	}
+	if opts.MaxConcurrency <= 0 {
+		opts.MaxConcurrency = 10
+	}
+	return &SQSConsumer{client: client, opts: opts, handler: handler}
+}
+
+// Start implementa Consumer.
+func (c *SQSConsumer) Start(ctx context.Context) error {
+	return c.runner.start(ctx, c.Run)
+}
+
+// Stop implementa Consumer.
+func (c *SQSConsumer) Stop(ctx context.Context) error {
+	return c.runner.stop(ctx)
+}
+
+// Run implementa Consumer.
+func (c *SQSConsumer) Run(ctx context.Context) error {
+	// As confirmações e extensões continuam após o cancelamento de ctx para não
+	// reentregar mensagens já processadas durante o encerramento.
+	bg := context.WithoutCancel(ctx)
+	deleter := newSQSDeleter(bg, c.client, c.opts.QueueURL)
+	defer deleter.close()
+
+	sem := make(chan struct{}, c.opts.MaxConcurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for ctx.Err() == nil {
+		out, err := c.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:              aws.String(c.opts.QueueURL),
+			MaxNumberOfMessages:   c.opts.MaxMessages,
+			WaitTimeSeconds:       int32(c.opts.WaitTime / time.Second),
+			VisibilityTimeout:     int32(c.opts.VisibilityTimeout / time.Second),
+			MessageAttributeNames: []string{"All"},
+			MessageSystemAttributeNames: []types.MessageSystemAttributeName{
+				types.MessageSystemAttributeNameApproximateReceiveCount,
+				types.MessageSystemAttributeNameMessageGroupId,
+			},
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			logx.Ctx(ctx).Error("Failed to receive SQS messages", "queue", c.opts.QueueURL, "error", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		for _, m := range out.Messages {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(m types.Message) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				if c.handle(bg, m) {
+					deleter.add(m.ReceiptHandle)
+				}
+			}(m)
+		}
+	}
+	return nil
+}
+
+// handle processa uma mensagem, estendendo a visibilidade enquanto o handler
+// executa, e informa se ela deve ser removida da fila.
+func (c *SQSConsumer) handle(ctx context.Context, m types.Message) bool {
+	msg := &Message{
+		ID:         aws.ToString(m.MessageId),
+		Body:       []byte(aws.ToString(m.Body)),
+		Attributes: map[string]string{},
+	}
+	carrier := map[string]string{}
+	for key, value := range m.MessageAttributes {
+		if key == sqsTraceAttribute {
+			_ = json.Unmarshal([]byte(aws.ToString(value.StringValue)), &carrier)
+			continue
+		}
+		msg.Attributes[key] = aws.ToString(value.StringValue)
+	}
+	msg.ReceiveCount, _ = strconv.Atoi(m.Attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)])
+	if group, ok := m.Attributes[string(types.MessageSystemAttributeNameMessageGroupId)]; ok {
+		msg.Key = group
+	}
+
+	span, ctx := dd.StartConsumerSpan(ctx, "sqs", queueName(c.opts.QueueURL), carrier)
+	defer dd.FinishSpan(span)
+
+	stop := c.extendVisibility(ctx, m.ReceiptHandle)
+	err := runHandler(ctx, c.handler, msg)
+	stop()
+
+	if err == nil {
+		return true
+	}
+
+	dd.SetSpanError(span, err)
+	logx.Ctx(ctx).Error("Failed to process SQS message", "queue", c.opts.QueueURL, "message_id", msg.ID, "receive_count", msg.ReceiveCount, "error", err)
+	if c.opts.MaxReceiveCount > 0 && msg.ReceiveCount >= c.opts.MaxReceiveCount && c.opts.OnDeadLetter != nil {
+		c.opts.OnDeadLetter(msg, err)
+	}
+	return false
+}
+
+// extendVisibility estende a visibilidade da mensagem periodicamente até a
+// função devolvida ser chamada.
+func (c *SQSConsumer) extendVisibility(ctx context.Context, receipt *string) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(c.opts.VisibilityTimeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				_, err := c.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+					QueueUrl:          aws.String(c.opts.QueueURL),
+					ReceiptHandle:     receipt,
+					VisibilityTimeout: int32(c.opts.VisibilityTimeout / time.Second),
+				})
+				if err != nil {
+					logx.Ctx(ctx).Warn("Failed to extend SQS message visibility", "error", err)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// runHandler executa o handler convertendo panics em erro.
+func runHandler(ctx context.Context, handler Handler, msg *Message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("queue: handler panic: %v", r)
+		}
+	}()
+	return handler(ctx, msg)
+}
+
+// sqsDeleter agrupa as remoções de mensagens confirmadas em lotes de até 10,
+// enviados quando o lote enche ou a cada segundo.
+type sqsDeleter struct {
+	client   *sqs.Client
+	queueURL string
+	ctx      context.Context
+
+	mu      sync.Mutex
+	pending []*string
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// newSQSDeleter inicia o envio periódico das remoções.
+func newSQSDeleter(ctx context.Context, client *sqs.Client, queueURL string) *sqsDeleter {
+	d := &sqsDeleter{client: client, queueURL: queueURL, ctx: ctx, stop: make(chan struct{}), done: make(chan struct{})}
+	go func() {
+		defer close(d.done)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-d.stop:
+				d.flush()
+				return
+			case <-ticker.C:
+				d.flush()
+			}
+		}
+	}()
+	return d
+}
+
+// add agenda a remoção da mensagem.
+func (d *sqsDeleter) add(receipt *string) {
+	d.mu.Lock()
+	d.pending = append(d.pending, receipt)
+	full := len(d.pending) >= sqsBatchSize
+	d.mu.Unlock()
+
+	if full {
+		d.flush()
+	}
+}
+
+// flush remove as mensagens pendentes.
+func (d *sqsDeleter) flush() {
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = nil
+	d.mu.Unlock()
+
+	for start := 0; start < len(pending); start += sqsBatchSize {
+		batch := pending[start:min(start+sqsBatchSize, len(pending))]
+		entries := make([]types.DeleteMessageBatchRequestEntry, 0, len(batch))
+		for i, receipt := range batch {
+			entries = append(entries, types.DeleteMessageBatchRequestEntry{Id: aws.String(strconv.Itoa(i)), ReceiptHandle: receipt})
+		}
+
+		out, err := d.client.DeleteMessageBatch(d.ctx, &sqs.DeleteMessageBatchInput{QueueUrl: aws.String(d.queueURL), Entries: entries})
+		if err == nil && len(out.Failed) > 0 {
+			err = errors.New(aws.ToString(out.Failed[0].Message))
+		}
+		if err != nil {
+			logx.L().Error("Failed to delete SQS messages", "queue", d.queueURL, "error", err)
+		}
+	}
+}
+
+// close envia as remoções pendentes e encerra o envio periódico.
+func (d *sqsDeleter) close() {
+	close(d.stop)
+	<-d.done
+}
+
+// queueName extrai o nome da fila da URL.
+func queueName(queueURL string) string {
+	return queueURL[strings.LastIndex(queueURL, "/")+1:]
+}
@@ -0,0 +1,270 @@
+// Package render renderiza páginas HTML (html/template) e saídas em texto
+// (text/template) a partir de um fs.FS, com layouts, partials compartilhados e
+// recarga dos arquivos a cada renderização em desenvolvimento. O Renderer
+// implementa o HTMLRender do Gin e pode ser usado com server.HTML.
+//
+//	//go:embed views
+//	var views embed.FS
+//
+//	renderer, err := render.New(views, render.Options{Dir: "views", Layout: "layouts/base"})
+//	if err != nil {
+//		return err
+//	}
+//
+//	server.N().
+//		Views(renderer).
+//		Routes(server.Route("GET", "/tools/jobs", func(c *gin.Context) {
+//			server.HTML(c, "jobs/index", gin.H{"Jobs": jobs})
+//		})).
+//		Run("8080")
+//
+// Estrutura esperada em Dir:
+//
+//	layouts/*.html   layouts; o conteúdo da view é inserido com {{template "content" .}}
+//	partials/*.html  blocos compartilhados, disponíveis em todas as views
+//	**/*.html        views HTML, que definem {{define "content"}}...{{end}} quando há layout
+//	**/*.txt         views em texto (text/template), sem layout
+package render
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+
+	ginrender "github.com/gin-gonic/gin/render"
+)
+
+const (
+	layoutsDir  = "layouts"
+	partialsDir = "partials"
+)
+
+// Options configura o Renderer.
+type Options struct {
+	// Dir é o diretório raiz das views dentro do fs.FS (padrão: raiz).
+	Dir string
+	// Layout é o layout padrão das views HTML, sem extensão (ex.: "layouts/base").
+	// Vazio renderiza as views sem layout.
+	Layout string
+	// Funcs são funções adicionais disponíveis nos templates.
+	Funcs map[string]interface{}
+	// Reload relê os arquivos a cada renderização (útil em desenvolvimento com os.DirFS).
+	Reload bool
+}
+
+// Renderer mantém os templates compilados de cada view.
+type Renderer struct {
+	fsys fs.FS
+	opts Options
+
+	mu    sync.RWMutex
+	html  map[string]*htmltemplate.Template
+	text  map[string]*texttemplate.Template
+	entry map[string]string
+}
+
+// New carrega e compila as views de fsys, devolvendo erro de sintaxe ou de
+// layout ausente já na inicialização.
+func New(fsys fs.FS, opts Options) (*Renderer, error) {
+	if opts.Dir == "" {
+		opts.Dir = "."
+	}
+	if opts.Dir != "." {
+		sub, err := fs.Sub(fsys, opts.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("render: %w", err)
+		}
+		fsys = sub
+	}
+
+	r := &Renderer{fsys: fsys, opts: opts}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Render executa a view name com data em w. Views .txt usam text/template;
+// as demais, html/template com o layout padrão.
+func (r *Renderer) Render(w io.Writer, name string, data interface{}) error {
+	if r.opts.Reload {
+		if err := r.load(); err != nil {
+			return err
+		}
+	}
+
+	r.mu.RLock()
+	html, isHTML := r.html[name]
+	text, isText := r.text[name]
+	entry := r.entry[name]
+	r.mu.RUnlock()
+
+	// Renderiza em buffer para não enviar páginas pela metade em caso de erro.
+	var buf bytes.Buffer
+	var err error
+	switch {
+	case isHTML:
+		err = html.ExecuteTemplate(&buf, entry, data)
+	case isText:
+		err = text.ExecuteTemplate(&buf, entry, data)
+	default:
+		return fmt.Errorf("render: view %s not found", name)
+	}
+	if err != nil {
+		return fmt.Errorf("render: view %s: %w", name, err)
+	}
+
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+// String executa a view name e devolve o resultado (ex.: corpo de e-mails).
+func (r *Renderer) String(name string, data interface{}) (string, error) {
+	var buf strings.Builder
+	if err := r.Render(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Instance implementa o HTMLRender do Gin.
+func (r *Renderer) Instance(name string, data any) ginrender.Render {
+	return view{renderer: r, name: name, data: data}
+}
+
+// view é a renderização de uma view em uma resposta do Gin.
+type view struct {
+	renderer *Renderer
+	name     string
+	data     interface{}
+}
+
+// Render implementa render.Render do Gin.
+func (v view) Render(w http.ResponseWriter) error {
+	v.WriteContentType(w)
+	return v.renderer.Render(w, v.name, v.data)
+}
+
+// WriteContentType implementa render.Render do Gin.
+func (v view) WriteContentType(w http.ResponseWriter) {
+	contentType := "text/html; charset=utf-8"
+	if v.renderer.isText(v.name) {
+		contentType = "text/plain; charset=utf-8"
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+}
+
+// isText indica se a view é renderizada com text/template.
+func (r *Renderer) isText(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.text[name]
+	return ok
+}
+
+// load compila todas as views, combinando cada uma com layouts e partials.
+func (r *Renderer) load() error {
+	var (
+		layouts  []string
+		partials []string
+		views    []string
+	)
+	err := fs.WalkDir(r.fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		switch {
+		case strings.HasPrefix(p, layoutsDir+"/") && path.Ext(p) == ".html":
+			layouts = append(layouts, p)
+		case strings.HasPrefix(p, partialsDir+"/") && path.Ext(p) == ".html":
+			partials = append(partials, p)
+		case path.Ext(p) == ".html" || path.Ext(p) == ".txt":
+			views = append(views, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("render: %w", err)
+	}
+
+	layout := ""
+	if r.opts.Layout != "" {
+		layout = r.opts.Layout + ".html"
+		if !contains(layouts, layout) {
+			return fmt.Errorf("render: layout %s not found", r.opts.Layout)
+		}
+	}
+
+	html := make(map[string]*htmltemplate.Template)
+	text := make(map[string]*texttemplate.Template)
+	entry := make(map[string]string)
+	for _, file := range views {
+		name := strings.TrimSuffix(file, path.Ext(file))
+
+		if path.Ext(file) == ".txt" {
+			t := texttemplate.New(file).Funcs(r.opts.Funcs)
+			if err := r.parse(file, func(content string) error {
+				_, err := t.Parse(content)
+				return err
+			}); err != nil {
+				return err
+			}
+			text[name], entry[name] = t, file
+			continue
+		}
+
+		t := htmltemplate.New(name).Funcs(r.opts.Funcs)
+		files := append(append([]string{}, layouts...), partials...)
+		for _, f := range append(files, file) {
+			if err := r.parse(f, func(content string) error {
+				_, err := t.New(f).Parse(content)
+				return err
+			}); err != nil {
+				return err
+			}
+		}
+
+		html[name], entry[name] = t, file
+		if layout != "" && t.Lookup("content") != nil {
+			entry[name] = layout
+		}
+	}
+
+	r.mu.Lock()
+	r.html, r.text, r.entry = html, text, entry
+	r.mu.Unlock()
+	return nil
+}
+
+// parse lê o arquivo e o entrega ao parser, identificando o arquivo nos erros.
+// Os templates são nomeados pelo caminho completo (ex.: "layouts/base.html"),
+// diferente de ParseFS, que usa apenas o nome base.
+func (r *Renderer) parse(file string, parser func(content string) error) error {
+	content, err := fs.ReadFile(r.fsys, file)
+	if err != nil {
+		return fmt.Errorf("render: %w", err)
+	}
+	if err := parser(string(content)); err != nil {
+		return fmt.Errorf("render: %s: %w", file, err)
+	}
+	return nil
+}
+
+// contains indica se s está em list.
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
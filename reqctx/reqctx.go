@@ -0,0 +1,83 @@
+// Package reqctx reúne acessores tipados para os valores da requisição
+// (usuário, correlation id, tenant, locale e claims), para que as camadas de
+// serviço e repositório leiam o context.Context sem depender do gin.Context
+// nem de chaves em string que podem colidir entre pacotes.
+//
+//	ctx = reqctx.WithUser(ctx, user)
+//	user, ok := reqctx.User[*User](ctx)
+package reqctx
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/auth"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"github.com/nathanribeiroo/module-dep-projects/server"
+	"github.com/nathanribeiroo/module-dep-projects/tenant"
+)
+
+// Key é uma chave tipada de contexto. Chaves distintas nunca colidem, mesmo
+// com o mesmo nome, pois a identidade é o ponteiro criado por NewKey.
+type Key[T any] struct {
+	name string
+}
+
+// NewKey cria uma chave tipada; o nome serve apenas para diagnóstico.
+//
+//	var orderKey = reqctx.NewKey[*Order]("order")
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{name: name}
+}
+
+// String devolve o nome da chave.
+func (k *Key[T]) String() string { return k.name }
+
+// With devolve um contexto que carrega o valor.
+func (k *Key[T]) With(ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, k, value)
+}
+
+// Get devolve o valor armazenado no contexto, se houver.
+func (k *Key[T]) Get(ctx context.Context) (T, bool) {
+	value, ok := ctx.Value(k).(T)
+	return value, ok
+}
+
+// Set armazena o valor no contexto da requisição do Gin, tornando-o visível
+// aos handlers seguintes e às camadas que recebem c.Request.Context().
+func (k *Key[T]) Set(c *gin.Context, value T) {
+	c.Request = c.Request.WithContext(k.With(c.Request.Context(), value))
+}
+
+// WithUser devolve um contexto que carrega o usuário autenticado; equivale a
+// server.WithUser.
+func WithUser[U any](ctx context.Context, user U) context.Context {
+	return server.WithUser(ctx, user)
+}
+
+// User devolve o usuário do contexto, se houver e for do tipo U; equivale a
+// server.User.
+func User[U any](ctx context.Context) (U, bool) {
+	return server.User[U](ctx)
+}
+
+// CorrelationID devolve o correlation id da requisição.
+func CorrelationID(ctx context.Context) string {
+	return logx.CorrelationID(ctx)
+}
+
+// Tenant devolve o tenant da requisição, ou vazio se não houver.
+func Tenant(ctx context.Context) string {
+	return tenant.ID(ctx)
+}
+
+// Locale devolve o locale resolvido para a requisição.
+func Locale(ctx context.Context) string {
+	return server.LocaleFromContext(ctx)
+}
+
+// Claims devolve as claims do token validado pelo middleware de autenticação.
+func Claims(ctx context.Context) (auth.Claims, bool) {
+	return auth.ClaimsFromContext(ctx)
+}
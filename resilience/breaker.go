@@ -0,0 +1,179 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+)
+
+// State é o estado do circuito.
+type State int
+
+const (
+	// Closed permite as chamadas e contabiliza as falhas.
+	Closed State = iota
+	// Open rejeita as chamadas até o fim do cooldown.
+	Open
+	// HalfOpen permite uma chamada de teste, que fecha ou reabre o circuito.
+	HalfOpen
+)
+
+// String devolve o nome do estado.
+func (s State) String() string {
+	switch s {
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// BreakerOptions configura o circuit breaker.
+type BreakerOptions struct {
+	// Name identifica o circuito nas métricas e nos erros.
+	Name string
+	// FailureThreshold é o número de falhas consecutivas que abre o circuito (padrão: 5).
+	FailureThreshold int
+	// Cooldown é o tempo em que o circuito fica aberto antes da chamada de teste (padrão: 10s).
+	Cooldown time.Duration
+	// IsFailure decide se o erro conta como falha (padrão: todos, exceto o cancelamento do contexto).
+	IsFailure func(err error) bool
+	// OnStateChange é chamado a cada mudança de estado.
+	OnStateChange func(name string, from State, to State)
+}
+
+// CircuitBreaker interrompe as chamadas a uma dependência após falhas
+// consecutivas, dando tempo para que ela se recupere.
+type CircuitBreaker struct {
+	opts BreakerOptions
+	tags []string
+
+	mu        sync.Mutex
+	state     State
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// NewCircuitBreaker cria o circuito, inicialmente fechado.
+func NewCircuitBreaker(opts BreakerOptions) *CircuitBreaker {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = 5
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = 10 * time.Second
+	}
+	if opts.IsFailure == nil {
+		opts.IsFailure = func(err error) bool { return !errors.Is(err, context.Canceled) }
+	}
+	return &CircuitBreaker{opts: opts, tags: tags(opts.Name)}
+}
+
+// Execute implementa Policy, devolvendo uma AppError UNAVAILABLE (que envolve
+// ErrCircuitOpen) quando o circuito está aberto.
+func (b *CircuitBreaker) Execute(ctx context.Context, fn Func) error {
+	if !b.Allow() {
+		return unavailable("circuit open", b.opts.Name, ErrCircuitOpen)
+	}
+
+	err := fn(ctx)
+	if err != nil && b.opts.IsFailure(err) {
+		b.Failure()
+		return err
+	}
+	b.Success()
+	return err
+}
+
+// Allow informa se a chamada pode ser feita; para uso sem Execute, seguido de
+// Success, Failure ou Release.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Open:
+		if time.Now().Before(b.openUntil) {
+			dd.Metrics().Incr("resilience.circuit.rejected", b.tags...)
+			return false
+		}
+		b.setState(HalfOpen)
+		b.probing = true
+		return true
+	case HalfOpen:
+		// Apenas uma chamada de teste por vez.
+		if b.probing {
+			dd.Metrics().Incr("resilience.circuit.rejected", b.tags...)
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Success registra uma chamada bem-sucedida, fechando o circuito.
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	if b.state != Closed {
+		b.setState(Closed)
+	}
+}
+
+// Release libera a chamada de teste sem registrar resultado, para chamadas
+// interrompidas por motivos alheios ao destino (ex.: cancelamento pelo chamador).
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// Failure registra uma falha, abrindo o circuito ao atingir o limite ou na chamada de teste.
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == HalfOpen || b.failures >= b.opts.FailureThreshold {
+		b.failures = 0
+		b.openUntil = time.Now().Add(b.opts.Cooldown)
+		b.setState(Open)
+	}
+}
+
+// State devolve o estado atual do circuito.
+func (b *CircuitBreaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == Open && !time.Now().Before(b.openUntil) {
+		return HalfOpen
+	}
+	return b.state
+}
+
+// setState altera o estado, publicando a métrica e chamando OnStateChange.
+func (b *CircuitBreaker) setState(to State) {
+	from := b.state
+	b.state = to
+
+	dd.Metrics().Gauge("resilience.circuit.state", float64(to), b.tags...)
+	if to == Open {
+		dd.Metrics().Incr("resilience.circuit.opened", b.tags...)
+	}
+	if b.opts.OnStateChange != nil {
+		b.opts.OnStateChange(b.opts.Name, from, to)
+	}
+}
@@ -0,0 +1,76 @@
+package resilience
+
+import (
+	"context"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+)
+
+// BulkheadOptions configura o bulkhead.
+type BulkheadOptions struct {
+	// Name identifica o bulkhead nas métricas e nos erros.
+	Name string
+	// MaxConcurrent é o número máximo de chamadas simultâneas (padrão: 10).
+	MaxConcurrent int
+	// MaxWait é a espera máxima por uma vaga antes da rejeição (padrão: 0, rejeita imediatamente).
+	MaxWait time.Duration
+}
+
+// Bulkhead limita as chamadas simultâneas a uma dependência, isolando a
+// lentidão dela do restante do serviço.
+type Bulkhead struct {
+	opts  BulkheadOptions
+	tags  []string
+	slots chan struct{}
+}
+
+// NewBulkhead cria o bulkhead.
+func NewBulkhead(opts BulkheadOptions) *Bulkhead {
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = 10
+	}
+	return &Bulkhead{opts: opts, tags: tags(opts.Name), slots: make(chan struct{}, opts.MaxConcurrent)}
+}
+
+// Execute implementa Policy, devolvendo uma AppError UNAVAILABLE (que envolve
+// ErrBulkheadFull) quando não há vaga dentro de MaxWait.
+func (b *Bulkhead) Execute(ctx context.Context, fn Func) error {
+	if !b.acquire(ctx) {
+		dd.Metrics().Incr("resilience.bulkhead.rejected", b.tags...)
+		return unavailable("too many concurrent calls", b.opts.Name, ErrBulkheadFull)
+	}
+	defer func() { <-b.slots }()
+
+	dd.Metrics().Gauge("resilience.bulkhead.in_use", float64(len(b.slots)), b.tags...)
+	return fn(ctx)
+}
+
+// acquire reserva uma vaga, aguardando até MaxWait.
+func (b *Bulkhead) acquire(ctx context.Context) bool {
+	select {
+	case b.slots <- struct{}{}:
+		return true
+	default:
+	}
+	if b.opts.MaxWait <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(b.opts.MaxWait)
+	defer timer.Stop()
+
+	select {
+	case b.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// InUse devolve o número de chamadas em andamento.
+func (b *Bulkhead) InUse() int {
+	return len(b.slots)
+}
@@ -0,0 +1,86 @@
+// Package resilience reúne as políticas de resiliência compartilhadas pelo
+// httpclient, db, cache e queue: Retry, CircuitBreaker, Bulkhead e Timeout,
+// combináveis sobre func(ctx) error, com métricas no Datadog (tag policy:<nome>)
+// e erros da errx (UNAVAILABLE para circuito aberto e bulkhead cheio, TIMEOUT
+// para tempo esgotado).
+//
+//	breaker := resilience.NewCircuitBreaker(resilience.BreakerOptions{Name: "billing"})
+//	policy := resilience.Wrap(
+//		resilience.NewRetry(resilience.RetryOptions{Name: "billing", MaxRetries: 2}),
+//		breaker,
+//		resilience.Timeout("billing", 2*time.Second),
+//	)
+//
+//	err := policy.Execute(ctx, func(ctx context.Context) error {
+//		return billing.Charge(ctx, payment)
+//	})
+package resilience
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+var (
+	// ErrCircuitOpen indica que o circuito está aberto e a chamada não foi feita.
+	ErrCircuitOpen = errors.New("resilience: circuit open")
+	// ErrBulkheadFull indica que o limite de chamadas concorrentes foi atingido.
+	ErrBulkheadFull = errors.New("resilience: bulkhead full")
+	// ErrTimeout indica que a chamada excedeu o tempo da política Timeout.
+	ErrTimeout = errors.New("resilience: timeout")
+)
+
+// Func é a operação protegida pelas políticas.
+type Func func(ctx context.Context) error
+
+// Policy executa uma operação aplicando uma política de resiliência.
+type Policy interface {
+	Execute(ctx context.Context, fn Func) error
+}
+
+// PolicyFunc adapta uma função à interface Policy.
+type PolicyFunc func(ctx context.Context, fn Func) error
+
+// Execute implementa Policy.
+func (f PolicyFunc) Execute(ctx context.Context, fn Func) error {
+	return f(ctx, fn)
+}
+
+// Wrap combina as políticas; a primeira é a mais externa. Em geral, use
+// Retry por fora, depois CircuitBreaker e Bulkhead, e Timeout por dentro,
+// para que cada tentativa tenha o seu próprio tempo limite.
+func Wrap(policies ...Policy) Policy {
+	return PolicyFunc(func(ctx context.Context, fn Func) error {
+		next := fn
+		for i := len(policies) - 1; i >= 0; i-- {
+			policy, inner := policies[i], next
+			next = func(ctx context.Context) error {
+				return policy.Execute(ctx, inner)
+			}
+		}
+		return next(ctx)
+	})
+}
+
+// Do executa fn com as políticas informadas, combinadas como em Wrap.
+func Do(ctx context.Context, fn Func, policies ...Policy) error {
+	return Wrap(policies...).Execute(ctx, fn)
+}
+
+// unavailable monta a AppError UNAVAILABLE das rejeições das políticas.
+func unavailable(message string, name string, cause error) error {
+	return errx.New(message).
+		WithCode(errx.UNAVAILABLE).
+		WithError(cause).
+		WithDetails(map[string]interface{}{"policy": name})
+}
+
+// tags devolve as tags de métricas da política.
+func tags(name string) []string {
+	if name == "" {
+		name = "default"
+	}
+	return []string{"policy:" + name}
+}
@@ -0,0 +1,107 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+)
+
+// RetryOptions configura a política de novas tentativas.
+type RetryOptions struct {
+	// Name identifica a política nas métricas.
+	Name string
+	// MaxRetries é o número de novas tentativas após a primeira falha (padrão: 3; use -1 para não repetir).
+	MaxRetries int
+	// Backoff é a espera antes da primeira nova tentativa, dobrada a cada
+	// tentativa seguinte (padrão: 100ms).
+	Backoff time.Duration
+	// MaxBackoff limita a espera entre tentativas (padrão: sem limite).
+	MaxBackoff time.Duration
+	// Jitter sorteia cada espera entre metade e o valor integral, espalhando
+	// as novas tentativas de instâncias diferentes.
+	Jitter bool
+	// Retryable decide se o erro merece nova tentativa (padrão: todos, exceto
+	// o cancelamento do contexto e o circuito aberto).
+	Retryable func(err error) bool
+	// OnRetry é chamado antes de cada nova tentativa, com o número da tentativa que falhou.
+	OnRetry func(ctx context.Context, attempt int, err error)
+}
+
+// Retry repete a operação em falhas transitórias, com backoff exponencial.
+type Retry struct {
+	opts RetryOptions
+	tags []string
+}
+
+// NewRetry cria a política de novas tentativas.
+func NewRetry(opts RetryOptions) *Retry {
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = 3
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = 100 * time.Millisecond
+	}
+	if opts.Retryable == nil {
+		opts.Retryable = defaultRetryable
+	}
+	return &Retry{opts: opts, tags: tags(opts.Name)}
+}
+
+type attemptKey struct{}
+
+// Attempt devolve o número da tentativa em andamento (1 na primeira) dentro de
+// uma operação executada por Retry, ou 1 fora dela.
+func Attempt(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
+
+// Execute implementa Policy.
+func (r *Retry) Execute(ctx context.Context, fn Func) error {
+	backoff := r.opts.Backoff
+	for attempt := 1; ; attempt++ {
+		err := fn(context.WithValue(ctx, attemptKey{}, attempt))
+		if err == nil {
+			return nil
+		}
+		if attempt > r.opts.MaxRetries || !r.opts.Retryable(err) || ctx.Err() != nil {
+			if attempt > 1 {
+				dd.Metrics().Incr("resilience.retry.exhausted", r.tags...)
+			}
+			return err
+		}
+
+		if r.opts.OnRetry != nil {
+			r.opts.OnRetry(ctx, attempt, err)
+		}
+		dd.Metrics().Incr("resilience.retry", r.tags...)
+
+		wait := backoff
+		if r.opts.Jitter {
+			wait = wait/2 + rand.N(wait/2+1)
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if r.opts.MaxBackoff > 0 && backoff > r.opts.MaxBackoff {
+			backoff = r.opts.MaxBackoff
+		}
+	}
+}
+
+// defaultRetryable repete qualquer erro, exceto cancelamento e circuito aberto.
+func defaultRetryable(err error) bool {
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, ErrCircuitOpen)
+}
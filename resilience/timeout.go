@@ -0,0 +1,31 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// Timeout devolve uma política que limita a duração da operação. Ao esgotar
+// o tempo, devolve uma AppError TIMEOUT que envolve ErrTimeout e o erro da
+// operação. A operação precisa respeitar o cancelamento de ctx.
+func Timeout(name string, timeout time.Duration) Policy {
+	metricTags := tags(name)
+	return PolicyFunc(func(ctx context.Context, fn Func) error {
+		ctx, cancel := context.WithTimeoutCause(ctx, timeout, ErrTimeout)
+		defer cancel()
+
+		err := fn(ctx)
+		if err != nil && errors.Is(context.Cause(ctx), ErrTimeout) {
+			dd.Metrics().Incr("resilience.timeout", metricTags...)
+			return errx.New("operation timed out").
+				WithCode(errx.TIMEOUT).
+				WithError(errors.Join(ErrTimeout, err)).
+				WithDetails(map[string]interface{}{"policy": name, "timeout": timeout.String()})
+		}
+		return err
+	})
+}
@@ -0,0 +1,383 @@
+// Package scheduler executa jobs recorrentes em segundo plano, agendados por
+// expressão cron ou por intervalo fixo. Cada execução tem timeout próprio, é
+// protegida contra panics (convertidos em errx), não se sobrepõe à execução
+// anterior do mesmo job e gera um span "scheduler.job" no Datadog. Com
+// WithLock, cada disparo roda em apenas uma das instâncias do serviço.
+//
+//	sched := scheduler.New()
+//	if err := sched.Every("refresh-rates", time.Minute, refreshRates, scheduler.WithTimeout(30*time.Second)); err != nil {
+//		return err
+//	}
+//	if err := sched.Cron("daily-report", "0 6 * * *", sendReport, scheduler.WithLock(locker)); err != nil {
+//		return err
+//	}
+//
+//	server.N().
+//		OnStart(sched.Start).
+//		OnStop(sched.Stop).
+//		AdminToken(token).
+//		AdminRoutes(sched.AdminRoutes).
+//		Run("8080")
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/lock"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"github.com/robfig/cron/v3"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// Job é a função executada a cada disparo do agendamento.
+type Job func(ctx context.Context) error
+
+// JobOption personaliza um job registrado.
+type JobOption func(*job)
+
+// WithTimeout limita a duração de cada execução do job (padrão: sem limite).
+func WithTimeout(timeout time.Duration) JobOption {
+	return func(j *job) {
+		j.timeout = timeout
+	}
+}
+
+// AllowOverlap permite que uma nova execução comece enquanto a anterior ainda
+// está em andamento. Por padrão, o disparo é ignorado nesse caso.
+func AllowOverlap() JobOption {
+	return func(j *job) {
+		j.overlap = true
+	}
+}
+
+// WithLock faz o job disputar um lock distribuído ("scheduler:<nome>") a cada
+// disparo, para que apenas uma das instâncias do serviço o execute. O lock é
+// mantido por alguns segundos após o fim da execução, cobrindo a diferença de
+// relógio entre as instâncias; as que não o obtêm contam o disparo como ignorado.
+func WithLock(locker lock.Locker) JobOption {
+	return func(j *job) {
+		j.locker = locker
+	}
+}
+
+const (
+	// lockTTL é a expiração do lock de um job, renovado durante a execução.
+	lockTTL = 30 * time.Second
+	// lockHold é o tempo máximo que o lock é mantido após o disparo.
+	lockHold = 5 * time.Second
+)
+
+// JobStatus é o estado de um job exposto pelo endpoint administrativo.
+type JobStatus struct {
+	Name         string        `json:"name"`
+	Schedule     string        `json:"schedule"`
+	Running      bool          `json:"running"`
+	NextRun      time.Time     `json:"next_run,omitempty"`
+	LastRun      time.Time     `json:"last_run,omitempty"`
+	LastDuration time.Duration `json:"last_duration"`
+	LastError    string        `json:"last_error,omitempty"`
+	Runs         int64         `json:"runs"`
+	Failures     int64         `json:"failures"`
+	Skipped      int64         `json:"skipped"`
+}
+
+// job é um job registrado e o seu estado.
+type job struct {
+	name     string
+	spec     string
+	schedule cron.Schedule
+	fn       Job
+	timeout  time.Duration
+	overlap  bool
+	locker   lock.Locker
+
+	mu      sync.Mutex
+	running int
+	status  JobStatus
+}
+
+// Scheduler mantém os jobs registrados e os dispara enquanto estiver iniciado.
+type Scheduler struct {
+	mu     sync.Mutex
+	jobs   []*job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New cria um Scheduler vazio.
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every registra um job executado a cada intervalo, a partir do início do Scheduler.
+func (s *Scheduler) Every(name string, interval time.Duration, fn Job, opts ...JobOption) error {
+	if interval <= 0 {
+		return fmt.Errorf("scheduler: job %s: interval must be positive", name)
+	}
+	return s.add(name, "@every "+interval.String(), cron.Every(interval), fn, opts)
+}
+
+// Cron registra um job agendado por uma expressão cron de cinco campos
+// ("minuto hora dia mês dia-da-semana") ou por descritores como "@hourly".
+func (s *Scheduler) Cron(name string, spec string, fn Job, opts ...JobOption) error {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return fmt.Errorf("scheduler: job %s: invalid cron spec %q: %w", name, spec, err)
+	}
+	return s.add(name, spec, schedule, fn, opts)
+}
+
+// add registra o job, recusando nomes repetidos.
+func (s *Scheduler) add(name string, spec string, schedule cron.Schedule, fn Job, opts []JobOption) error {
+	j := &job{name: name, spec: spec, schedule: schedule, fn: fn}
+	for _, opt := range opts {
+		opt(j)
+	}
+	j.status = JobStatus{Name: name, Schedule: spec}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.jobs {
+		if existing.name == name {
+			return fmt.Errorf("scheduler: job %s already registered", name)
+		}
+	}
+	if s.cancel != nil {
+		return fmt.Errorf("scheduler: job %s registered after start", name)
+	}
+	s.jobs = append(s.jobs, j)
+	return nil
+}
+
+// Start inicia o disparo dos jobs em segundo plano; compatível com server.OnStart.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return errors.New("scheduler: already started")
+	}
+
+	ctx, s.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go func(j *job) {
+			defer s.wg.Done()
+			s.loop(ctx, j)
+		}(j)
+	}
+	return nil
+}
+
+// Stop interrompe os disparos e aguarda as execuções em andamento, cujo
+// contexto é cancelado; compatível com server.OnStop.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Status devolve o estado dos jobs, ordenados pelo nome.
+func (s *Scheduler) Status() []JobStatus {
+	s.mu.Lock()
+	jobs := append([]*job(nil), s.jobs...)
+	s.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(jobs))
+	for _, j := range jobs {
+		j.mu.Lock()
+		status := j.status
+		status.Running = j.running > 0
+		j.mu.Unlock()
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// AdminRoutes registra GET /jobs com o estado dos jobs; use com server.AdminRoutes
+// para publicá-lo em /admin/jobs, protegido pelo token administrativo.
+func (s *Scheduler) AdminRoutes(r gin.IRouter) {
+	r.GET("/jobs", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"jobs": s.Status()})
+	})
+}
+
+// loop aguarda cada disparo do job até ctx ser cancelado.
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	var running sync.WaitGroup
+	defer running.Wait()
+
+	for {
+		next := j.schedule.Next(time.Now())
+		j.mu.Lock()
+		j.status.NextRun = next
+		j.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if !j.acquire() {
+			logx.Ctx(ctx).Warn("Scheduled job still running, skipping", "job", j.name)
+			continue
+		}
+
+		running.Add(1)
+		go func(tick time.Time) {
+			defer running.Done()
+			defer j.release()
+			j.execute(ctx, tick)
+		}(next)
+	}
+}
+
+// acquire reserva uma execução, respeitando a prevenção de sobreposição.
+func (j *job) acquire() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.running > 0 && !j.overlap {
+		j.status.Skipped++
+		return false
+	}
+	j.running++
+	return true
+}
+
+// release libera a execução reservada por acquire.
+func (j *job) release() {
+	j.mu.Lock()
+	j.running--
+	j.mu.Unlock()
+}
+
+// execute roda o disparo, disputando o lock distribuído quando configurado.
+func (j *job) execute(ctx context.Context, tick time.Time) {
+	if j.locker == nil {
+		j.run(ctx)
+		return
+	}
+
+	err := lock.Do(ctx, j.locker, "scheduler:"+j.name, lockTTL, func(ctx context.Context) error {
+		j.run(ctx)
+		j.hold(ctx, tick)
+		return nil
+	})
+	switch {
+	case errors.Is(err, lock.ErrNotAcquired):
+		j.mu.Lock()
+		j.status.Skipped++
+		j.mu.Unlock()
+		logx.Ctx(ctx).Debug("Scheduled job running on another instance, skipping", "job", j.name)
+	case err != nil:
+		logx.Ctx(ctx).Warn("Scheduled job lock failed", "job", j.name, "error", err)
+	}
+}
+
+// hold mantém o lock até pouco depois do disparo, para que instâncias com o
+// relógio atrasado não executem o mesmo disparo após uma execução rápida.
+func (j *job) hold(ctx context.Context, tick time.Time) {
+	wait := lockHold
+	if interval := j.schedule.Next(tick).Sub(tick) / 2; interval < wait {
+		wait = interval
+	}
+
+	timer := time.NewTimer(time.Until(tick.Add(wait)))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// run executa o job uma vez com timeout, span e recuperação de panic.
+func (j *job) run(ctx context.Context) {
+	if j.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.timeout)
+		defer cancel()
+	}
+
+	span, ctx := dd.StartSpan(ctx, "scheduler.job",
+		tracer.ResourceName(j.name),
+		tracer.Tag(ext.SpanType, "worker"),
+		tracer.Tag("job.schedule", j.spec),
+	)
+
+	start := time.Now()
+	err := j.call(ctx)
+	duration := time.Since(start)
+
+	dd.SetSpanError(span, err)
+	dd.FinishSpan(span)
+
+	j.mu.Lock()
+	j.status.Runs++
+	j.status.LastRun = start
+	j.status.LastDuration = duration
+	j.status.LastError = ""
+	if err != nil {
+		j.status.Failures++
+		j.status.LastError = err.Error()
+	}
+	j.mu.Unlock()
+
+	if err != nil {
+		logx.Ctx(ctx).Error("Scheduled job failed", "job", j.name, "duration", duration, "error", err)
+		return
+	}
+	logx.Ctx(ctx).Debug("Scheduled job finished", "job", j.name, "duration", duration)
+}
+
+// call executa a função do job convertendo panics em erros da errx.
+func (j *job) call(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errx.New("scheduled job panicked").
+				WithCode(errx.INTERNAL).
+				WithDetails(map[string]interface{}{
+					"job":   j.name,
+					"panic": fmt.Sprint(r),
+					"stack": string(debug.Stack()),
+				})
+		}
+	}()
+	return j.fn(ctx)
+}
@@ -0,0 +1,41 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+// AWS devolve um provedor que lê segredos do AWS Secrets Manager pelo nome ou ARN.
+func AWS(client *secretsmanager.Client) Provider {
+	return ProviderFunc(func(ctx context.Context, name string) (string, error) {
+		out, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(name),
+		})
+		if err != nil {
+			var notFound *types.ResourceNotFoundException
+			if errors.As(err, &notFound) {
+				return "", ErrNotFound
+			}
+			return "", err
+		}
+		if out.SecretString != nil {
+			return *out.SecretString, nil
+		}
+		return string(out.SecretBinary), nil
+	})
+}
+
+// NewAWS cria um provedor do AWS Secrets Manager com a configuração padrão do
+// SDK (variáveis AWS_*, perfil compartilhado ou role da instância).
+func NewAWS(ctx context.Context) (Provider, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return AWS(secretsmanager.NewFromConfig(cfg)), nil
+}
@@ -0,0 +1,24 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// Env devolve um provedor que lê segredos das variáveis de ambiente. O nome é
+// convertido para o formato de variável: "db/password" → DB_PASSWORD.
+func Env() Provider {
+	return ProviderFunc(func(_ context.Context, name string) (string, error) {
+		value, ok := os.LookupEnv(envName(name))
+		if !ok {
+			return "", ErrNotFound
+		}
+		return value, nil
+	})
+}
+
+// envName converte o nome do segredo em nome de variável de ambiente.
+func envName(name string) string {
+	return strings.ToUpper(strings.NewReplacer("/", "_", "-", "_", ".", "_").Replace(name))
+}
@@ -0,0 +1,159 @@
+// Package secrets obtém segredos de provedores externos (variáveis de ambiente,
+// AWS Secrets Manager e Vault), com cache e renovação periódica para acompanhar
+// as rotações.
+//
+//	secrets.SetDefault(secrets.New(5*time.Minute, awsProvider, secrets.Env()))
+//	password, err := secrets.Get(ctx, "db/password")
+//
+// Para segredos em JSON, um campo específico é selecionado com "#":
+// "db/credentials#password".
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrNotFound indica que nenhum provedor possui o segredo.
+var ErrNotFound = errors.New("secret not found")
+
+// Provider obtém o valor bruto de um segredo pelo nome.
+type Provider interface {
+	// Get devolve o valor do segredo ou ErrNotFound quando ele não existe no provedor.
+	Get(ctx context.Context, name string) (string, error)
+}
+
+// ProviderFunc adapta uma função à interface Provider.
+type ProviderFunc func(ctx context.Context, name string) (string, error)
+
+// Get implementa Provider.
+func (f ProviderFunc) Get(ctx context.Context, name string) (string, error) {
+	return f(ctx, name)
+}
+
+// Store consulta os provedores em ordem e mantém os valores em cache pelo TTL.
+// Após o TTL, o segredo é buscado novamente, refletindo rotações no provedor.
+type Store struct {
+	providers []Provider
+	ttl       time.Duration
+
+	mu    sync.Mutex
+	cache map[string]entry
+}
+
+// entry é um valor em cache e sua validade.
+type entry struct {
+	value   string
+	expires time.Time
+}
+
+// New cria um Store com os provedores informados, consultados em ordem.
+// Com ttl zero, os valores não são mantidos em cache.
+func New(ttl time.Duration, providers ...Provider) *Store {
+	return &Store{
+		providers: providers,
+		ttl:       ttl,
+		cache:     map[string]entry{},
+	}
+}
+
+// Get devolve o segredo, usando o cache enquanto válido. Referências no formato
+// "nome#campo" selecionam um campo de um segredo em JSON.
+func (s *Store) Get(ctx context.Context, ref string) (string, error) {
+	s.mu.Lock()
+	if e, ok := s.cache[ref]; ok && time.Now().Before(e.expires) {
+		s.mu.Unlock()
+		return e.value, nil
+	}
+	s.mu.Unlock()
+
+	name, key, _ := strings.Cut(ref, "#")
+	value, err := s.lookup(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if key != "" {
+		if value, err = jsonField(value, key); err != nil {
+			return "", fmt.Errorf("secret %s: %w", ref, err)
+		}
+	}
+
+	if s.ttl > 0 {
+		s.mu.Lock()
+		s.cache[ref] = entry{value: value, expires: time.Now().Add(s.ttl)}
+		s.mu.Unlock()
+	}
+	return value, nil
+}
+
+// Invalidate remove segredos do cache; sem argumentos, limpa todo o cache.
+func (s *Store) Invalidate(refs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(refs) == 0 {
+		s.cache = map[string]entry{}
+		return
+	}
+	for _, ref := range refs {
+		delete(s.cache, ref)
+	}
+}
+
+// lookup consulta os provedores em ordem até encontrar o segredo.
+func (s *Store) lookup(ctx context.Context, name string) (string, error) {
+	for _, p := range s.providers {
+		value, err := p.Get(ctx, name)
+		if err == nil {
+			return value, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return "", fmt.Errorf("secret %s: %w", name, err)
+		}
+	}
+	return "", fmt.Errorf("secret %s: %w", name, ErrNotFound)
+}
+
+// jsonField extrai um campo de um segredo em JSON.
+func jsonField(value string, key string) (string, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return "", err
+	}
+	field, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("field %s: %w", key, ErrNotFound)
+	}
+	if str, ok := field.(string); ok {
+		return str, nil
+	}
+	return fmt.Sprint(field), nil
+}
+
+// defaultStore é o Store usado pelas funções do pacote; por padrão, lê do ambiente.
+var defaultStore atomic.Pointer[Store]
+
+func init() {
+	defaultStore.Store(New(0, Env()))
+}
+
+// SetDefault substitui o Store usado por Get e pelo carregador de configuração.
+func SetDefault(s *Store) {
+	defaultStore.Store(s)
+}
+
+// Default devolve o Store padrão.
+func Default() *Store {
+	return defaultStore.Load()
+}
+
+// Get obtém um segredo do Store padrão.
+func Get(ctx context.Context, ref string) (string, error) {
+	return Default().Get(ctx, ref)
+}
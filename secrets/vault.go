@@ -0,0 +1,75 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// VaultOptions configura o provedor do Vault.
+type VaultOptions struct {
+	// Address é o endereço do Vault (padrão: VAULT_ADDR).
+	Address string
+	// Token é o token de acesso (padrão: VAULT_TOKEN).
+	Token string
+	// Mount é o ponto de montagem do KV versão 2 (padrão: "secret").
+	Mount string
+	// Client é o cliente HTTP usado nas consultas (padrão: timeout de 10s).
+	Client *http.Client
+}
+
+// Vault devolve um provedor que lê segredos do KV versão 2 do Vault. O nome é o
+// caminho do segredo e o valor é o JSON com todos os campos; use a referência
+// "db#password" no Store para selecionar um campo.
+func Vault(opts VaultOptions) Provider {
+	if opts.Address == "" {
+		opts.Address = os.Getenv("VAULT_ADDR")
+	}
+	if opts.Token == "" {
+		opts.Token = os.Getenv("VAULT_TOKEN")
+	}
+	if opts.Mount == "" {
+		opts.Mount = "secret"
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return ProviderFunc(func(ctx context.Context, name string) (string, error) {
+		endpoint := strings.TrimSuffix(opts.Address, "/") + "/v1/" + url.PathEscape(opts.Mount) + "/data/" + strings.TrimPrefix(name, "/")
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("X-Vault-Token", opts.Token)
+
+		resp, err := opts.Client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+
+		switch {
+		case resp.StatusCode == http.StatusNotFound:
+			return "", ErrNotFound
+		case resp.StatusCode != http.StatusOK:
+			return "", fmt.Errorf("vault: unexpected status %d", resp.StatusCode)
+		}
+
+		var body struct {
+			Data struct {
+				Data json.RawMessage `json:"data"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return "", fmt.Errorf("vault: %w", err)
+		}
+		return string(body.Data.Data), nil
+	})
+}
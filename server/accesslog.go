@@ -0,0 +1,47 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// AccessLogOptions configura o log de acesso emitido a cada requisição.
+type AccessLogOptions struct {
+	// Format é logx.FormatJSON (uma linha JSON por requisição) ou
+	// logx.FormatConsole (legível, para desenvolvimento). Quando vazio, usa
+	// console no modo debug do Gin e o logger padrão do logx nos demais.
+	Format string
+	// Output recebe as linhas quando Format é informado; quando nil, usa os.Stdout.
+	Output io.Writer
+	// Fields permite incluir, remover ou renomear campos do log de acesso;
+	// recebe os pares chave/valor padrão e devolve os que serão registrados.
+	Fields func(c *gin.Context, fields []any) []any
+}
+
+// AccessLog configura o formato e os campos do log de acesso.
+func (s *Server) AccessLog(opts AccessLogOptions) *Server {
+	s.accessLog = opts
+	return s
+}
+
+// logger devolve o logger do log de acesso para o modo do Gin informado,
+// ou nil para usar o logger padrão do logx.
+func (o AccessLogOptions) logger(mode string) *slog.Logger {
+	format := o.Format
+	if format == "" && mode == gin.DebugMode {
+		format = logx.FormatConsole
+	}
+	if format == "" {
+		return nil
+	}
+
+	output := o.Output
+	if output == nil {
+		output = os.Stdout
+	}
+	return logx.New(format, output)
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// bodyKey é a chave do gin.Context onde o corpo lido por Body é armazenado.
+const bodyKey = "server.body"
+
+// defaultMaxBody é o limite usado por Body quando o corpo não foi lido por BufferBody.
+const defaultMaxBody = 1 << 20
+
+// ErrBodyTooLarge indica que o corpo da requisição excede o limite de leitura.
+var ErrBodyTooLarge = errors.New("server: request body too large")
+
+// bodyReader devolve ao handler o corpo parcialmente lido seguido do restante.
+type bodyReader struct {
+	io.Reader
+	io.Closer
+}
+
+// BufferBody devolve um middleware que lê o corpo da requisição uma única vez,
+// limitado a maxBytes (padrão: 1 MiB), para que validação, auditoria e
+// verificação de assinatura o leiam por Body sem consumi-lo para o handler.
+// Corpos acima do limite são rejeitados com 413.
+func BufferBody(maxBytes int64) gin.HandlerFunc {
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxBody
+	}
+
+	return func(c *gin.Context) {
+		if _, err := readBody(c, maxBytes); err != nil {
+			if errors.Is(err, ErrBodyTooLarge) {
+				Fail(c, errx.New("request body too large").
+					WithCode(errx.PAYLOAD_TOO_LARGE).
+					WithDetails(map[string]interface{}{"max_bytes": maxBytes}))
+				return
+			}
+			logx.Ctx(c.Request.Context()).Warn("failed to read request body", "error", err)
+			Fail(c, errx.New("failed to read request body").WithCode(errx.BAD_REQUEST))
+			return
+		}
+		c.Next()
+	}
+}
+
+// Body devolve o corpo da requisição e o rebobina para o próximo leitor. O
+// corpo é lido uma única vez por requisição; sem BufferBody, o limite é 1 MiB
+// e, quando excedido, devolve ErrBodyTooLarge mantendo o corpo intacto para o handler.
+//
+//	body, err := server.Body(c)
+func Body(c *gin.Context) ([]byte, error) {
+	return readBody(c, defaultMaxBody)
+}
+
+// readBody lê e armazena o corpo da requisição até limit bytes.
+func readBody(c *gin.Context, limit int64) ([]byte, error) {
+	if value, ok := c.Get(bodyKey); ok {
+		body := value.([]byte)
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		return body, nil
+	}
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	original := c.Request.Body
+	body, err := io.ReadAll(io.LimitReader(original, limit+1))
+	if err != nil || int64(len(body)) > limit {
+		c.Request.Body = bodyReader{Reader: io.MultiReader(bytes.NewReader(body), original), Closer: original}
+		if err != nil {
+			return nil, fmt.Errorf("server: read request body: %w", err)
+		}
+		return nil, ErrBodyTooLarge
+	}
+
+	c.Set(bodyKey, body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// defaultRedactFields são os campos mascarados quando nenhum é informado.
+var defaultRedactFields = []string{
+	"password", "senha", "token", "access_token", "refresh_token",
+	"secret", "authorization", "cpf", "card_number",
+}
+
+// redactedValue substitui o valor de campos sensíveis nos logs.
+const redactedValue = "***"
+
+// BodyLogOptions configura a captura de corpos de requisição e resposta.
+type BodyLogOptions struct {
+	// MaxBytes limita quantos bytes de cada corpo são registrados; quando zero, assume 4096.
+	MaxBytes int
+	// SampleRate é a fração de requisições registradas (0 < rate <= 1); quando zero, registra todas.
+	SampleRate float64
+	// RedactFields lista os campos JSON mascarados (comparação sem diferenciar maiúsculas).
+	RedactFields []string
+	// Output recebe as linhas JSON de log; quando nil, os corpos são registrados
+	// pelo logger da requisição (logx.Ctx).
+	Output io.Writer
+}
+
+// bodyLogEntry é a linha JSON emitida para cada requisição amostrada.
+type bodyLogEntry struct {
+	Method        string `json:"method"`
+	Path          string `json:"path"`
+	Status        int    `json:"status"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+	RequestBody   string `json:"request_body,omitempty"`
+	ResponseBody  string `json:"response_body,omitempty"`
+	Truncated     bool   `json:"truncated,omitempty"`
+}
+
+// BodyLogging registra globalmente o middleware de log de corpos.
+// Deve ser habilitado apenas em ambientes de homologação ou para depuração pontual.
+func (s *Server) BodyLogging(opts BodyLogOptions) *Server {
+	return s.Middlewares(BodyLogger(opts))
+}
+
+// BodyLogger devolve um middleware que registra os corpos de requisição e resposta,
+// limitados em tamanho, com campos sensíveis mascarados e sujeitos a amostragem.
+func BodyLogger(opts BodyLogOptions) gin.HandlerFunc {
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = 4096
+	}
+	if opts.SampleRate <= 0 || opts.SampleRate > 1 {
+		opts.SampleRate = 1
+	}
+	if len(opts.RedactFields) == 0 {
+		opts.RedactFields = defaultRedactFields
+	}
+
+	redact := newRedactor(opts.RedactFields)
+
+	return func(c *gin.Context) {
+		if opts.SampleRate < 1 && rand.Float64() >= opts.SampleRate {
+			c.Next()
+			return
+		}
+
+		reqBody, err := Body(c)
+		if errors.Is(err, ErrBodyTooLarge) {
+			reqBody = peekBody(c, opts.MaxBytes+1)
+		}
+
+		w := &captureWriter{ResponseWriter: c.Writer, limit: opts.MaxBytes}
+		c.Writer = w
+
+		c.Next()
+
+		c.Writer = w.ResponseWriter
+
+		entry := bodyLogEntry{
+			Method:        c.Request.Method,
+			Path:          c.Request.URL.Path,
+			Status:        c.Writer.Status(),
+			CorrelationID: c.Writer.Header().Get("x-itau-correlation-id"),
+			RequestBody:   redact(truncate(reqBody, opts.MaxBytes)),
+			ResponseBody:  redact(w.buf.Bytes()),
+			Truncated:     len(reqBody) > opts.MaxBytes || w.size > opts.MaxBytes,
+		}
+
+		if opts.Output == nil {
+			logx.Ctx(c.Request.Context()).Info("http body",
+				"method", entry.Method,
+				"path", entry.Path,
+				"status", entry.Status,
+				"request_body", entry.RequestBody,
+				"response_body", entry.ResponseBody,
+				"truncated", entry.Truncated,
+			)
+			return
+		}
+
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(opts.Output, string(line))
+	}
+}
+
+// captureWriter copia até limit bytes da resposta sem interferir no envio ao cliente.
+type captureWriter struct {
+	gin.ResponseWriter
+	limit int
+	buf   bytes.Buffer
+	size  int
+}
+
+// Write envia os bytes ao cliente e guarda uma cópia limitada para o log.
+func (w *captureWriter) Write(data []byte) (int, error) {
+	w.size += len(data)
+	if room := w.limit - w.buf.Len(); room > 0 {
+		w.buf.Write(truncate(data, room))
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString implementa gin.ResponseWriter delegando para Write.
+func (w *captureWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// peekBody lê até n bytes do corpo da requisição sem consumi-lo, para registrar
+// o início de corpos acima do limite de Body.
+func peekBody(c *gin.Context, n int) []byte {
+	original := c.Request.Body
+	if original == nil {
+		return nil
+	}
+	prefix, _ := io.ReadAll(io.LimitReader(original, int64(n)))
+	c.Request.Body = bodyReader{Reader: io.MultiReader(bytes.NewReader(prefix), original), Closer: original}
+	return prefix
+}
+
+// truncate limita data a max bytes.
+func truncate(data []byte, max int) []byte {
+	if len(data) > max {
+		return data[:max]
+	}
+	return data
+}
+
+// newRedactor devolve uma função que mascara os campos sensíveis de um corpo.
+// Corpos JSON válidos são percorridos integralmente; corpos truncados ou não JSON
+// são mascarados por expressão regular nos pares "campo": valor.
+func newRedactor(fields []string) func([]byte) string {
+	lower := make(map[string]bool, len(fields))
+	quoted := make([]string, 0, len(fields))
+	for _, f := range fields {
+		lower[strings.ToLower(f)] = true
+		quoted = append(quoted, regexp.QuoteMeta(f))
+	}
+	pattern := regexp.MustCompile(`(?i)("(?:` + strings.Join(quoted, "|") + `)"\s*:\s*)("(?:[^"\\]|\\.)*"?|[^,}\]\s]+)`)
+
+	return func(body []byte) string {
+		if len(body) == 0 {
+			return ""
+		}
+
+		var doc interface{}
+		if err := json.Unmarshal(body, &doc); err == nil {
+			if out, err := json.Marshal(redactValue(doc, lower)); err == nil {
+				return string(out)
+			}
+		}
+
+		return pattern.ReplaceAllString(string(body), `${1}"`+redactedValue+`"`)
+	}
+}
+
+// redactValue percorre o documento JSON mascarando as chaves sensíveis.
+func redactValue(v interface{}, fields map[string]bool) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, inner := range val {
+			if fields[strings.ToLower(k)] {
+				val[k] = redactedValue
+				continue
+			}
+			val[k] = redactValue(inner, fields)
+		}
+		return val
+	case []interface{}:
+		for i, inner := range val {
+			val[i] = redactValue(inner, fields)
+		}
+		return val
+	default:
+		return v
+	}
+}
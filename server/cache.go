@@ -0,0 +1,77 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CachePolicy descreve a política de cache HTTP de uma rota ou grupo.
+type CachePolicy struct {
+	// MaxAge é o tempo em que a resposta pode ser reutilizada sem revalidação.
+	MaxAge time.Duration
+	// Private restringe o cache ao cliente (sem caches compartilhados/CDN).
+	Private bool
+	// NoStore proíbe qualquer armazenamento da resposta; ignora os demais campos.
+	NoStore bool
+	// MustRevalidate exige revalidação após a expiração.
+	MustRevalidate bool
+	// StaleWhileRevalidate permite servir conteúdo expirado enquanto revalida em segundo plano.
+	StaleWhileRevalidate time.Duration
+}
+
+// header monta o valor do cabeçalho Cache-Control correspondente à política.
+func (p CachePolicy) header() string {
+	if p.NoStore {
+		return "no-store"
+	}
+
+	directives := []string{"public"}
+	if p.Private {
+		directives[0] = "private"
+	}
+	directives = append(directives, "max-age="+strconv.Itoa(int(p.MaxAge/time.Second)))
+	if p.MustRevalidate {
+		directives = append(directives, "must-revalidate")
+	}
+	if p.StaleWhileRevalidate > 0 {
+		directives = append(directives, "stale-while-revalidate="+strconv.Itoa(int(p.StaleWhileRevalidate/time.Second)))
+	}
+	return strings.Join(directives, ", ")
+}
+
+// CacheWith devolve um middleware que aplica a política informada definindo
+// Cache-Control e Expires. Respostas a métodos diferentes de GET/HEAD não são
+// armazenáveis e recebem no-store.
+func CacheWith(policy CachePolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if policy.NoStore || (c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead) {
+			c.Header("Cache-Control", "no-store")
+			c.Header("Expires", "0")
+			c.Next()
+			return
+		}
+
+		c.Header("Cache-Control", policy.header())
+		c.Header("Expires", time.Now().Add(policy.MaxAge).UTC().Format(http.TimeFormat))
+		c.Next()
+	}
+}
+
+// Cache permite que a resposta seja armazenada por caches compartilhados durante ttl.
+func Cache(ttl time.Duration) gin.HandlerFunc {
+	return CacheWith(CachePolicy{MaxAge: ttl})
+}
+
+// PrivateCache permite que apenas o cliente armazene a resposta durante ttl.
+func PrivateCache(ttl time.Duration) gin.HandlerFunc {
+	return CacheWith(CachePolicy{MaxAge: ttl, Private: true})
+}
+
+// NoStore proíbe o armazenamento da resposta em qualquer cache.
+func NoStore() gin.HandlerFunc {
+	return CacheWith(CachePolicy{NoStore: true})
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// chaosEnvs são os valores de DD_ENV em que a injeção de falhas é permitida por padrão.
+var chaosEnvs = []string{"local", "dev", "development", "test", "qa", "hml", "homolog", "staging", "stg", "sandbox"}
+
+// maxChaosLatency é o limite padrão do atraso pedido em X-Chaos-Latency.
+const maxChaosLatency = 10 * time.Second
+
+// ChaosRule descreve as falhas injetadas em uma rota.
+type ChaosRule struct {
+	// Latency é o atraso adicionado antes do handler.
+	Latency time.Duration
+	// Jitter é a variação aleatória somada ao atraso (0 a Jitter).
+	Jitter time.Duration
+	// ErrorRate é a fração de requisições respondidas com erro (0 a 1).
+	ErrorRate float64
+	// ErrorStatus é o status HTTP do erro injetado; quando zero, assume 503.
+	ErrorStatus int
+}
+
+// ChaosOptions configura a injeção de falhas para testes de resiliência.
+type ChaosOptions struct {
+	// Rules associa regras a rotas, no formato "MÉTODO /rota" ou "/rota" (como
+	// registradas no Gin); a chave "*" vale para as demais rotas.
+	Rules map[string]ChaosRule
+	// Headers permite que o cliente defina as falhas por requisição com
+	// X-Chaos-Latency (ex.: "300ms"), X-Chaos-Error-Rate (ex.: "0.5") e X-Chaos-Status.
+	Headers bool
+	// MaxHeaderLatency limita o atraso pedido em X-Chaos-Latency; quando zero, assume 10s.
+	MaxHeaderLatency time.Duration
+	// Envs são os valores de DD_ENV em que a injeção é ativada; quando vazio,
+	// assume os ambientes de desenvolvimento e homologação usuais (ex.: "dev", "hml", "staging").
+	Envs []string
+}
+
+// Chaos registra globalmente a injeção de falhas. Ver InjectFaults.
+func (s *Server) Chaos(opts ChaosOptions) *Server {
+	return s.Middlewares(InjectFaults(opts))
+}
+
+// InjectFaults devolve um middleware que injeta latência e erros conforme as
+// regras da rota ou os cabeçalhos X-Chaos-*, para validar retries e circuit
+// breakers dos clientes. Destina-se a homologação: o middleware só atua quando
+// DD_ENV é um dos ambientes permitidos (ver ChaosOptions.Envs) e não faz nada
+// com DD_ENV vazio ou qualquer outro valor. Os erros injetados respondem com o
+// status exato da regra, não geram alertas nem consomem o SLO, e as respostas
+// afetadas recebem o cabeçalho X-Chaos-Injected.
+func InjectFaults(opts ChaosOptions) gin.HandlerFunc {
+	envs := opts.Envs
+	if len(envs) == 0 {
+		envs = chaosEnvs
+	}
+	if opts.MaxHeaderLatency <= 0 {
+		opts.MaxHeaderLatency = maxChaosLatency
+	}
+
+	env := strings.ToLower(os.Getenv("DD_ENV"))
+	if !slices.Contains(envs, env) {
+		logx.L().Warn("chaos middleware disabled outside allowed environments", "env", env)
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		rule, ok := opts.Rules[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			rule, ok = opts.Rules[c.FullPath()]
+		}
+		if !ok {
+			rule = opts.Rules["*"]
+		}
+		if opts.Headers {
+			rule = chaosFromHeaders(c, rule, opts.MaxHeaderLatency)
+		}
+
+		var injected []string
+		if delay := rule.Latency; delay > 0 || rule.Jitter > 0 {
+			if rule.Jitter > 0 {
+				delay += time.Duration(rand.Int63n(int64(rule.Jitter)))
+			}
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-c.Request.Context().Done():
+				timer.Stop()
+			}
+			injected = append(injected, "latency="+delay.String())
+		}
+
+		if rule.ErrorRate > 0 && rand.Float64() < rule.ErrorRate {
+			status := rule.ErrorStatus
+			if status == 0 {
+				status = 503
+			}
+			injected = append(injected, "error="+strconv.Itoa(status))
+			c.Header("X-Chaos-Injected", strings.Join(injected, ","))
+			dd.Metrics().Incr("server.chaos.injected", "type:error", "route:"+c.FullPath())
+			errx.AbortWithStatus(c, status, errx.New("injected fault").
+				WithCode(errx.StatusToCode(status)).
+				WithDetails(map[string]interface{}{"chaos": true}).
+				WithAlert(false).
+				WithSLOImpact(false))
+			return
+		}
+
+		if len(injected) > 0 {
+			c.Header("X-Chaos-Injected", strings.Join(injected, ","))
+			dd.Metrics().Incr("server.chaos.injected", "type:latency", "route:"+c.FullPath())
+		}
+		c.Next()
+	}
+}
+
+// chaosFromHeaders sobrepõe a regra com os valores dos cabeçalhos X-Chaos-*,
+// limitando o atraso a maxLatency.
+func chaosFromHeaders(c *gin.Context, rule ChaosRule, maxLatency time.Duration) ChaosRule {
+	if v := c.GetHeader("X-Chaos-Latency"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			rule.Latency = min(d, maxLatency)
+		}
+	}
+	if v := c.GetHeader("X-Chaos-Error-Rate"); v != "" {
+		if rate, err := strconv.ParseFloat(v, 64); err == nil && rate >= 0 && rate <= 1 {
+			rule.ErrorRate = rate
+		}
+	}
+	if v := c.GetHeader("X-Chaos-Status"); v != "" {
+		if status, err := strconv.Atoi(v); err == nil && status >= 400 && status <= 599 {
+			rule.ErrorStatus = status
+		}
+	}
+	return rule
+}
@@ -0,0 +1,271 @@
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultCompressibleTypes são os content-types comprimidos quando nenhum é informado.
+var defaultCompressibleTypes = []string{
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"image/svg+xml",
+	"text/",
+}
+
+// CompressionOptions configura o middleware de compressão de respostas.
+type CompressionOptions struct {
+	// MinSize é o tamanho mínimo, em bytes, para que a resposta seja comprimida.
+	// Quando zero, assume 1024.
+	MinSize int
+	// ContentTypes lista os prefixos de content-type elegíveis à compressão.
+	ContentTypes []string
+	// GzipLevel é o nível de compressão gzip; quando zero, usa gzip.DefaultCompression.
+	GzipLevel int
+	// BrotliLevel é o nível de compressão brotli; quando zero, usa brotli.DefaultCompression.
+	BrotliLevel int
+	// DisableBrotli restringe a negociação apenas ao gzip.
+	DisableBrotli bool
+}
+
+// Compression registra globalmente o middleware de compressão gzip/brotli.
+// Para aplicar apenas a um grupo de rotas, utilize Compress diretamente.
+func (s *Server) Compression(opts CompressionOptions) *Server {
+	return s.Middlewares(Compress(opts))
+}
+
+// Compress devolve um middleware que comprime respostas de acordo com o
+// Accept-Encoding do cliente, respeitando os filtros de tamanho e content-type.
+func Compress(opts CompressionOptions) gin.HandlerFunc {
+	if opts.MinSize <= 0 {
+		opts.MinSize = 1024
+	}
+	if len(opts.ContentTypes) == 0 {
+		opts.ContentTypes = defaultCompressibleTypes
+	}
+	if opts.GzipLevel == 0 {
+		opts.GzipLevel = gzip.DefaultCompression
+	}
+	if opts.BrotliLevel == 0 {
+		opts.BrotliLevel = brotli.DefaultCompression
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodHead || c.GetHeader("Upgrade") != "" {
+			c.Next()
+			return
+		}
+
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"), !opts.DisableBrotli)
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{ResponseWriter: c.Writer, opts: &opts, encoding: encoding}
+		c.Writer = w
+		defer func() {
+			w.close()
+			c.Writer = w.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding escolhe a melhor codificação suportada a partir do Accept-Encoding.
+// Codificações com q=0 (ou q inválido) são recusadas; "*" vale para as não listadas.
+func negotiateEncoding(header string, allowBrotli bool) string {
+	accepted := map[string]bool{}
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(f), "=")
+			if strings.EqualFold(strings.TrimSpace(key), "q") {
+				parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil {
+					parsed = 0
+				}
+				q = parsed
+			}
+		}
+		accepted[name] = q > 0
+	}
+
+	acceptable := func(name string) bool {
+		if ok, listed := accepted[name]; listed {
+			return ok
+		}
+		return accepted["*"]
+	}
+
+	switch {
+	case allowBrotli && acceptable("br"):
+		return "br"
+	case acceptable("gzip"):
+		return "gzip"
+	default:
+		return ""
+	}
+}
+
+// compressWriter acumula o início da resposta até decidir se vale a pena comprimi-la,
+// passando a escrever de forma contínua (streaming) a partir daí.
+type compressWriter struct {
+	gin.ResponseWriter
+	opts     *CompressionOptions
+	encoding string
+
+	status  int
+	buf     []byte
+	decided bool
+	encoder io.WriteCloser
+}
+
+// WriteHeader adia o envio do status até a decisão sobre a compressão.
+func (w *compressWriter) WriteHeader(code int) {
+	if !w.decided {
+		w.status = code
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// WriteHeaderNow é ignorado enquanto a decisão sobre a compressão estiver pendente.
+func (w *compressWriter) WriteHeaderNow() {
+	if w.decided {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// Status devolve o status definido pelo handler, mesmo que ainda não enviado.
+func (w *compressWriter) Status() int {
+	if !w.decided && w.status != 0 {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+// Written informa se o handler já produziu alguma saída.
+func (w *compressWriter) Written() bool {
+	return len(w.buf) > 0 || w.ResponseWriter.Written()
+}
+
+// Write acumula os bytes até atingir MinSize e então passa a escrever no destino final.
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, data...)
+		if len(w.buf) < w.opts.MinSize {
+			return len(data), nil
+		}
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString implementa gin.ResponseWriter delegando para Write.
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush força a decisão de compressão e descarrega o que já foi produzido.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if f, ok := w.encoder.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide define se a resposta será comprimida e escreve o conteúdo acumulado.
+func (w *compressWriter) decide() error {
+	w.decided = true
+
+	if w.shouldCompress() {
+		h := w.Header()
+		h.Set("Content-Encoding", w.encoding)
+		h.Add("Vary", "Accept-Encoding")
+		h.Del("Content-Length")
+
+		if w.encoding == "br" {
+			w.encoder = brotli.NewWriterLevel(w.ResponseWriter, w.opts.BrotliLevel)
+		} else {
+			gz, err := gzip.NewWriterLevel(w.ResponseWriter, w.opts.GzipLevel)
+			if err != nil {
+				gz = gzip.NewWriter(w.ResponseWriter)
+			}
+			w.encoder = gz
+		}
+	}
+
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if w.encoder != nil {
+		_, err := w.encoder.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// shouldCompress avalia tamanho, status, content-type e codificação prévia da resposta.
+func (w *compressWriter) shouldCompress() bool {
+	if len(w.buf) < w.opts.MinSize {
+		return false
+	}
+	if w.status == http.StatusNoContent || w.status == http.StatusNotModified {
+		return false
+	}
+
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := strings.ToLower(h.Get("Content-Type"))
+	for _, t := range w.opts.ContentTypes {
+		if strings.HasPrefix(contentType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// close finaliza a resposta, enviando o conteúdo pendente e fechando o encoder.
+func (w *compressWriter) close() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.encoder != nil {
+		_ = w.encoder.Close()
+	}
+}
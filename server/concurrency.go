@@ -0,0 +1,76 @@
+package server
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// ConcurrencyOptions configura o limitador de requisições simultâneas.
+type ConcurrencyOptions struct {
+	// Max é o número máximo de requisições em andamento.
+	Max int
+	// Wait é quanto uma requisição aguarda por uma vaga antes de ser rejeitada; zero rejeita imediatamente.
+	Wait time.Duration
+	// RetryAfter é o valor sugerido ao cliente no cabeçalho Retry-After; quando zero, assume 1s.
+	RetryAfter time.Duration
+}
+
+// ConcurrencyLimit registra globalmente o limitador de requisições simultâneas.
+// Para limitar apenas um grupo de rotas, utilize LimitConcurrency diretamente.
+func (s *Server) ConcurrencyLimit(opts ConcurrencyOptions) *Server {
+	return s.Middlewares(LimitConcurrency(opts))
+}
+
+// LimitConcurrency devolve um middleware que limita as requisições em andamento,
+// respondendo 503 com Retry-After quando não há vaga disponível.
+// Cada chamada cria um limitador independente.
+func LimitConcurrency(opts ConcurrencyOptions) gin.HandlerFunc {
+	if opts.Max <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	if opts.RetryAfter <= 0 {
+		opts.RetryAfter = time.Second
+	}
+
+	slots := make(chan struct{}, opts.Max)
+	retryAfter := strconv.Itoa(int((opts.RetryAfter + time.Second - 1) / time.Second))
+
+	return func(c *gin.Context) {
+		if !acquire(c, slots, opts.Wait) {
+			c.Header("Retry-After", retryAfter)
+			Fail(c, errx.New("too many concurrent requests").WithCode(errx.UNAVAILABLE))
+			return
+		}
+		defer func() { <-slots }()
+
+		c.Next()
+	}
+}
+
+// acquire tenta ocupar uma vaga, aguardando no máximo wait ou o cancelamento da requisição.
+func acquire(c *gin.Context, slots chan struct{}, wait time.Duration) bool {
+	select {
+	case slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	if wait <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-c.Request.Context().Done():
+		return false
+	}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// DecompressionOptions configura a descompressão dos corpos de requisição.
+type DecompressionOptions struct {
+	// MaxSize limita o tamanho do corpo descomprimido, protegendo contra
+	// "zip bombs"; quando zero, assume 10 MiB.
+	MaxSize int64
+}
+
+// Decompression registra globalmente a descompressão de corpos de requisição.
+// Para aplicar apenas a um grupo de rotas, utilize Decompress diretamente.
+func (s *Server) Decompression(opts DecompressionOptions) *Server {
+	return s.Middlewares(Decompress(opts))
+}
+
+// Decompress devolve um middleware que aceita corpos com Content-Encoding gzip
+// ou br, entregando ao handler o conteúdo descomprimido. Codificações não
+// suportadas e corpos gzip inválidos são rejeitados com 400; ao ultrapassar
+// MaxSize, a leitura falha com ErrBodyTooLarge, respondido com 413 por Handler
+// e BufferBody.
+func Decompress(opts DecompressionOptions) gin.HandlerFunc {
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = 10 << 20
+	}
+
+	return func(c *gin.Context) {
+		encoding := strings.ToLower(strings.TrimSpace(c.GetHeader("Content-Encoding")))
+		if encoding == "" || encoding == "identity" || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+
+		var reader io.Reader
+		switch encoding {
+		case "gzip", "x-gzip":
+			gz, err := gzip.NewReader(c.Request.Body)
+			if err != nil {
+				Fail(c, errx.New("invalid gzip request body").WithCode(errx.BAD_REQUEST))
+				return
+			}
+			reader = gz
+		case "br":
+			reader = brotli.NewReader(c.Request.Body)
+		default:
+			Fail(c, errx.New("unsupported content encoding").
+				WithCode(errx.BAD_REQUEST).
+				WithDetails(map[string]interface{}{"content_encoding": encoding}))
+			return
+		}
+
+		c.Request.Body = bodyReader{
+			Reader: &maxBytesReader{r: reader, max: opts.MaxSize},
+			Closer: c.Request.Body,
+		}
+		c.Request.Header.Del("Content-Encoding")
+		c.Request.Header.Del("Content-Length")
+		c.Request.ContentLength = -1
+
+		c.Next()
+	}
+}
+
+// maxBytesReader falha com ErrBodyTooLarge ao ultrapassar max bytes.
+type maxBytesReader struct {
+	r   io.Reader
+	n   int64
+	max int64
+}
+
+func (m *maxBytesReader) Read(p []byte) (int, error) {
+	if m.n >= m.max {
+		// Verifica se ainda há dados além do limite antes de falhar.
+		var one [1]byte
+		n, err := m.r.Read(one[:])
+		if n > 0 {
+			return 0, ErrBodyTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > m.max-m.n {
+		p = p[:m.max-m.n]
+	}
+	n, err := m.r.Read(p)
+	m.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// WithDeprecation marca as rotas montadas por m como descontinuadas até until.
+// Ver Deprecated.
+func (m RouteMount) WithDeprecation(until time.Time, link string) RouteMount {
+	return func(r gin.IRouter) {
+		m(r.Group("", Deprecated(until, link)))
+	}
+}
+
+// deprecationUsage contabiliza o uso diário de uma rota descontinuada.
+type deprecationUsage struct {
+	mu    sync.Mutex
+	day   string
+	count int64
+}
+
+// Deprecated devolve um middleware que sinaliza a descontinuação da rota com
+// os cabeçalhos Deprecation, Sunset (RFC 8594) e Link para a documentação da
+// migração, incrementando a métrica server.deprecated.request a cada uso. O
+// primeiro uso de cada dia gera um log com o total do último dia com chamadas,
+// para acompanhar a migração dos clientes sem poluir os logs.
+func Deprecated(until time.Time, link string) gin.HandlerFunc {
+	sunset := until.UTC().Format(http.TimeFormat)
+	var usage sync.Map
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		c.Header("Sunset", sunset)
+		if link != "" {
+			c.Header("Link", `<`+link+`>; rel="deprecation"`)
+		}
+
+		route := c.FullPath()
+		dd.Metrics().Incr("server.deprecated.request",
+			"route:"+route,
+			"method:"+c.Request.Method,
+		)
+
+		value, _ := usage.LoadOrStore(c.Request.Method+" "+route, &deprecationUsage{})
+		u := value.(*deprecationUsage)
+
+		today := time.Now().UTC().Format(time.DateOnly)
+		u.mu.Lock()
+		if u.day != today {
+			logx.Ctx(c.Request.Context()).Warn("deprecated route in use",
+				"method", c.Request.Method,
+				"route", route,
+				"sunset", until.UTC().Format(time.DateOnly),
+				"days_left", int(time.Until(until).Hours()/24),
+				"last_day", u.day,
+				"last_day_requests", u.count,
+			)
+			u.day, u.count = today, 0
+		}
+		u.count++
+		u.mu.Unlock()
+
+		c.Next()
+	}
+}
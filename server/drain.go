@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// drainKey é a chave do gin.Context onde o rastreador de conexões é disponibilizado.
+const drainKey = "server.drain"
+
+// defaultDrainTimeout é o prazo padrão para o encerramento das conexões de longa duração.
+const defaultDrainTimeout = 5 * time.Second
+
+// connTracker acompanha conexões de longa duração (WebSocket, SSE, long-poll)
+// para que sejam avisadas e aguardadas durante o encerramento.
+type connTracker struct {
+	mu       sync.Mutex
+	active   int
+	stopping bool
+	// draining é fechado quando o encerramento começa.
+	draining chan struct{}
+	// idle é fechado quando, já em encerramento, não resta conexão ativa.
+	idle chan struct{}
+}
+
+// newConnTracker cria um rastreador sem conexões ativas.
+func newConnTracker() *connTracker {
+	return &connTracker{draining: make(chan struct{}), idle: make(chan struct{})}
+}
+
+// add registra uma conexão, recusando-a quando o encerramento já começou.
+func (t *connTracker) add() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopping {
+		return false
+	}
+	t.active++
+	return true
+}
+
+// done encerra o registro de uma conexão.
+func (t *connTracker) done() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.active--
+	if t.stopping && t.active == 0 {
+		close(t.idle)
+	}
+}
+
+// start sinaliza a todas as conexões que o servidor está encerrando.
+func (t *connTracker) start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopping {
+		return
+	}
+	t.stopping = true
+	close(t.draining)
+	if t.active == 0 {
+		close(t.idle)
+	}
+}
+
+// wait aguarda o término das conexões rastreadas ou o cancelamento de ctx.
+func (t *connTracker) wait(ctx context.Context) {
+	select {
+	case <-t.idle:
+	case <-ctx.Done():
+	}
+}
+
+// DrainTimeout define quanto tempo o encerramento aguarda as conexões de
+// longa duração finalizarem após serem avisadas.
+func (s *Server) DrainTimeout(timeout time.Duration) *Server {
+	s.drainTimeout = timeout
+	return s
+}
+
+// LongLived registra a requisição atual como conexão de longa duração. O canal
+// devolvido é fechado quando o servidor inicia o encerramento, momento em que o
+// handler deve avisar o cliente (ex.: frame WebSocket 1001 "going away" ou evento
+// SSE final) e retornar. A função done deve ser chamada ao término do handler.
+// Durante o encerramento o registro é recusado e o canal devolvido já está fechado.
+func LongLived(c *gin.Context) (draining <-chan struct{}, done func()) {
+	value, ok := c.Get(drainKey)
+	tracker, _ := value.(*connTracker)
+	if !ok || tracker == nil {
+		return make(chan struct{}), func() {}
+	}
+
+	if !tracker.add() {
+		return tracker.draining, func() {}
+	}
+	var once sync.Once
+	return tracker.draining, func() { once.Do(tracker.done) }
+}
+
+// connTrackerMiddleware disponibiliza o rastreador de conexões aos handlers.
+func (s *Server) connTrackerMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(drainKey, s.conns)
+		c.Next()
+	}
+}
+
+// drainConnections avisa as conexões de longa duração e aguarda seu término até o prazo configurado.
+func (s *Server) drainConnections(ctx context.Context) {
+	s.conns.start()
+
+	timeout := s.drainTimeout
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+
+	drainCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s.conns.wait(drainCtx)
+}
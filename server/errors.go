@@ -0,0 +1,56 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// defaultErrorMessages são as mensagens usadas pelos handlers internos de erro.
+var defaultErrorMessages = map[errx.Code]string{
+	errx.NOT_FOUND:          "route not found",
+	errx.METHOD_NOT_ALLOWED: "method not allowed",
+	errx.INTERNAL:           "internal server error",
+}
+
+// ErrorMessage substitui a mensagem devolvida pelos handlers internos de
+// 404 (NOT_FOUND), 405 (METHOD_NOT_ALLOWED) e 500 (INTERNAL).
+func (s *Server) ErrorMessage(code errx.Code, message string) *Server {
+	if s.errorMsgs == nil {
+		s.errorMsgs = map[errx.Code]string{}
+	}
+	s.errorMsgs[code] = message
+	return s
+}
+
+// ErrorFormat define o formato das respostas de erro do serviço (ex.:
+// errx.LegacyEncoder para consumidores do payload {codigo, mensagem, detalhes}
+// ou errx.ProblemEncoder para RFC 7807). O formato vale para todo o processo.
+func (s *Server) ErrorFormat(enc errx.HTTPEncoder) *Server {
+	errx.SetHTTPEncoder(enc)
+	return s
+}
+
+// errorMessage devolve a mensagem configurada para o código ou a padrão.
+func (s *Server) errorMessage(code errx.Code) string {
+	if msg, ok := s.errorMsgs[code]; ok {
+		return msg
+	}
+	return defaultErrorMessages[code]
+}
+
+// notFoundHandler responde 404 no envelope padrão de erros.
+func (s *Server) notFoundHandler(c *gin.Context) {
+	Fail(c, errx.New(s.errorMessage(errx.NOT_FOUND)).WithCode(errx.NOT_FOUND))
+}
+
+// methodNotAllowedHandler responde 405 no envelope padrão de erros.
+func (s *Server) methodNotAllowedHandler(c *gin.Context) {
+	Fail(c, errx.New(s.errorMessage(errx.METHOD_NOT_ALLOWED)).WithCode(errx.METHOD_NOT_ALLOWED))
+}
+
+// recovery substitui o gin.Recovery, registrando o panic e respondendo 500 no envelope padrão.
+func (s *Server) recovery() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+		Fail(c, errx.New(s.errorMessage(errx.INTERNAL)).WithCode(errx.INTERNAL))
+	})
+}
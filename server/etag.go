@@ -0,0 +1,173 @@
+package server
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConditionalRequests registra globalmente o middleware de ETag.
+// Para aplicar apenas a um grupo de rotas, utilize ETag diretamente.
+func (s *Server) ConditionalRequests() *Server {
+	return s.Middlewares(ETag())
+}
+
+// ETag devolve um middleware que calcula ETags fracos para respostas JSON de
+// GET/HEAD e responde 304 quando o If-None-Match do cliente corresponde.
+func ETag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &etagWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		defer func() {
+			c.Writer = w.ResponseWriter
+			w.finish(c.GetHeader("If-None-Match"))
+		}()
+
+		c.Next()
+	}
+}
+
+// etagWriter acumula a resposta inteira para que o ETag possa ser calculado antes do envio.
+// Respostas que não recebem ETag (não JSON, streams ou status diferente de 200)
+// e as que chamam Flush passam a ser enviadas diretamente, sem acúmulo.
+type etagWriter struct {
+	gin.ResponseWriter
+	status      int
+	buf         []byte
+	passthrough bool
+}
+
+// WriteHeader adia o envio do status até o cálculo do ETag.
+func (w *etagWriter) WriteHeader(code int) {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+// WriteHeaderNow é ignorado, pois o envio só ocorre ao final do handler.
+func (w *etagWriter) WriteHeaderNow() {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// Status devolve o status definido pelo handler.
+func (w *etagWriter) Status() int {
+	if w.status != 0 && !w.passthrough {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+// Written informa se o handler já produziu alguma saída.
+func (w *etagWriter) Written() bool {
+	if w.passthrough {
+		return w.ResponseWriter.Written()
+	}
+	return len(w.buf) > 0 || w.status != 0
+}
+
+// Size devolve o número de bytes produzidos pelo handler.
+func (w *etagWriter) Size() int {
+	if w.passthrough {
+		return w.ResponseWriter.Size()
+	}
+	return len(w.buf)
+}
+
+// Write acumula os bytes da resposta.
+func (w *etagWriter) Write(data []byte) (int, error) {
+	if !w.passthrough && !w.taggable() {
+		w.release()
+	}
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	w.buf = append(w.buf, data...)
+	return len(data), nil
+}
+
+// WriteString implementa gin.ResponseWriter delegando para Write.
+func (w *etagWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush envia o que foi acumulado e passa a escrever diretamente: quem faz
+// flush está transmitindo a resposta aos poucos, que fica sem ETag.
+func (w *etagWriter) Flush() {
+	w.release()
+	w.ResponseWriter.Flush()
+}
+
+// taggable informa se a resposta é elegível ao ETag: 200 com corpo JSON completo.
+func (w *etagWriter) taggable() bool {
+	contentType := w.Header().Get("Content-Type")
+	return w.Status() == http.StatusOK &&
+		strings.Contains(contentType, "json") &&
+		!strings.Contains(contentType, "ndjson") &&
+		!strings.HasPrefix(contentType, "text/event-stream") &&
+		w.Header().Get("ETag") == ""
+}
+
+// release envia o status e os bytes acumulados e desliga o acúmulo.
+func (w *etagWriter) release() {
+	if w.passthrough {
+		return
+	}
+	w.passthrough = true
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	if len(w.buf) > 0 {
+		_, _ = w.ResponseWriter.Write(w.buf)
+		w.buf = nil
+	}
+}
+
+// finish calcula o ETag, quando aplicável, e envia a resposta ou um 304.
+func (w *etagWriter) finish(ifNoneMatch string) {
+	if w.passthrough {
+		return
+	}
+
+	if w.taggable() {
+		h := fnv.New64a()
+		_, _ = h.Write(w.buf)
+		tag := fmt.Sprintf(`W/"%x"`, h.Sum64())
+		w.Header().Set("ETag", tag)
+
+		if etagMatches(ifNoneMatch, tag) {
+			w.Header().Del("Content-Length")
+			w.Header().Del("Content-Type")
+			w.ResponseWriter.WriteHeader(http.StatusNotModified)
+			w.ResponseWriter.WriteHeaderNow()
+			return
+		}
+	}
+
+	w.release()
+}
+
+// etagMatches aplica a comparação fraca do If-None-Match (RFC 9110).
+func etagMatches(header, tag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(tag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// healthCheckTimeout limita a duração de cada verificação do /healthcheck.
+const healthCheckTimeout = 5 * time.Second
+
+// healthCheck é uma dependência verificada pelo endpoint /healthcheck.
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// HealthCheck registra uma verificação executada pelo endpoint /healthcheck
+// (ex.: o ping do banco de dados). Se alguma falhar, o endpoint responde 503
+// com "unavailable" na verificação; o erro, que pode conter hosts e usuários,
+// vai apenas para o log.
+func (s *Server) HealthCheck(name string, check func(ctx context.Context) error) *Server {
+	s.healthChecks = append(s.healthChecks, healthCheck{name: name, check: check})
+	return s
+}
+
+// runHealthChecks executa as verificações em paralelo e devolve o resultado de cada uma.
+func (s *Server) runHealthChecks(ctx context.Context) (map[string]string, bool) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]string, len(s.healthChecks))
+		healthy = true
+	)
+	for _, hc := range s.healthChecks {
+		wg.Add(1)
+		go func(hc healthCheck) {
+			defer wg.Done()
+			err := hc.check(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				logx.Ctx(ctx).Error("health check failed", "check", hc.name, "error", err)
+				results[hc.name] = "unavailable"
+				healthy = false
+				return
+			}
+			results[hc.name] = "ok"
+		}(hc)
+	}
+	wg.Wait()
+	return results, healthy
+}
+
+// addHealthCheck registra o endpoint padrão de verificação de saúde da aplicação.
+func (s *Server) addHealthCheck() {
+	s.gin.GET("/healthcheck", func(c *gin.Context) {
+		if len(s.healthChecks) == 0 {
+			c.JSON(http.StatusOK, gin.H{"status": "ok"})
+			return
+		}
+
+		results, healthy := s.runHealthChecks(c.Request.Context())
+		if !healthy {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "fail", "checks": results})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "checks": results})
+	})
+}
@@ -0,0 +1,37 @@
+package server
+
+import "context"
+
+// userCtxKey é o tipo da chave do usuário autenticado no context.Context da requisição.
+type userCtxKey struct{}
+
+// subjectCtxKey é o tipo da chave do cliente autenticado no context.Context da requisição.
+type subjectCtxKey struct{}
+
+// WithSubject devolve um contexto que carrega o identificador do cliente
+// autenticado (ex.: o subject do token, preenchido pelo auth.Middleware).
+func WithSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, subjectCtxKey{}, subject)
+}
+
+// SubjectFromContext devolve o cliente autenticado da requisição, ou vazio.
+func SubjectFromContext(ctx context.Context) string {
+	subject, _ := ctx.Value(subjectCtxKey{}).(string)
+	return subject
+}
+
+// WithUser devolve um contexto que carrega o usuário autenticado, para que as
+// camadas de serviço e repositório o leiam sem depender do gin.Context.
+//
+//	ctx = server.WithUser(ctx, user)
+func WithUser[U any](ctx context.Context, user U) context.Context {
+	return context.WithValue(ctx, userCtxKey{}, user)
+}
+
+// User devolve o usuário do contexto, se houver e for do tipo U.
+//
+//	user, ok := server.User[*User](ctx)
+func User[U any](ctx context.Context) (U, bool) {
+	user, ok := ctx.Value(userCtxKey{}).(U)
+	return user, ok
+}
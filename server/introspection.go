@@ -0,0 +1,105 @@
+package server
+
+import (
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouteInfo descreve uma rota registrada no engine.
+type RouteInfo struct {
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	Handler     string   `json:"handler"`
+	Middlewares []string `json:"middlewares"`
+}
+
+// routeChains guarda a cadeia completa de handlers observada para cada rota.
+// O Gin não expõe a cadeia por rota após o registro, então ela é capturada
+// na primeira requisição atendida; antes disso, apenas os middlewares globais são listados.
+type routeChains struct {
+	chains sync.Map
+}
+
+// observe registra a cadeia de handlers da rota atual, se ainda não conhecida.
+func (r *routeChains) observe() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if route := c.FullPath(); route != "" {
+			key := c.Request.Method + " " + route
+			if _, ok := r.chains.Load(key); !ok {
+				r.chains.Store(key, c.HandlerNames())
+			}
+		}
+		c.Next()
+	}
+}
+
+// RegisteredRoutes lista as rotas registradas, com métodos, handler e cadeia
+// de middlewares. Só está disponível após o engine ser montado por Run ou Handler.
+func (s *Server) RegisteredRoutes() []RouteInfo {
+	if s.gin == nil {
+		return nil
+	}
+
+	global := make([]string, 0, len(s.gin.Handlers))
+	for _, h := range s.gin.Handlers {
+		global = append(global, nameOfFunction(h))
+	}
+
+	routes := make([]RouteInfo, 0)
+	for _, r := range s.gin.Routes() {
+		middlewares := global
+		if chain, ok := s.chains.chains.Load(r.Method + " " + r.Path); ok {
+			names := chain.([]string)
+			middlewares = names[:len(names)-1]
+		}
+
+		routes = append(routes, RouteInfo{
+			Method:      r.Method,
+			Path:        r.Path,
+			Handler:     r.Handler,
+			Middlewares: middlewares,
+		})
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path == routes[j].Path {
+			return routes[i].Method < routes[j].Method
+		}
+		return routes[i].Path < routes[j].Path
+	})
+	return routes
+}
+
+// addRoutesAdmin registra GET /admin/routes e as rotas de AdminRoutes quando o
+// token administrativo está configurado.
+func (s *Server) addRoutesAdmin() {
+	if s.adminToken == "" {
+		return
+	}
+
+	s.gin.GET("/admin/routes", s.adminAuth(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"routes": s.RegisteredRoutes()})
+	})
+
+	admin := s.gin.Group("/admin", s.adminAuth())
+	for _, mount := range s.adminRoutes {
+		mount(admin)
+	}
+}
+
+// AdminRoutes registra rotas adicionais sob /admin, protegidas pelo token
+// administrativo. Sem AdminToken, as rotas não são publicadas.
+func (s *Server) AdminRoutes(mounts ...RouteMount) *Server {
+	s.adminRoutes = append(s.adminRoutes, mounts...)
+	return s
+}
+
+// nameOfFunction devolve o nome qualificado de um handler.
+func nameOfFunction(f interface{}) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// IPFilterOptions define as faixas de IP permitidas e bloqueadas.
+// Entradas aceitam CIDR (ex.: "10.0.0.0/8") ou IP único.
+type IPFilterOptions struct {
+	// Allow restringe o acesso às faixas informadas; vazio permite qualquer origem.
+	Allow []string
+	// Deny bloqueia as faixas informadas e tem precedência sobre Allow.
+	Deny []string
+}
+
+// TrustedProxies define os proxies (IPs ou CIDRs) cujos cabeçalhos
+// X-Forwarded-For/X-Real-IP são considerados para obter o IP do cliente.
+// Sem essa configuração nenhum proxy é confiável e o IP do cliente é o
+// endereço da conexão, para que IPFilter não seja contornado por um
+// X-Forwarded-For forjado. Uma entrada inválida impede o servidor de iniciar.
+func (s *Server) TrustedProxies(proxies ...string) *Server {
+	s.trustedProxies = proxies
+	return s
+}
+
+// applyTrustedProxies aplica a lista de proxies confiáveis ao engine.
+func (s *Server) applyTrustedProxies() error {
+	if err := s.gin.SetTrustedProxies(s.trustedProxies); err != nil {
+		return fmt.Errorf("server: invalid trusted proxies: %w", err)
+	}
+	return nil
+}
+
+// IPFilter devolve um middleware que responde 403 para clientes fora das faixas
+// permitidas ou dentro das bloqueadas. O IP é obtido por c.ClientIP(), portanto
+// depende de TrustedProxies estar corretamente configurado atrás de balanceadores.
+func IPFilter(opts IPFilterOptions) (gin.HandlerFunc, error) {
+	allow, err := parseNetworks(opts.Allow)
+	if err != nil {
+		return nil, err
+	}
+	deny, err := parseNetworks(opts.Deny)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		ip := net.ParseIP(c.ClientIP())
+		if ip == nil || matchesAny(deny, ip) || (len(allow) > 0 && !matchesAny(allow, ip)) {
+			Fail(c, errx.New("access denied for client address").
+				WithCode(errx.FORBIDDEN).
+				WithDetails(map[string]interface{}{"client_ip": c.ClientIP()}))
+			return
+		}
+		c.Next()
+	}, nil
+}
+
+// parseNetworks converte IPs e CIDRs em redes, tratando IPs únicos como /32 ou /128.
+func parseNetworks(entries []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, errx.New("invalid IP address").WithCode(errx.INTERNAL).
+					WithDetails(map[string]interface{}{"entry": entry})
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, errx.New("invalid CIDR").WithCode(errx.INTERNAL).WithError(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// matchesAny informa se ip pertence a alguma das redes.
+func matchesAny(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
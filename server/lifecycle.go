@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// defaultShutdownTimeout é o tempo máximo padrão para o encerramento gracioso.
+const defaultShutdownTimeout = 10 * time.Second
+
+// Hook é uma função executada no início ou no encerramento do servidor.
+type Hook func(ctx context.Context) error
+
+// OnStart registra hooks executados, na ordem de registro, antes de o servidor
+// começar a aceitar conexões. Um erro em qualquer hook impede a inicialização.
+func (s *Server) OnStart(hooks ...Hook) *Server {
+	s.startHooks = append(s.startHooks, hooks...)
+	return s
+}
+
+// OnStop registra hooks executados após o encerramento gracioso do servidor,
+// na ordem inversa de registro (o último registrado é o primeiro executado).
+func (s *Server) OnStop(hooks ...Hook) *Server {
+	s.stopHooks = append(s.stopHooks, hooks...)
+	return s
+}
+
+// ShutdownTimeout define quanto tempo o servidor aguarda as requisições em
+// andamento e os hooks de parada durante o encerramento.
+func (s *Server) ShutdownTimeout(timeout time.Duration) *Server {
+	s.shutdownTimeout = timeout
+	return s
+}
+
+// runStartHooks executa os hooks de início em ordem, interrompendo no primeiro erro.
+func (s *Server) runStartHooks(ctx context.Context) error {
+	for _, hook := range s.startHooks {
+		if err := hook(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runStopHooks executa todos os hooks de parada em ordem inversa, registrando os erros.
+func (s *Server) runStopHooks(ctx context.Context) {
+	for i := len(s.stopHooks) - 1; i >= 0; i-- {
+		if err := s.stopHooks[i](ctx); err != nil {
+			logx.L().Error("Stop hook failed", "error", err)
+		}
+	}
+}
+
+// shutdown retira a instância do balanceamento, avisa e aguarda as conexões de
+// longa duração, encerra o servidor HTTP (e os servidores adicionais) aguardando
+// as requisições em andamento e executa os hooks de parada.
+func (s *Server) shutdown(srv *http.Server, extra ...func(context.Context) error) {
+	s.SetReady(false)
+
+	timeout := s.shutdownTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	s.drainConnections(ctx)
+
+	if err := srv.Shutdown(ctx); err != nil {
+		logx.L().Error("Failed to shutdown server", "error", err)
+	}
+	for _, fn := range extra {
+		if err := fn(ctx); err != nil {
+			logx.L().Error("Failed to shutdown server", "error", err)
+		}
+	}
+
+	s.runStopHooks(ctx)
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+)
+
+// Listener descreve um endereço em que o servidor aceita conexões.
+type Listener struct {
+	// Network é "tcp" (padrão) ou "unix".
+	Network string
+	// Address é o endereço TCP (ex.: ":8443") ou o caminho do socket Unix.
+	Address string
+	// TLS habilita HTTPS neste listener com a configuração informada.
+	TLS *tls.Config
+	// CertFile e KeyFile habilitam HTTPS carregando o certificado do disco.
+	CertFile string
+	KeyFile  string
+	// ReusePort habilita SO_REUSEPORT, permitindo que um novo processo faça bind
+	// na mesma porta enquanto o anterior é drenado (apenas sistemas Unix).
+	ReusePort bool
+}
+
+// Listen registra listeners adicionais (TCP, HTTPS ou socket Unix) que servem
+// o mesmo engine simultaneamente, cada um com suas próprias configurações de TLS.
+func (s *Server) Listen(listeners ...Listener) *Server {
+	s.listeners = append(s.listeners, listeners...)
+	return s
+}
+
+// open cria o net.Listener correspondente, aplicando TLS quando configurado.
+// Quando inherited não é nil, o socket herdado do processo anterior é reaproveitado
+// em vez de um novo bind. Devolve o listener bruto (sem TLS) e o listener servido.
+func (l Listener) open(inherited net.Listener) (raw net.Listener, served net.Listener, err error) {
+	network := l.Network
+	if network == "" {
+		network = "tcp"
+	}
+
+	tlsConfig, err := l.tlsConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw = inherited
+	if raw == nil {
+		if network == "unix" {
+			// Remove um socket órfão de uma execução anterior.
+			if info, err := os.Stat(l.Address); err == nil && info.Mode()&fs.ModeSocket != 0 {
+				_ = os.Remove(l.Address)
+			}
+		}
+
+		lc := net.ListenConfig{}
+		if l.ReusePort {
+			lc.Control = reusePortControl
+		}
+
+		raw, err = lc.Listen(context.Background(), network, l.Address)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	served = raw
+	if tlsConfig != nil {
+		served = tls.NewListener(raw, tlsConfig)
+	}
+	return raw, served, nil
+}
+
+// tlsConfig devolve a configuração TLS do listener ou nil quando ele é texto puro.
+func (l Listener) tlsConfig() (*tls.Config, error) {
+	var cfg *tls.Config
+	if l.TLS != nil {
+		cfg = l.TLS.Clone()
+	}
+
+	if l.CertFile != "" || l.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(l.CertFile, l.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("listener %s: %w", l.Address, err)
+		}
+		if cfg == nil {
+			cfg = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		cfg.Certificates = append(cfg.Certificates, cert)
+	}
+
+	if cfg == nil {
+		return nil, nil
+	}
+
+	if len(cfg.Certificates) == 0 && cfg.GetCertificate == nil {
+		return nil, fmt.Errorf("listener %s: TLS enabled without certificates", l.Address)
+	}
+	if len(cfg.NextProtos) == 0 {
+		cfg.NextProtos = []string{"h2", "http/1.1"}
+	}
+	return cfg, nil
+}
+
+// openListeners abre todos os listeners, reaproveitando os sockets herdados de
+// um restart gracioso quando disponíveis, e fecha os já abertos em caso de falha.
+// Devolve os listeners servidos e os respectivos listeners brutos.
+func openListeners(listeners []Listener) (served []net.Listener, raw []net.Listener, err error) {
+	if len(listeners) == 0 {
+		return nil, nil, errors.New("no listener configured")
+	}
+
+	inherited, err := inheritedListeners()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(inherited) > 0 && len(inherited) != len(listeners) {
+		closeListeners(inherited)
+		return nil, nil, fmt.Errorf("inherited %d listeners, expected %d", len(inherited), len(listeners))
+	}
+
+	for i, l := range listeners {
+		var from net.Listener
+		if len(inherited) > 0 {
+			from = inherited[i]
+		}
+
+		r, ln, err := l.open(from)
+		if err != nil {
+			closeListeners(served)
+			closeListeners(inherited[min(i, len(inherited)):])
+			return nil, nil, err
+		}
+		raw = append(raw, r)
+		served = append(served, ln)
+	}
+	return served, raw, nil
+}
+
+// closeListeners fecha os listeners informados, ignorando erros.
+func closeListeners(listeners []net.Listener) {
+	for _, ln := range listeners {
+		_ = ln.Close()
+	}
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"golang.org/x/text/language"
+)
+
+// localeKey é a chave usada para armazenar o locale no gin.Context e no context.Context.
+const localeKey = "server.locale"
+
+// localeCtxKey é o tipo da chave do locale no context.Context da requisição.
+type localeCtxKey struct{}
+
+func init() {
+	// As respostas de erro de errx.AbortWithError usam o locale resolvido aqui.
+	errx.SetGinLocale(GetLocale)
+}
+
+// LocaleOptions configura a resolução do locale da requisição.
+type LocaleOptions struct {
+	// Supported lista os locales aceitos (ex.: "pt-BR", "en", "es"); o primeiro é o padrão.
+	Supported []string
+	// QueryParam é o parâmetro de query que força o locale; quando vazio, assume "lang".
+	QueryParam string
+	// Header é o cabeçalho customizado que força o locale; quando vazio, assume "X-Locale".
+	Header string
+}
+
+// Locale registra globalmente o middleware de resolução de locale.
+func (s *Server) Locale(opts LocaleOptions) *Server {
+	return s.Middlewares(ResolveLocale(opts))
+}
+
+// ResolveLocale devolve um middleware que resolve o locale da requisição, na
+// ordem: query string, cabeçalho customizado e Accept-Language, usando o
+// primeiro locale suportado como padrão. O valor é disponibilizado por GetLocale
+// e LocaleFromContext e usado por Fail para traduzir mensagens do errx.
+func ResolveLocale(opts LocaleOptions) gin.HandlerFunc {
+	if len(opts.Supported) == 0 {
+		opts.Supported = []string{"pt-BR"}
+	}
+	if opts.QueryParam == "" {
+		opts.QueryParam = "lang"
+	}
+	if opts.Header == "" {
+		opts.Header = "X-Locale"
+	}
+
+	tags := make([]language.Tag, 0, len(opts.Supported))
+	for _, s := range opts.Supported {
+		tags = append(tags, language.Make(s))
+	}
+	matcher := language.NewMatcher(tags)
+
+	return func(c *gin.Context) {
+		locale := opts.Supported[0]
+
+		candidates := []string{c.Query(opts.QueryParam), c.GetHeader(opts.Header)}
+		resolved := false
+		for _, candidate := range candidates {
+			if candidate == "" {
+				continue
+			}
+			if _, idx, conf := matcher.Match(language.Make(candidate)); conf != language.No {
+				locale = opts.Supported[idx]
+				resolved = true
+				break
+			}
+		}
+
+		if !resolved {
+			if accepted, _, err := language.ParseAcceptLanguage(c.GetHeader("Accept-Language")); err == nil && len(accepted) > 0 {
+				if _, idx, conf := matcher.Match(accepted...); conf != language.No {
+					locale = opts.Supported[idx]
+				}
+			}
+		}
+
+		c.Set(localeKey, locale)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), localeCtxKey{}, locale))
+		c.Header("Content-Language", locale)
+
+		c.Next()
+	}
+}
+
+// GetLocale devolve o locale resolvido para a requisição ou vazio se o middleware não estiver ativo.
+func GetLocale(c *gin.Context) string {
+	return c.GetString(localeKey)
+}
+
+// LocaleFromContext devolve o locale armazenado no context.Context da requisição.
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeCtxKey{}).(string)
+	return locale
+}
@@ -1,17 +1,102 @@
 package server
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/idgen"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
 )
 
-func addLogger() gin.HandlerFunc {
+func addLogger(opts AccessLogOptions, mode string) gin.HandlerFunc {
+	base := opts.logger(mode)
+
 	return func(c *gin.Context) {
-		fmt.Printf("Request: %s %s\n", c.Request.Method, c.Request.URL.Path)
+		start := time.Now()
+
 		c.Next()
+
+		status := c.Writer.Status()
+		var err error
+		if last := c.Errors.Last(); last != nil {
+			err = last.Err
+		}
+
+		// Requisições abandonadas pelo cliente são registradas como 499 e
+		// CLIENT_CLOSED, em vez do 500 produzido pelo handler interrompido.
+		// Só quando o handler falhou pelo cancelamento ou nada foi respondido:
+		// uma resposta já enviada mantém o status, mesmo que o cliente tenha
+		// desconectado logo depois.
+		written := c.Writer.Written()
+		abandoned := errors.Is(c.Request.Context().Err(), context.Canceled) && !written
+		if abandoned || errors.Is(err, context.Canceled) {
+			if !written {
+				status = errx.ToHTTPCode(errx.CLIENT_CLOSED)
+			}
+			err = errx.New("request canceled by client").
+				WithCode(errx.CLIENT_CLOSED).
+				WithError(err).
+				WithOrigin(errx.ORIGIN_CLIENT).
+				WithAlert(false).
+				WithSLOImpact(false)
+		}
+
+		args := []any{
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", status,
+			"duration_ms", time.Since(start).Milliseconds(),
+		}
+
+		logger := logx.Ctx(c.Request.Context())
+		if base != nil {
+			logger = logx.With(base, c.Request.Context())
+		}
+		if err == nil {
+			logger.Info("request", accessLogFields(opts, c, args)...)
+			return
+		}
+
+		// Erros esperados (sem alerta) não devem poluir o nível de erro
+		// nem disparar monitores baseados em log.
+		alert, sloImpact := errx.ShouldAlert(err), errx.AffectsSLO(err)
+		args = append(args,
+			"error", err.Error(),
+			"error_code", errx.GetCode(err),
+			"error_origin", errx.GetOrigin(err),
+			"alert", alert,
+			"slo_impact", sloImpact,
+		)
+		dd.Metrics().Incr("server.request.error",
+			"code:"+string(errx.GetCode(err)),
+			"origin:"+string(errx.GetOrigin(err)),
+			"alert:"+strconv.FormatBool(alert),
+			"slo_impact:"+strconv.FormatBool(sloImpact),
+		)
+
+		args = accessLogFields(opts, c, args)
+		switch {
+		case alert:
+			logger.Error("request", args...)
+		case errx.GetCode(err) == errx.CLIENT_CLOSED:
+			logger.Info("request", args...)
+		default:
+			logger.Warn("request", args...)
+		}
+	}
+}
+
+// accessLogFields aplica a customização de campos do log de acesso, quando configurada.
+func accessLogFields(opts AccessLogOptions, c *gin.Context, args []any) []any {
+	if opts.Fields == nil {
+		return args
 	}
+	return opts.Fields(c, args)
 }
 
 func xItauCorrelationId() gin.HandlerFunc {
@@ -20,10 +105,11 @@ func xItauCorrelationId() gin.HandlerFunc {
 		id := c.GetHeader("x-itau-correlation-id")
 
 		if id == "" {
-			id = uuid.New().String()
+			id = idgen.UUIDv7()
 		}
 
 		c.Writer.Header().Set("x-itau-correlation-id", id)
+		c.Request = c.Request.WithContext(logx.WithCorrelationID(c.Request.Context(), id))
 
 		c.Next()
 	}
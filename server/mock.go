@@ -0,0 +1,264 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+)
+
+// mockConfig guarda as rotas do documento OpenAPI servidas em modo mock.
+type mockConfig struct {
+	routes []mockRoute
+	doc    map[string]interface{}
+	err    error
+}
+
+// mockRoute é uma operação do documento, com o path convertido em expressão regular.
+type mockRoute struct {
+	method  string
+	path    string
+	pattern *regexp.Regexp
+	op      map[string]interface{}
+}
+
+// specParam identifica parâmetros de path no formato OpenAPI ({id}).
+var specParam = regexp.MustCompile(`\\\{[^}]+\\\}`)
+
+// MockMode serve respostas de exemplo do documento OpenAPI (JSON ou YAML) para
+// as rotas ainda não implementadas, permitindo que o frontend integre antes do
+// backend. Rotas registradas têm precedência; o exemplo vem de "example",
+// "examples" ou é gerado a partir do schema. O cabeçalho X-Mock-Status escolhe
+// outra resposta documentada (ex.: 404) e as respostas recebem X-Mock: true.
+// Um documento inválido impede a inicialização do servidor em Run.
+func (s *Server) MockMode(spec []byte) *Server {
+	s.mock = parseMockSpec(spec)
+	return s
+}
+
+// parseMockSpec lê o documento e prepara as rotas em ordem de especificidade.
+func parseMockSpec(spec []byte) *mockConfig {
+	var raw interface{}
+	if err := yaml.Unmarshal(spec, &raw); err != nil {
+		return &mockConfig{err: fmt.Errorf("server: parse mock spec: %w", err)}
+	}
+	doc, _ := normalizeYAML(raw).(map[string]interface{})
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		return &mockConfig{err: fmt.Errorf("server: mock spec has no paths")}
+	}
+
+	mock := &mockConfig{doc: doc}
+	for path, item := range paths {
+		ops, _ := item.(map[string]interface{})
+		pattern := regexp.MustCompile("^" + specParam.ReplaceAllString(regexp.QuoteMeta(path), `[^/]+`) + "$")
+		for method, op := range ops {
+			if op, ok := op.(map[string]interface{}); ok {
+				mock.routes = append(mock.routes, mockRoute{
+					method:  strings.ToUpper(method),
+					path:    path,
+					pattern: pattern,
+					op:      op,
+				})
+			}
+		}
+	}
+	// Paths literais antes de paths com parâmetros (ex.: /users/me antes de /users/{id}).
+	sort.SliceStable(mock.routes, func(i, j int) bool {
+		return strings.Count(mock.routes[i].path, "{") < strings.Count(mock.routes[j].path, "{")
+	})
+	return mock
+}
+
+// normalizeYAML converte mapas com chaves não textuais (ex.: códigos de status
+// sem aspas no YAML) em map[string]interface{}.
+func normalizeYAML(node interface{}) interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = normalizeYAML(value)
+		}
+		return v
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			out[fmt.Sprint(key)] = normalizeYAML(value)
+		}
+		return out
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalizeYAML(value)
+		}
+		return v
+	}
+	return node
+}
+
+// handler responde com o exemplo da operação correspondente ou segue para o próximo handler.
+func (m *mockConfig) handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, route := range m.routes {
+			if route.method != c.Request.Method || !route.pattern.MatchString(c.Request.URL.Path) {
+				continue
+			}
+
+			status, response := m.response(route.op, c.GetHeader("X-Mock-Status"))
+			c.Header("X-Mock", "true")
+			if body, ok := m.example(response); ok {
+				c.JSON(status, body)
+			} else {
+				c.Status(status)
+			}
+			c.Abort()
+			return
+		}
+	}
+}
+
+// response escolhe a resposta pedida em X-Mock-Status ou a primeira 2xx documentada.
+func (m *mockConfig) response(op map[string]interface{}, wanted string) (int, map[string]interface{}) {
+	responses, _ := op["responses"].(map[string]interface{})
+	if r, ok := responses[wanted].(map[string]interface{}); ok {
+		status, _ := strconv.Atoi(wanted)
+		return status, r
+	}
+
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if status, err := strconv.Atoi(code); err == nil && status >= 200 && status < 300 {
+			r, _ := responses[code].(map[string]interface{})
+			return status, r
+		}
+	}
+	return http.StatusOK, nil
+}
+
+// example devolve o corpo de exemplo do conteúdo JSON da resposta.
+func (m *mockConfig) example(response map[string]interface{}) (interface{}, bool) {
+	content, _ := m.resolve(response["content"]).(map[string]interface{})
+	var media map[string]interface{}
+	for mediaType, value := range content {
+		if strings.Contains(mediaType, "json") {
+			media, _ = value.(map[string]interface{})
+			break
+		}
+	}
+	if media == nil {
+		return nil, false
+	}
+
+	if example, ok := media["example"]; ok {
+		return example, true
+	}
+	if examples, ok := media["examples"].(map[string]interface{}); ok {
+		names := make([]string, 0, len(examples))
+		for name := range examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if example, ok := m.resolve(examples[name]).(map[string]interface{}); ok {
+				if value, ok := example["value"]; ok {
+					return value, true
+				}
+			}
+		}
+	}
+	if schema, ok := media["schema"]; ok {
+		return m.generate(schema, 0), true
+	}
+	return nil, false
+}
+
+// generate cria um valor de exemplo a partir do schema, limitando a profundidade em schemas recursivos.
+func (m *mockConfig) generate(node interface{}, depth int) interface{} {
+	schema, _ := m.resolve(node).(map[string]interface{})
+	if schema == nil || depth > 8 {
+		return nil
+	}
+	if example, ok := schema["example"]; ok {
+		return example
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+	for _, key := range []string{"allOf", "oneOf", "anyOf"} {
+		if list, ok := schema[key].([]interface{}); ok && len(list) > 0 {
+			if key != "allOf" {
+				return m.generate(list[0], depth+1)
+			}
+			merged := map[string]interface{}{}
+			for _, part := range list {
+				if obj, ok := m.generate(part, depth+1).(map[string]interface{}); ok {
+					for k, v := range obj {
+						merged[k] = v
+					}
+				}
+			}
+			return merged
+		}
+	}
+
+	switch schema["type"] {
+	case "object", nil:
+		obj := map[string]interface{}{}
+		props, _ := schema["properties"].(map[string]interface{})
+		for name, prop := range props {
+			obj[name] = m.generate(prop, depth+1)
+		}
+		return obj
+	case "array":
+		return []interface{}{m.generate(schema["items"], depth+1)}
+	case "integer":
+		return 0
+	case "number":
+		return 0.0
+	case "boolean":
+		return true
+	case "string":
+		switch schema["format"] {
+		case "date-time":
+			return time.Now().UTC().Format(time.RFC3339)
+		case "date":
+			return time.Now().UTC().Format(time.DateOnly)
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "email":
+			return "user@example.com"
+		}
+		return "string"
+	}
+	return nil
+}
+
+// resolve segue referências locais ($ref: "#/components/...") do documento.
+func (m *mockConfig) resolve(node interface{}) interface{} {
+	for i := 0; i < 16; i++ {
+		obj, ok := node.(map[string]interface{})
+		if !ok {
+			return node
+		}
+		ref, ok := obj["$ref"].(string)
+		if !ok {
+			return node
+		}
+		var target interface{} = m.doc
+		for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			part = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+			parent, _ := target.(map[string]interface{})
+			target = parent[part]
+		}
+		node = target
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+package server
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pathParam identifica parâmetros de rota do Gin (:id e *path).
+var pathParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// OpenAPIInfo descreve os metadados gerais do documento OpenAPI.
+type OpenAPIInfo struct {
+	Title       string
+	Version     string
+	Description string
+}
+
+// Operation descreve uma rota para fins de documentação OpenAPI.
+type Operation struct {
+	// Summary é o resumo curto exibido na listagem de rotas.
+	Summary string
+	// Description é a descrição detalhada da operação.
+	Description string
+	// Tags agrupam operações relacionadas no Swagger UI.
+	Tags []string
+	// Request é um valor de exemplo do corpo de entrada (ex.: CreateUser{}).
+	Request interface{}
+	// Response é um valor de exemplo do corpo de resposta de sucesso.
+	Response interface{}
+	// Status é o status HTTP de sucesso; quando zero, assume 200.
+	Status int
+	// Query é um valor de exemplo cujos campos com tag `form` viram query params.
+	Query interface{}
+}
+
+// OpenAPI captura as rotas registradas através dele e produz um documento OpenAPI 3.
+type OpenAPI struct {
+	mu    sync.RWMutex
+	info  OpenAPIInfo
+	paths map[string]map[string]interface{}
+}
+
+// NewOpenAPI cria um registro vazio de documentação OpenAPI.
+func NewOpenAPI(info OpenAPIInfo) *OpenAPI {
+	return &OpenAPI{
+		info:  info,
+		paths: map[string]map[string]interface{}{},
+	}
+}
+
+// Route registra a rota no router informado e captura seus metadados para o documento.
+func (o *OpenAPI) Route(r gin.IRouter, method, path string, op Operation, handlers ...gin.HandlerFunc) gin.IRoutes {
+	full := joinPath(basePath(r), path)
+	o.add(method, full, op)
+	return r.Handle(method, path, handlers...)
+}
+
+// Document devolve o documento OpenAPI 3 pronto para serialização.
+func (o *OpenAPI) Document() map[string]interface{} {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	paths := make(map[string]interface{}, len(o.paths))
+	for path, ops := range o.paths {
+		paths[path] = ops
+	}
+
+	info := map[string]interface{}{
+		"title":   o.info.Title,
+		"version": o.info.Version,
+	}
+	if o.info.Description != "" {
+		info["description"] = o.info.Description
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info":    info,
+		"paths":   paths,
+	}
+}
+
+// add converte a operação para o formato OpenAPI e a armazena no registro.
+func (o *OpenAPI) add(method, path string, op Operation) {
+	oasPath := pathParam.ReplaceAllString(path, "{$1}")
+
+	item := map[string]interface{}{}
+	if op.Summary != "" {
+		item["summary"] = op.Summary
+	}
+	if op.Description != "" {
+		item["description"] = op.Description
+	}
+	if len(op.Tags) > 0 {
+		item["tags"] = op.Tags
+	}
+
+	params := []interface{}{}
+	for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+		params = append(params, map[string]interface{}{
+			"name":     m[1],
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string"},
+		})
+	}
+	params = append(params, queryParams(op.Query)...)
+	if len(params) > 0 {
+		item["parameters"] = params
+	}
+
+	if op.Request != nil {
+		item["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": schemaOf(reflect.TypeOf(op.Request), nil)},
+			},
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	response := map[string]interface{}{"description": http.StatusText(status)}
+	if op.Response != nil {
+		response["content"] = map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schemaOf(reflect.TypeOf(op.Response), nil)},
+		}
+	}
+	item["responses"] = map[string]interface{}{strconv.Itoa(status): response}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.paths[oasPath] == nil {
+		o.paths[oasPath] = map[string]interface{}{}
+	}
+	o.paths[oasPath][strings.ToLower(method)] = item
+}
+
+// queryParams gera parâmetros de query a partir dos campos com tag `form` de v.
+func queryParams(v interface{}) []interface{} {
+	if v == nil {
+		return nil
+	}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	params := []interface{}{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("form"), ",")[0]
+		if name == "" || name == "-" || !f.IsExported() {
+			continue
+		}
+		params = append(params, map[string]interface{}{
+			"name":     name,
+			"in":       "query",
+			"required": strings.Contains(f.Tag.Get("binding"), "required"),
+			"schema":   schemaOf(f.Type, nil),
+		})
+	}
+	return params
+}
+
+// schemaOf gera um JSON Schema compatível com OpenAPI a partir de um tipo Go,
+// respeitando as tags `json` e evitando recursão infinita em tipos cíclicos.
+func schemaOf(t reflect.Type, seen map[reflect.Type]bool) map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{}
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": schemaOf(t.Elem(), seen)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaOf(t.Elem(), seen)}
+	case reflect.Struct:
+		if seen == nil {
+			seen = map[reflect.Type]bool{}
+		}
+		if seen[t] {
+			return map[string]interface{}{"type": "object"}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		props := map[string]interface{}{}
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			tag := strings.Split(f.Tag.Get("json"), ",")
+			name := tag[0]
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			props[name] = schemaOf(f.Type, seen)
+			if strings.Contains(f.Tag.Get("binding"), "required") {
+				required = append(required, name)
+			}
+		}
+
+		schema := map[string]interface{}{"type": "object", "properties": props}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// basePath devolve o prefixo de um router do Gin, quando disponível.
+func basePath(r gin.IRouter) string {
+	if g, ok := r.(interface{ BasePath() string }); ok {
+		return g.BasePath()
+	}
+	return "/"
+}
+
+// joinPath concatena o prefixo do grupo ao caminho relativo da rota.
+func joinPath(base, path string) string {
+	if path == "" {
+		return base
+	}
+	return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// swaggerUI é a página mínima do Swagger UI apontando para /openapi.json.
+const swaggerUI = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8"/>
+  <title>Swagger UI</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css"/>
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});</script>
+</body>
+</html>`
+
+// addOpenAPI expõe o documento em /openapi.json e, opcionalmente, o Swagger UI em /docs.
+func (s *Server) addOpenAPI() {
+	if s.openapi == nil {
+		return
+	}
+
+	s.gin.GET("/openapi.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, s.openapi.Document())
+	})
+
+	if s.swaggerUI {
+		s.gin.GET("/docs", func(c *gin.Context) {
+			c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUI))
+		})
+	}
+}
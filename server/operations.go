@@ -0,0 +1,297 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/idgen"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// OperationStatus é a situação de uma operação assíncrona.
+type OperationStatus string
+
+const (
+	OperationPending   OperationStatus = "pending"
+	OperationRunning   OperationStatus = "running"
+	OperationSucceeded OperationStatus = "succeeded"
+	OperationFailed    OperationStatus = "failed"
+)
+
+// AsyncOperation é o estado de uma operação assíncrona exposto na rota de status.
+type AsyncOperation struct {
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	Owner     string           `json:"owner,omitempty"`
+	Status    OperationStatus  `json:"status"`
+	Progress  int              `json:"progress"`
+	Result    json.RawMessage  `json:"result,omitempty"`
+	Error     *errx.ShowLogger `json:"error,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// Done informa se a operação terminou, com sucesso ou falha.
+func (o AsyncOperation) Done() bool {
+	return o.Status == OperationSucceeded || o.Status == OperationFailed
+}
+
+// OperationStore persiste o estado das operações; use um store compartilhado
+// (ex.: Redis ou banco) quando houver mais de uma instância do serviço.
+type OperationStore interface {
+	Save(ctx context.Context, op AsyncOperation) error
+	Get(ctx context.Context, id string) (AsyncOperation, bool, error)
+}
+
+// OperationFunc executa o trabalho da operação, reportando o progresso (0 a
+// 100) e devolvendo o resultado, serializado em JSON na rota de status.
+type OperationFunc func(ctx context.Context, progress func(percent int)) (any, error)
+
+// OperationsOptions configura o subsistema de operações assíncronas.
+type OperationsOptions struct {
+	// Store guarda as operações; quando nil, usa um store em memória.
+	Store OperationStore
+	// Path é a rota de status; quando vazio, assume "/operations".
+	Path string
+	// Timeout limita a duração de cada operação; quando zero, não há limite.
+	Timeout time.Duration
+	// Owner identifica o dono da requisição; a operação guarda o dono de quem a
+	// iniciou e a rota de status responde 404 a qualquer outro. Quando nil, usa
+	// o cliente autenticado (SubjectFromContext).
+	Owner func(c *gin.Context) string
+}
+
+// Operations executa operações em segundo plano no padrão 202 Accepted: o
+// handler devolve o ID da operação e o cliente acompanha o progresso e o
+// resultado pela rota de status.
+type Operations struct {
+	opts    OperationsOptions
+	running sync.WaitGroup
+	// location é a rota de status completa, com o prefixo do grupo onde foi montada.
+	location atomic.Pointer[string]
+}
+
+// NewOperations cria o subsistema de operações assíncronas.
+func NewOperations(opts OperationsOptions) *Operations {
+	if opts.Store == nil {
+		opts.Store = NewMemoryOperationStore(24 * time.Hour)
+	}
+	if opts.Path == "" {
+		opts.Path = "/operations"
+	}
+	opts.Path = "/" + strings.Trim(opts.Path, "/")
+	if opts.Owner == nil {
+		opts.Owner = func(c *gin.Context) string { return SubjectFromContext(c.Request.Context()) }
+	}
+	o := &Operations{opts: opts}
+	o.location.Store(&opts.Path)
+	return o
+}
+
+// Operations monta a rota de status e aguarda as operações em andamento no
+// encerramento do servidor.
+func (s *Server) Operations(ops *Operations) *Server {
+	return s.Routes(ops.Routes()).OnStop(ops.Stop)
+}
+
+// Routes devolve a montagem da rota GET <Path>/:id; o cabeçalho Location de
+// Start inclui o prefixo do grupo em que a rota foi montada.
+func (o *Operations) Routes() RouteMount {
+	return func(r gin.IRouter) {
+		if g, ok := r.(interface{ BasePath() string }); ok {
+			location := path.Join(g.BasePath(), o.opts.Path)
+			o.location.Store(&location)
+		}
+		r.GET(o.opts.Path+"/:id", o.status)
+	}
+}
+
+// Start registra a operação, responde 202 com o estado inicial e o cabeçalho
+// Location apontando para a rota de status e executa fn em segundo plano, com
+// um contexto que não é cancelado ao fim da requisição.
+//
+//	r.POST("/reports", func(c *gin.Context) {
+//		ops.Start(c, "report", func(ctx context.Context, progress func(int)) (any, error) {
+//			return buildReport(ctx, progress)
+//		})
+//	})
+func (o *Operations) Start(c *gin.Context, name string, fn OperationFunc) {
+	now := time.Now().UTC()
+	op := AsyncOperation{
+		ID:        idgen.UUIDv7(),
+		Name:      name,
+		Owner:     o.opts.Owner(c),
+		Status:    OperationPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := o.opts.Store.Save(c.Request.Context(), op); err != nil {
+		logx.Ctx(c.Request.Context()).Error("failed to save operation", "operation", name, "error", err)
+		Fail(c, errx.New("failed to start operation").WithCode(errx.INTERNAL))
+		return
+	}
+
+	ctx := context.WithoutCancel(c.Request.Context())
+	o.running.Add(1)
+	go o.run(ctx, op, fn)
+
+	c.Header("Location", *o.location.Load()+"/"+op.ID)
+	c.JSON(http.StatusAccepted, Envelope{Data: op})
+}
+
+// Stop aguarda as operações em andamento ou o fim de ctx (compatível com Server.OnStop).
+func (o *Operations) Stop(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		o.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run executa a operação, registrando progresso, resultado e falhas no store.
+func (o *Operations) run(ctx context.Context, op AsyncOperation, fn OperationFunc) {
+	defer o.running.Done()
+
+	// O estado é salvo mesmo quando o trabalho excede o Timeout.
+	storeCtx := ctx
+	if o.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.opts.Timeout)
+		defer cancel()
+	}
+
+	var mu sync.Mutex
+	save := func(update func(op *AsyncOperation)) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		update(&op)
+		op.UpdatedAt = time.Now().UTC()
+		if err := o.opts.Store.Save(storeCtx, op); err != nil {
+			logx.Ctx(storeCtx).Error("failed to save operation", "operation", op.Name, "id", op.ID, "error", err)
+		}
+	}
+
+	save(func(op *AsyncOperation) { op.Status = OperationRunning })
+	progress := func(percent int) {
+		save(func(op *AsyncOperation) { op.Progress = min(max(percent, 0), 100) })
+	}
+
+	result, err := callOperation(ctx, fn, progress)
+	if err == nil {
+		var raw []byte
+		if raw, err = json.Marshal(result); err == nil {
+			save(func(op *AsyncOperation) {
+				op.Status = OperationSucceeded
+				op.Progress = 100
+				op.Result = raw
+			})
+			return
+		}
+	}
+
+	logx.Ctx(ctx).Error("operation failed", "operation", op.Name, "id", op.ID, "error", err)
+	_, payload := errx.PrintHttpLogger(errx.FromContextErr(err))
+	if payload == nil {
+		_, payload = errx.PrintHttpLogger(errx.New("internal server error").WithCode(errx.INTERNAL))
+	}
+	save(func(op *AsyncOperation) {
+		op.Status = OperationFailed
+		op.Error = payload
+	})
+}
+
+// callOperation executa fn convertendo panics em INTERNAL; o panic e a pilha
+// vão apenas para o log, sem aparecer na rota de status.
+func callOperation(ctx context.Context, fn OperationFunc, progress func(int)) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logx.Ctx(ctx).Error("operation panicked", "panic", fmt.Sprint(r), "stack", string(debug.Stack()))
+			err = errx.New("internal server error").WithCode(errx.INTERNAL)
+		}
+	}()
+	return fn(ctx, progress)
+}
+
+// status responde o estado da operação ou 404 quando ela não existe, expirou
+// ou pertence a outro dono.
+func (o *Operations) status(c *gin.Context) {
+	op, ok, err := o.opts.Store.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		logx.Ctx(c.Request.Context()).Error("failed to load operation", "id", c.Param("id"), "error", err)
+		Fail(c, errx.New("failed to load operation").WithCode(errx.INTERNAL))
+		return
+	}
+	if !ok || op.Owner != o.opts.Owner(c) {
+		Fail(c, errx.New("operation not found").WithCode(errx.NOT_FOUND))
+		return
+	}
+	OK(c, op)
+}
+
+// MemoryOperationStore é um OperationStore em memória, válido apenas dentro do
+// processo; operações concluídas são descartadas após a retenção, em uma
+// varredura feita no máximo a cada operationSweepInterval.
+type MemoryOperationStore struct {
+	mu        sync.Mutex
+	ops       map[string]AsyncOperation
+	retention time.Duration
+	nextSweep time.Time
+}
+
+// operationSweepInterval é o intervalo mínimo entre as varreduras de operações expiradas.
+const operationSweepInterval = time.Minute
+
+// NewMemoryOperationStore cria um OperationStore em memória com a retenção informada.
+func NewMemoryOperationStore(retention time.Duration) *MemoryOperationStore {
+	return &MemoryOperationStore{ops: map[string]AsyncOperation{}, retention: retention}
+}
+
+// Save implementa OperationStore.
+func (m *MemoryOperationStore) Save(_ context.Context, op AsyncOperation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ops[op.ID] = op
+
+	now := time.Now()
+	if now.Before(m.nextSweep) {
+		return nil
+	}
+	m.nextSweep = now.Add(operationSweepInterval)
+	for id, existing := range m.ops {
+		if existing.Done() && now.Sub(existing.UpdatedAt) > m.retention {
+			delete(m.ops, id)
+		}
+	}
+	return nil
+}
+
+// Get implementa OperationStore.
+func (m *MemoryOperationStore) Get(_ context.Context, id string) (AsyncOperation, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	op, ok := m.ops[id]
+	if ok && op.Done() && time.Since(op.UpdatedAt) > m.retention {
+		return AsyncOperation{}, false, nil
+	}
+	return op, ok, nil
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	maxPage         = 100_000
+)
+
+// PageOptions define limites e campos permitidos para o binder de paginação.
+type PageOptions struct {
+	// DefaultSize é o tamanho de página usado quando o cliente não informa size.
+	DefaultSize int
+	// MaxSize é o teto aplicado ao size informado pelo cliente.
+	MaxSize int
+	// MaxPage é a maior página aceita; acima dela, a requisição é rejeitada
+	// (padrão: 100000), evitando offsets que estourem o int.
+	MaxPage int
+	// SortFields é a lista de campos aceitos em sort; vazia desabilita a ordenação.
+	SortFields []string
+	// FilterFields é a lista de campos aceitos em filter[campo]; vazia desabilita filtros.
+	FilterFields []string
+}
+
+// SortField representa um critério de ordenação.
+type SortField struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc"`
+}
+
+// PageRequest é a representação tipada dos parâmetros de listagem da requisição.
+type PageRequest struct {
+	Page    int               `json:"page"`
+	Size    int               `json:"size"`
+	Sort    []SortField       `json:"sort,omitempty"`
+	Filters map[string]string `json:"filters,omitempty"`
+}
+
+// Offset devolve o deslocamento correspondente à página solicitada.
+func (p PageRequest) Offset() int {
+	return (p.Page - 1) * p.Size
+}
+
+// PageMeta descreve a página devolvida ao cliente.
+type PageMeta struct {
+	Page       int   `json:"page"`
+	Size       int   `json:"size"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// PageResponse é o envelope padrão de respostas paginadas.
+type PageResponse[T any] struct {
+	Data []T      `json:"data"`
+	Meta PageMeta `json:"meta"`
+}
+
+// NewPageResponse monta o envelope paginado a partir da requisição, itens e total.
+func NewPageResponse[T any](req PageRequest, items []T, total int64) PageResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	totalPages := 0
+	if req.Size > 0 {
+		totalPages = int((total + int64(req.Size) - 1) / int64(req.Size))
+	}
+
+	return PageResponse[T]{
+		Data: items,
+		Meta: PageMeta{
+			Page:       req.Page,
+			Size:       req.Size,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}
+}
+
+// BindPage lê page, size, sort (ex.: sort=name,-created_at) e filter[campo]
+// da query string, aplicando os limites e whitelists de opts.
+func BindPage(c *gin.Context, opts ...PageOptions) (PageRequest, error) {
+	var o PageOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.DefaultSize <= 0 {
+		o.DefaultSize = defaultPageSize
+	}
+	if o.MaxSize <= 0 {
+		o.MaxSize = maxPageSize
+	}
+	if o.MaxPage <= 0 {
+		o.MaxPage = maxPage
+	}
+
+	req := PageRequest{Page: 1, Size: o.DefaultSize}
+
+	if v := c.Query("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 || page > o.MaxPage {
+			return req, invalidQuery("page", v)
+		}
+		req.Page = page
+	}
+
+	if v := c.Query("size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size < 1 {
+			return req, invalidQuery("size", v)
+		}
+		if size > o.MaxSize {
+			size = o.MaxSize
+		}
+		req.Size = size
+	}
+
+	if v := c.Query("sort"); v != "" {
+		for _, raw := range strings.Split(v, ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			field := SortField{Field: strings.TrimLeft(raw, "+-"), Desc: strings.HasPrefix(raw, "-")}
+			if !contains(o.SortFields, field.Field) {
+				return req, invalidQuery("sort", raw)
+			}
+			req.Sort = append(req.Sort, field)
+		}
+	}
+
+	for field, value := range c.QueryMap("filter") {
+		if !contains(o.FilterFields, field) {
+			return req, invalidQuery("filter", field)
+		}
+		if req.Filters == nil {
+			req.Filters = map[string]string{}
+		}
+		req.Filters[field] = value
+	}
+
+	return req, nil
+}
+
+// invalidQuery monta o erro BAD_REQUEST para um parâmetro de query inválido.
+func invalidQuery(param, value string) error {
+	return errx.New("invalid query parameter").
+		WithCode(errx.BAD_REQUEST).
+		WithDetails(map[string]interface{}{"param": param, "value": value})
+}
+
+// contains informa se value está presente em list.
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+
+	"github.com/quic-go/quic-go/http3"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// HTTP3Options configura o listener experimental de HTTP/3 sobre QUIC (UDP).
+type HTTP3Options struct {
+	// Address é o endereço UDP do listener (ex.: ":8443").
+	Address string
+	// TLS é a configuração TLS do listener; obrigatória caso CertFile/KeyFile não sejam informados.
+	TLS *tls.Config
+	// CertFile e KeyFile carregam o certificado do disco.
+	CertFile string
+	KeyFile  string
+}
+
+// H2C habilita HTTP/2 sem TLS (prior knowledge ou Upgrade: h2c) nos listeners
+// em texto puro, indicado para tráfego interno da malha de serviços.
+func (s *Server) H2C(enabled bool) *Server {
+	s.h2c = enabled
+	return s
+}
+
+// HTTP3 habilita, de forma experimental, um listener HTTP/3 adicional. As
+// respostas dos demais listeners passam a anunciar o endpoint via Alt-Svc.
+func (s *Server) HTTP3(opts HTTP3Options) *Server {
+	s.http3 = &opts
+	return s
+}
+
+// handler devolve o http.Handler final, aplicando h2c e Alt-Svc quando configurados.
+func (s *Server) handler(h3 *http3.Server) http.Handler {
+	var handler http.Handler = s.gin
+
+	if h3 != nil {
+		next := handler
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = h3.SetQUICHeaders(w.Header())
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	if s.h2c {
+		handler = h2c.NewHandler(handler, &http2.Server{})
+	}
+
+	return handler
+}
+
+// newHTTP3Server cria o servidor HTTP/3 configurado, ou nil quando desabilitado.
+func (s *Server) newHTTP3Server() (*http3.Server, error) {
+	if s.http3 == nil {
+		return nil, nil
+	}
+
+	cfg, err := Listener{
+		Address:  s.http3.Address,
+		TLS:      s.http3.TLS,
+		CertFile: s.http3.CertFile,
+		KeyFile:  s.http3.KeyFile,
+	}.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, errors.New("http3: TLS configuration is required")
+	}
+
+	h3 := &http3.Server{
+		Addr:      s.http3.Address,
+		Handler:   s.gin,
+		TLSConfig: http3.ConfigureTLSConfig(cfg),
+	}
+	return h3, nil
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"github.com/redis/go-redis/v9"
+)
+
+// QuotaPeriod é o período de apuração da cota.
+type QuotaPeriod string
+
+const (
+	QuotaDaily   QuotaPeriod = "day"
+	QuotaMonthly QuotaPeriod = "month"
+)
+
+// QuotaStore guarda os contadores de uso por cliente e período.
+type QuotaStore interface {
+	// Incr soma uma chamada ao contador key, que expira em expireAt, e devolve o total.
+	Incr(ctx context.Context, key string, expireAt time.Time) (int64, error)
+}
+
+// QuotaOptions configura a cota por cliente.
+type QuotaOptions struct {
+	// Limit é o número de chamadas permitidas por período.
+	Limit int64
+	// LimitFor define limites por cliente (ex.: por plano contratado); quando
+	// devolve zero ou é nil, vale Limit. Um valor negativo libera o cliente.
+	LimitFor func(c *gin.Context, client string) int64
+	// Period é o período de apuração (UTC); quando vazio, assume QuotaDaily.
+	Period QuotaPeriod
+	// Key identifica o cliente; quando nil, usa o cliente autenticado (ver
+	// SubjectFromContext, preenchido pelo auth.Middleware) e, sem autenticação,
+	// o IP de origem. Requisições com chave vazia não são contabilizadas.
+	// A chave nunca é gravada como está no store: é usado o seu hash.
+	Key func(c *gin.Context) string
+	// Store guarda os contadores; quando nil, usa um store em memória (uma única instância).
+	Store QuotaStore
+	// FailOpen libera as requisições quando o store falha; por padrão, elas são rejeitadas com 503.
+	FailOpen bool
+}
+
+// Quota registra globalmente a cota por cliente.
+func (s *Server) Quota(opts QuotaOptions) *Server {
+	return s.Middlewares(EnforceQuota(opts))
+}
+
+// EnforceQuota devolve um middleware que contabiliza as chamadas de cada
+// cliente no período e responde 429 ao exceder a cota, informando o uso nos
+// cabeçalhos X-Quota-Limit, X-Quota-Remaining e X-Quota-Reset (segundos Unix).
+func EnforceQuota(opts QuotaOptions) gin.HandlerFunc {
+	if opts.Period == "" {
+		opts.Period = QuotaDaily
+	}
+	if opts.Key == nil {
+		opts.Key = defaultQuotaKey
+	}
+	if opts.Store == nil {
+		opts.Store = NewMemoryQuotaStore()
+	}
+
+	return func(c *gin.Context) {
+		client := opts.Key(c)
+		if client == "" {
+			c.Next()
+			return
+		}
+
+		limit := opts.Limit
+		if opts.LimitFor != nil {
+			if custom := opts.LimitFor(c, client); custom != 0 {
+				limit = custom
+			}
+		}
+		if limit < 0 {
+			c.Next()
+			return
+		}
+
+		window, reset := quotaWindow(opts.Period, time.Now().UTC())
+		used, err := opts.Store.Incr(c.Request.Context(), "quota:"+quotaHash(client)+":"+window, reset)
+		if err != nil {
+			logx.Ctx(c.Request.Context()).Error("failed to check quota", "error", err)
+			if opts.FailOpen {
+				c.Next()
+				return
+			}
+			Fail(c, errx.New("quota check unavailable").WithCode(errx.UNAVAILABLE))
+			return
+		}
+
+		c.Header("X-Quota-Limit", strconv.FormatInt(limit, 10))
+		c.Header("X-Quota-Remaining", strconv.FormatInt(max(limit-used, 0), 10))
+		c.Header("X-Quota-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+		if used > limit {
+			dd.Metrics().Incr("server.quota.exceeded", "period:"+string(opts.Period))
+			c.Header("Retry-After", strconv.FormatInt(int64(time.Until(reset).Seconds())+1, 10))
+			Fail(c, errx.New("quota exceeded").
+				WithCode(errx.TOO_MANY_REQUESTS).
+				WithDetails(map[string]interface{}{
+					"limit":  limit,
+					"period": opts.Period,
+					"reset":  reset.Format(time.RFC3339),
+				}))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// defaultQuotaKey identifica o cliente pelo subject autenticado ou, sem ele, pelo IP.
+func defaultQuotaKey(c *gin.Context) string {
+	if subject := SubjectFromContext(c.Request.Context()); subject != "" {
+		return "sub:" + subject
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// quotaHash evita que credenciais usadas como chave apareçam nos nomes das chaves do store.
+func quotaHash(client string) string {
+	sum := sha256.Sum256([]byte(client))
+	return hex.EncodeToString(sum[:16])
+}
+
+// quotaWindow devolve o identificador do período corrente e o instante em que ele termina.
+func quotaWindow(period QuotaPeriod, now time.Time) (string, time.Time) {
+	if period == QuotaMonthly {
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start.Format("2006-01"), start.AddDate(0, 1, 0)
+	}
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return start.Format(time.DateOnly), start.AddDate(0, 0, 1)
+}
+
+// MemoryQuotaStore é um QuotaStore em memória, válido apenas dentro do processo.
+type MemoryQuotaStore struct {
+	mu       sync.Mutex
+	counters map[string]quotaCounter
+}
+
+// quotaCounter é o uso acumulado de um cliente no período.
+type quotaCounter struct {
+	count    int64
+	expireAt time.Time
+}
+
+// NewMemoryQuotaStore cria um QuotaStore em memória.
+func NewMemoryQuotaStore() *MemoryQuotaStore {
+	return &MemoryQuotaStore{counters: map[string]quotaCounter{}}
+}
+
+// Incr implementa QuotaStore.
+func (m *MemoryQuotaStore) Incr(_ context.Context, key string, expireAt time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	counter, ok := m.counters[key]
+	if !ok {
+		// Um novo período começou: descarta os contadores expirados.
+		for k, existing := range m.counters {
+			if now.After(existing.expireAt) {
+				delete(m.counters, k)
+			}
+		}
+	}
+	counter.count++
+	counter.expireAt = expireAt
+	m.counters[key] = counter
+	return counter.count, nil
+}
+
+// RedisQuotaStore é um QuotaStore compartilhado entre instâncias, baseado em INCR.
+type RedisQuotaStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisQuotaStore cria um QuotaStore no Redis.
+func NewRedisQuotaStore(client redis.UniversalClient) *RedisQuotaStore {
+	return &RedisQuotaStore{client: client}
+}
+
+// Incr implementa QuotaStore.
+func (r *RedisQuotaStore) Incr(ctx context.Context, key string, expireAt time.Time) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.ExpireAt(ctx, key, expireAt)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("server: increment quota: %w", err)
+	}
+	return incr.Val(), nil
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// SetReady altera o estado de prontidão da instância. Com false, o endpoint
+// /readiness passa a responder 503 para que o load balancer retire a instância,
+// sem interromper as requisições em andamento.
+func (s *Server) SetReady(ready bool) *Server {
+	s.draining.Store(!ready)
+	return s
+}
+
+// Ready informa se a instância está apta a receber tráfego.
+func (s *Server) Ready() bool {
+	return !s.draining.Load()
+}
+
+// ReadinessAdmin habilita o endpoint PUT /admin/readiness, protegido pelo
+// token informado (Authorization: Bearer <token>), que recebe {"ready": bool}.
+// Equivale a AdminToken, que também habilita os demais endpoints administrativos.
+func (s *Server) ReadinessAdmin(token string) *Server {
+	return s.AdminToken(token)
+}
+
+// AdminToken habilita os endpoints administrativos (/admin/*), protegidos pelo
+// token informado no cabeçalho Authorization: Bearer <token>.
+func (s *Server) AdminToken(token string) *Server {
+	s.adminToken = token
+	return s
+}
+
+// adminAuth exige o token administrativo configurado.
+func (s *Server) adminAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+			Fail(c, errx.New("invalid admin token").WithCode(errx.UNAUTHORIZED))
+			return
+		}
+		c.Next()
+	}
+}
+
+// addReadiness registra o endpoint de prontidão e, se configurado, o endpoint administrativo.
+func (s *Server) addReadiness() {
+	s.gin.GET("/readiness", func(c *gin.Context) {
+		if !s.Ready() {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "draining"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ready"})
+	})
+
+	if s.adminToken == "" {
+		return
+	}
+
+	s.gin.PUT("/admin/readiness", s.adminAuth(), func(c *gin.Context) {
+		var body struct {
+			Ready *bool `json:"ready"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil || body.Ready == nil {
+			Fail(c, errx.New("field 'ready' is required").WithCode(errx.BAD_REQUEST))
+			return
+		}
+
+		s.SetReady(*body.Ready)
+		c.JSON(http.StatusOK, gin.H{"ready": s.Ready()})
+	})
+}
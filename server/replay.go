@@ -0,0 +1,242 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/cryptox"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/idgen"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"github.com/redis/go-redis/v9"
+)
+
+// NonceStore registra os nonces já vistos dentro da janela de validade.
+type NonceStore interface {
+	// Claim registra o nonce por ttl e informa se ele ainda não havia sido usado.
+	Claim(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+}
+
+// ReplayOptions configura a proteção contra repetição de requisições assinadas.
+type ReplayOptions struct {
+	// Secret fornece as chaves HMAC que assinam timestamp, nonce e corpo (obrigatório).
+	Secret WebhookSecretProvider
+	// SignatureHeader é o cabeçalho com a assinatura; quando vazio, assume "X-Signature".
+	SignatureHeader string
+	// NonceHeader é o cabeçalho com o identificador único da requisição; quando vazio, assume "X-Nonce".
+	NonceHeader string
+	// TimestampHeader é o cabeçalho com o instante do envio, em segundos Unix ou
+	// RFC 3339; quando vazio, assume "X-Timestamp".
+	TimestampHeader string
+	// Window é a tolerância entre o timestamp e o relógio do servidor; quando zero, assume 5 minutos.
+	Window time.Duration
+	// Store guarda os nonces usados; quando nil, usa um store em memória (uma única instância).
+	Store NonceStore
+}
+
+func (o ReplayOptions) withDefaults() ReplayOptions {
+	if o.SignatureHeader == "" {
+		o.SignatureHeader = "X-Signature"
+	}
+	if o.NonceHeader == "" {
+		o.NonceHeader = "X-Nonce"
+	}
+	if o.TimestampHeader == "" {
+		o.TimestampHeader = "X-Timestamp"
+	}
+	if o.Window <= 0 {
+		o.Window = 5 * time.Minute
+	}
+	return o
+}
+
+// ReplayProtection registra globalmente a proteção contra repetição.
+func (s *Server) ReplayProtection(opts ReplayOptions) *Server {
+	return s.Middlewares(RejectReplays(opts))
+}
+
+// RejectReplays devolve um middleware que exige nonce, timestamp e a assinatura
+// HMAC-SHA256 de "<timestamp>.<nonce>.<corpo>" (ver SignReplay), rejeitando com
+// 401 requisições com assinatura inválida, fora da janela de validade ou cujo
+// nonce já foi usado. A assinatura é conferida antes do registro do nonce, para
+// que requisições não autenticadas não consumam nonces. Entra em pânico quando
+// opts.Secret é nil: sem assinatura cobrindo o nonce, a proteção seria contornável.
+func RejectReplays(opts ReplayOptions) gin.HandlerFunc {
+	if opts.Secret == nil {
+		panic("server: RejectReplays requires ReplayOptions.Secret")
+	}
+	opts = opts.withDefaults()
+	if opts.Store == nil {
+		opts.Store = NewMemoryNonceStore()
+	}
+
+	return func(c *gin.Context) {
+		nonce := c.GetHeader(opts.NonceHeader)
+		timestamp := c.GetHeader(opts.TimestampHeader)
+		sent, ok := parseTimestamp(timestamp)
+		if nonce == "" || !ok {
+			Fail(c, errx.New("missing or invalid nonce and timestamp").WithCode(errx.UNAUTHORIZED))
+			return
+		}
+
+		if skew := time.Since(sent); skew > opts.Window || skew < -opts.Window {
+			Fail(c, errx.New("request timestamp outside the allowed window").WithCode(errx.UNAUTHORIZED))
+			return
+		}
+
+		signature := c.GetHeader(opts.SignatureHeader)
+		if signature == "" {
+			Fail(c, errx.New("missing request signature").WithCode(errx.UNAUTHORIZED))
+			return
+		}
+
+		body, err := Body(c)
+		if err != nil {
+			if errors.Is(err, ErrBodyTooLarge) {
+				Fail(c, errx.New("request body too large").WithCode(errx.PAYLOAD_TOO_LARGE))
+				return
+			}
+			logx.Ctx(c.Request.Context()).Warn("failed to read signed request body", "error", err)
+			Fail(c, errx.New("failed to read request body").WithCode(errx.BAD_REQUEST))
+			return
+		}
+
+		keys, err := opts.Secret(c.Request.Context())
+		if err != nil {
+			logx.Ctx(c.Request.Context()).Error("failed to load replay protection secret", "error", err)
+			Fail(c, errx.New("internal server error").WithCode(errx.INTERNAL))
+			return
+		}
+
+		payload := replayPayload(timestamp, nonce, body)
+		verified := false
+		for _, key := range keys {
+			if cryptox.Verify([]byte(key), payload, signature) {
+				verified = true
+				break
+			}
+		}
+		if !verified {
+			Fail(c, errx.New("invalid request signature").WithCode(errx.UNAUTHORIZED))
+			return
+		}
+
+		// O nonce é mantido por duas janelas para cobrir a tolerância nos dois sentidos.
+		fresh, err := opts.Store.Claim(c.Request.Context(), nonce, 2*opts.Window)
+		if err != nil {
+			logx.Ctx(c.Request.Context()).Error("failed to check request nonce", "error", err)
+			Fail(c, errx.New("replay protection unavailable").WithCode(errx.UNAVAILABLE))
+			return
+		}
+		if !fresh {
+			Fail(c, errx.New("replayed request").WithCode(errx.UNAUTHORIZED))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// SignReplay assina a requisição do lado do cliente para RejectReplays: gera
+// nonce e timestamp e preenche os cabeçalhos configurados em opts com eles e
+// com a assinatura HMAC-SHA256 de "<timestamp>.<nonce>.<corpo>".
+//
+//	body, _ := json.Marshal(payload)
+//	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+//	server.SignReplay(req, key, body, server.ReplayOptions{})
+func SignReplay(req *http.Request, key string, body []byte, opts ReplayOptions) {
+	opts = opts.withDefaults()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	nonce := idgen.UUIDv7()
+
+	req.Header.Set(opts.TimestampHeader, timestamp)
+	req.Header.Set(opts.NonceHeader, nonce)
+	req.Header.Set(opts.SignatureHeader, cryptox.Sign([]byte(key), replayPayload(timestamp, nonce, body)))
+}
+
+// replayPayload monta o conteúdo assinado: "<timestamp>.<nonce>.<corpo>".
+func replayPayload(timestamp, nonce string, body []byte) []byte {
+	payload := make([]byte, 0, len(timestamp)+len(nonce)+2+len(body))
+	payload = append(payload, timestamp...)
+	payload = append(payload, '.')
+	payload = append(payload, nonce...)
+	payload = append(payload, '.')
+	payload = append(payload, body...)
+	return payload
+}
+
+// parseTimestamp interpreta o timestamp em segundos Unix ou RFC 3339.
+func parseTimestamp(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), true
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, err == nil
+}
+
+// MemoryNonceStore é um NonceStore em memória, válido apenas dentro do processo.
+type MemoryNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+	sweep  time.Time
+}
+
+// NewMemoryNonceStore cria um NonceStore em memória.
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{nonces: map[string]time.Time{}}
+}
+
+// Claim implementa NonceStore.
+func (m *MemoryNonceStore) Claim(_ context.Context, nonce string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.After(m.sweep) {
+		for key, expires := range m.nonces {
+			if now.After(expires) {
+				delete(m.nonces, key)
+			}
+		}
+		m.sweep = now.Add(ttl)
+	}
+
+	if expires, ok := m.nonces[nonce]; ok && now.Before(expires) {
+		return false, nil
+	}
+	m.nonces[nonce] = now.Add(ttl)
+	return true, nil
+}
+
+// RedisNonceStore é um NonceStore compartilhado entre instâncias, baseado em SET NX.
+type RedisNonceStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisNonceStore cria um NonceStore no Redis; prefix isola as chaves
+// (quando vazio, assume "nonce:").
+func NewRedisNonceStore(client redis.UniversalClient, prefix string) *RedisNonceStore {
+	if prefix == "" {
+		prefix = "nonce:"
+	}
+	return &RedisNonceStore{client: client, prefix: prefix}
+}
+
+// Claim implementa NonceStore.
+func (r *RedisNonceStore) Claim(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	ok, err := r.client.SetNX(ctx, r.prefix+nonce, 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("server: claim nonce: %w", err)
+	}
+	return ok, nil
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/cryptox"
+)
+
+func TestRejectReplays(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const (
+		key  = "replay-secret"
+		body = `{"amount":100}`
+	)
+	opts := ReplayOptions{Secret: WebhookSecret(key)}
+
+	router := gin.New()
+	router.POST("/transfers", RejectReplays(opts), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	send := func(req *http.Request) int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	newRequest := func() *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/transfers", strings.NewReader(body))
+	}
+	signed := func() *http.Request {
+		req := newRequest()
+		SignReplay(req, key, []byte(body), opts)
+		return req
+	}
+	signedAt := func(sent time.Time, nonce string) *http.Request {
+		req := newRequest()
+		ts := strconv.FormatInt(sent.Unix(), 10)
+		req.Header.Set("X-Timestamp", ts)
+		req.Header.Set("X-Nonce", nonce)
+		req.Header.Set("X-Signature", cryptox.Sign([]byte(key), replayPayload(ts, nonce, []byte(body))))
+		return req
+	}
+
+	reused := signed()
+	replay := newRequest()
+	replay.Header = reused.Header.Clone()
+
+	tampered := httptest.NewRequest(http.MethodPost, "/transfers", strings.NewReader(`{"amount":999}`))
+	tampered.Header = signed().Header
+
+	forged := signed()
+	forged.Header.Set("X-Nonce", "other-nonce")
+
+	unsigned := signed()
+	unsigned.Header.Del("X-Signature")
+
+	tests := []struct {
+		name       string
+		req        *http.Request
+		wantStatus int
+	}{
+		{name: "fresh request", req: reused, wantStatus: http.StatusOK},
+		{name: "nonce reuse", req: replay, wantStatus: http.StatusUnauthorized},
+		{name: "tampered body", req: tampered, wantStatus: http.StatusUnauthorized},
+		{name: "nonce not covered by the signature", req: forged, wantStatus: http.StatusUnauthorized},
+		{name: "missing signature", req: unsigned, wantStatus: http.StatusUnauthorized},
+		{name: "stale timestamp", req: signedAt(time.Now().Add(-10*time.Minute), "stale"), wantStatus: http.StatusUnauthorized},
+		{name: "missing nonce", req: signedAt(time.Now(), ""), wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := send(tt.req); got != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRejectReplaysKeepsNonceOfRejectedRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	store := NewMemoryNonceStore()
+	opts := ReplayOptions{Secret: WebhookSecret("replay-secret"), Store: store}
+	router := gin.New()
+	router.POST("/", RejectReplays(opts), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+	SignReplay(req, "wrong-secret", []byte("{}"), opts)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	fresh, err := store.Claim(context.Background(), req.Header.Get("X-Nonce"), time.Minute)
+	if err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if !fresh {
+		t.Fatal("a request with an invalid signature consumed its nonce")
+	}
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// Envelope é o formato padrão de resposta JSON da API (data/meta/error).
+type Envelope struct {
+	Data  interface{}      `json:"data,omitempty"`
+	Meta  interface{}      `json:"meta,omitempty"`
+	Error *errx.ShowLogger `json:"error,omitempty"`
+}
+
+// OK responde 200 com data encapsulado no envelope padrão.
+func OK(c *gin.Context, data interface{}) {
+	c.JSON(http.StatusOK, Envelope{Data: data})
+}
+
+// OKWithMeta responde 200 com data e metadados (ex.: paginação) no envelope padrão.
+func OKWithMeta(c *gin.Context, data interface{}, meta interface{}) {
+	c.JSON(http.StatusOK, Envelope{Data: data, Meta: meta})
+}
+
+// Created responde 201 com o recurso criado encapsulado no envelope padrão.
+func Created(c *gin.Context, data interface{}) {
+	c.JSON(http.StatusCreated, Envelope{Data: data})
+}
+
+// NoContent responde 204 sem corpo.
+func NoContent(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+// Fail interrompe a cadeia de handlers e responde com o erro formatado pelo errx,
+// traduzindo a mensagem quando o middleware de locale estiver ativo.
+// Erros que não são AppError são tratados como INTERNAL sem expor a causa ao cliente.
+// Equivale a errx.AbortWithError.
+func Fail(c *gin.Context, err error) {
+	errx.AbortWithError(c, err)
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+const (
+	// listenFDsEnv informa ao processo filho quantos listeners foram herdados.
+	listenFDsEnv = "SERVER_LISTEN_FDS"
+	// listenFDsStart é o primeiro descritor herdado (após stdin, stdout e stderr).
+	listenFDsStart = 3
+)
+
+// GracefulRestart habilita o restart sem indisponibilidade via SIGHUP: o processo
+// inicia uma nova instância do mesmo binário repassando os sockets abertos e, em
+// seguida, drena e encerra a instância atual. Também são aceitos sockets
+// passados pelo systemd (LISTEN_FDS/LISTEN_PID). O listener HTTP/3 (UDP) não é
+// herdado; não combine com HTTP3 enquanto não houver suporte.
+func (s *Server) GracefulRestart(enabled bool) *Server {
+	s.gracefulRestart = enabled
+	return s
+}
+
+// inheritedListeners recupera os listeners herdados do processo anterior ou do systemd.
+func inheritedListeners() ([]net.Listener, error) {
+	count := 0
+	if v := os.Getenv(listenFDsEnv); v != "" {
+		count, _ = strconv.Atoi(v)
+		_ = os.Unsetenv(listenFDsEnv)
+	} else if pid, _ := strconv.Atoi(os.Getenv("LISTEN_PID")); pid == os.Getpid() {
+		count, _ = strconv.Atoi(os.Getenv("LISTEN_FDS"))
+		_ = os.Unsetenv("LISTEN_PID")
+		_ = os.Unsetenv("LISTEN_FDS")
+	}
+
+	listeners := make([]net.Listener, 0, count)
+	for i := 0; i < count; i++ {
+		f := os.NewFile(uintptr(listenFDsStart+i), "listener-"+strconv.Itoa(i))
+		ln, err := net.FileListener(f)
+		_ = f.Close()
+		if err != nil {
+			closeListeners(listeners)
+			return nil, fmt.Errorf("inherited listener %d: %w", i, err)
+		}
+		listeners = append(listeners, ln)
+	}
+	return listeners, nil
+}
+
+// spawnSuccessor inicia uma nova instância do binário atual repassando os
+// descritores dos listeners brutos, na mesma ordem em que foram abertos.
+func spawnSuccessor(raw []net.Listener) (*os.Process, error) {
+	files := make([]*os.File, 0, len(raw))
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+
+	for _, ln := range raw {
+		fl, ok := ln.(interface{ File() (*os.File, error) })
+		if !ok {
+			return nil, fmt.Errorf("listener %s cannot be inherited", ln.Addr())
+		}
+		f, err := fl.File()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = files
+	cmd.Env = append(os.Environ(), listenFDsEnv+"="+strconv.Itoa(len(files)))
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	// O socket Unix passa a pertencer ao sucessor e não deve ser removido ao fechar.
+	for _, ln := range raw {
+		if ul, ok := ln.(*net.UnixListener); ok {
+			ul.SetUnlinkOnClose(false)
+		}
+	}
+
+	return cmd.Process, nil
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/idgen"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// StatusCoder pode ser implementado pela resposta de um handler tipado para
+// definir o status HTTP de sucesso (ex.: 201 em criações).
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// Route devolve um RouteMount que registra a rota com seus middlewares específicos,
+// executados antes do handler e após os middlewares globais.
+func Route(method, path string, handler gin.HandlerFunc, middlewares ...gin.HandlerFunc) RouteMount {
+	return func(r gin.IRouter) {
+		handlers := append(append([]gin.HandlerFunc{}, middlewares...), handler)
+		r.Handle(method, path, handlers...)
+	}
+}
+
+// Group devolve um RouteMount que agrupa rotas sob um prefixo comum com middlewares próprios.
+func Group(prefix string, middlewares []gin.HandlerFunc, routes ...RouteMount) RouteMount {
+	return func(r gin.IRouter) {
+		g := r.Group(prefix, middlewares...)
+		for _, route := range routes {
+			route(g)
+		}
+	}
+}
+
+// IDParam devolve um middleware que exige que o parâmetro de rota seja um ID
+// tipado com o prefixo informado (ex.: "pay_01H..."; veja idgen), respondendo
+// 400 no formato errx caso contrário.
+func IDParam(param string, prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := idgen.Validate(c.Param(param), prefix); err != nil {
+			Fail(c, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+// Handler adapta uma função de negócio tipada para gin.HandlerFunc. A requisição
+// é preenchida a partir dos parâmetros de rota (tag `uri`), query string (tag `form`)
+// e corpo JSON, validada pelas tags `binding` e a resposta é enviada no envelope padrão.
+// Erros são encaminhados para Fail; falhas de leitura da requisição que não são
+// AppError (ex.: JSON malformado) respondem "invalid request" sem o texto original,
+// que vai apenas para o log.
+func Handler[T any, U any](fn func(ctx context.Context, req T) (U, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req T
+		if err := bindRequest(c, &req); err != nil {
+			switch {
+			case errors.Is(err, ErrBodyTooLarge):
+				err = errx.New("request body too large").WithCode(errx.PAYLOAD_TOO_LARGE)
+			case !errx.IsAppError(err):
+				logx.Ctx(c.Request.Context()).Info("invalid request", "error", err)
+				err = errx.New("invalid request").WithCode(errx.BAD_REQUEST)
+			}
+			Fail(c, err)
+			return
+		}
+
+		resp, err := fn(c.Request.Context(), req)
+		if err != nil {
+			Fail(c, err)
+			return
+		}
+
+		status := http.StatusOK
+		if sc, ok := any(resp).(StatusCoder); ok {
+			status = sc.StatusCode()
+		}
+
+		if status == http.StatusNoContent {
+			NoContent(c)
+			return
+		}
+
+		c.JSON(status, Envelope{Data: resp})
+	}
+}
+
+// bindRequest preenche req com parâmetros de rota, query e corpo, validando ao final.
+func bindRequest(c *gin.Context, req interface{}) error {
+	if len(c.Params) > 0 {
+		params := make(map[string][]string, len(c.Params))
+		for _, p := range c.Params {
+			params[p.Key] = []string{p.Value}
+		}
+		if err := binding.MapFormWithTag(req, params, "uri"); err != nil {
+			return err
+		}
+	}
+
+	if err := binding.MapFormWithTag(req, c.Request.URL.Query(), "form"); err != nil {
+		return err
+	}
+
+	if c.Request.Body != nil && c.Request.ContentLength != 0 &&
+		c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		if err := json.NewDecoder(c.Request.Body).Decode(req); err != nil {
+			return err
+		}
+	}
+
+	if binding.Validator == nil {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(req)
+}
@@ -0,0 +1,78 @@
+package server
+
+import "github.com/gin-gonic/gin"
+
+// routingOptions espelha as opções de roteamento do engine do Gin.
+type routingOptions struct {
+	redirectTrailingSlash  bool
+	redirectFixedPath      bool
+	handleMethodNotAllowed bool
+	notFound               gin.HandlerFunc
+	methodNotAllowed       gin.HandlerFunc
+}
+
+// defaultRoutingOptions devolve os mesmos padrões do Gin. Sem handlers customizados,
+// 404/405 são respondidos no formato errx (ver errors.go).
+func defaultRoutingOptions() routingOptions {
+	return routingOptions{
+		redirectTrailingSlash: true,
+	}
+}
+
+// RedirectTrailingSlash define se /foo/ é redirecionado para /foo (e vice-versa) quando apenas a outra existe.
+func (s *Server) RedirectTrailingSlash(enabled bool) *Server {
+	s.routing.redirectTrailingSlash = enabled
+	return s
+}
+
+// RedirectFixedPath define se caminhos com maiúsculas ou elementos redundantes (../, //) são corrigidos e redirecionados.
+func (s *Server) RedirectFixedPath(enabled bool) *Server {
+	s.routing.redirectFixedPath = enabled
+	return s
+}
+
+// HandleMethodNotAllowed define se rotas existentes com método diferente respondem 405 em vez de 404.
+func (s *Server) HandleMethodNotAllowed(enabled bool) *Server {
+	s.routing.handleMethodNotAllowed = enabled
+	return s
+}
+
+// NotFound substitui o handler padrão de rotas não encontradas.
+func (s *Server) NotFound(handler gin.HandlerFunc) *Server {
+	s.routing.notFound = handler
+	return s
+}
+
+// MethodNotAllowed substitui o handler padrão de métodos não permitidos.
+func (s *Server) MethodNotAllowed(handler gin.HandlerFunc) *Server {
+	s.routing.methodNotAllowed = handler
+	return s
+}
+
+// applyRouting aplica as opções de roteamento e os handlers 404/405 ao engine.
+func (s *Server) applyRouting() {
+	s.gin.RedirectTrailingSlash = s.routing.redirectTrailingSlash
+	s.gin.RedirectFixedPath = s.routing.redirectFixedPath
+	s.gin.HandleMethodNotAllowed = s.routing.handleMethodNotAllowed
+
+	notFound := s.routing.notFound
+	if notFound == nil {
+		notFound = s.notFoundHandler
+	}
+	methodNotAllowed := s.routing.methodNotAllowed
+	if methodNotAllowed == nil {
+		methodNotAllowed = s.methodNotAllowedHandler
+	}
+
+	noRoute := []gin.HandlerFunc{}
+	if s.mock != nil && s.mock.err == nil {
+		noRoute = append(noRoute, s.mock.handler())
+	}
+	if s.spa != nil {
+		noRoute = append(noRoute, s.spaHandler())
+	}
+	noRoute = append(noRoute, notFound)
+
+	s.gin.NoRoute(noRoute...)
+	s.gin.NoMethod(methodNotAllowed)
+}
@@ -1,9 +1,21 @@
 package server
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	ginrender "github.com/gin-gonic/gin/render"
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
 )
 
 // RouteMount encapsula a lógica de montagem de um conjunto de rotas em um router do Gin.
@@ -15,6 +27,35 @@ type Server struct {
 	ginMode     string
 	middlewares []gin.HandlerFunc
 	routes      []RouteMount
+	openapi     *OpenAPI
+	swaggerUI   bool
+	spa         *spaConfig
+	mock        *mockConfig
+	views       ginrender.HTMLRender
+	accessLog   AccessLogOptions
+	draining    atomic.Bool
+	adminToken  string
+	adminRoutes []RouteMount
+	noTracing   bool
+	routing     routingOptions
+	errorMsgs   map[errx.Code]string
+	chains      routeChains
+	slo         sloMetrics
+	listeners   []Listener
+	h2c         bool
+	http3       *HTTP3Options
+
+	trustedProxies  []string
+	gracefulRestart bool
+
+	conns        *connTracker
+	drainTimeout time.Duration
+
+	healthChecks []healthCheck
+
+	startHooks      []Hook
+	stopHooks       []Hook
+	shutdownTimeout time.Duration
 }
 
 // N devolve uma instância limpa de Server pronta para ser configurada fluentemente.
@@ -24,6 +65,8 @@ func N() *Server {
 		ginMode:     gin.ReleaseMode,
 		middlewares: []gin.HandlerFunc{},
 		routes:      []RouteMount{},
+		routing:     defaultRoutingOptions(),
+		conns:       newConnTracker(),
 	}
 }
 
@@ -45,40 +88,173 @@ func (s *Server) GinMode(mode string) *Server {
 	return s
 }
 
+// OpenAPI publica o documento gerado pelo registro em /openapi.json e,
+// quando ui for verdadeiro, também o Swagger UI em /docs.
+func (s *Server) OpenAPI(doc *OpenAPI, ui bool) *Server {
+	s.openapi = doc
+	s.swaggerUI = ui
+	return s
+}
+
+// DisableTracing impede que o middleware do Datadog seja aplicado automaticamente
+// quando dd.Load tiver sido chamado.
+func (s *Server) DisableTracing() *Server {
+	s.noTracing = true
+	return s
+}
+
 // Run inicializa o engine do Gin, aplica middlewares, monta rotas e expõe o servidor HTTP.
+// Quando addr não é vazio, a porta TCP informada é somada aos listeners registrados em Listen.
+// O servidor é encerrado graciosamente ao receber SIGINT ou SIGTERM.
 func (s *Server) Run(addr string) {
+	if err := s.build(); err != nil {
+		logx.L().Error("Failed to start server", "error", err)
+		return
+	}
+
+	if err := errx.ValidateCodes(); err != nil {
+		logx.L().Error("Failed to start server", "error", err)
+		return
+	}
+
+	listeners := s.listeners
+	if addr != "" {
+		listeners = append([]Listener{{Network: "tcp", Address: ":" + addr}}, listeners...)
+	}
+
+	opened, raw, err := openListeners(listeners)
+	if err != nil {
+		logx.L().Error("Failed to start server", "error", err)
+		return
+	}
+
+	h3, err := s.newHTTP3Server()
+	if err != nil {
+		closeListeners(opened)
+		logx.L().Error("Failed to start server", "error", err)
+		return
+	}
+
+	srv := &http.Server{Handler: s.handler(h3)}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := s.runStartHooks(ctx); err != nil {
+		closeListeners(opened)
+		logx.L().Error("Failed to start server", "error", err)
+		return
+	}
+
+	errCh := make(chan error, len(opened)+1)
+	for _, l := range opened {
+		go func(l net.Listener) {
+			logx.L().Info("HTTP server is running", "addr", l.Addr().String())
+			if err := srv.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errCh <- err
+			}
+		}(l)
+	}
+
+	if h3 != nil {
+		go func() {
+			logx.L().Info("HTTP/3 server is running", "addr", h3.Addr)
+			if err := h3.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errCh <- err
+			}
+		}()
+	}
+
+	hup := make(chan os.Signal, 1)
+	if s.gracefulRestart {
+		signal.Notify(hup, syscall.SIGHUP)
+		defer signal.Stop(hup)
+	}
+
+wait:
+	for {
+		select {
+		case err := <-errCh:
+			logx.L().Error("Failed to serve", "error", err)
+			break wait
+		case <-ctx.Done():
+			logx.L().Info("HTTP server is shutting down")
+			break wait
+		case <-hup:
+			proc, err := spawnSuccessor(raw)
+			if err != nil {
+				logx.L().Error("Failed to restart server", "error", err)
+				continue
+			}
+			logx.L().Info("HTTP server restarted, draining", "pid", proc.Pid)
+			_ = proc.Release()
+			break wait
+		}
+	}
+
+	if h3 != nil {
+		s.shutdown(srv, h3.Shutdown)
+		return
+	}
+	s.shutdown(srv)
+}
+
+// Handler monta o engine com a mesma pilha de middlewares e rotas usada em Run
+// e o devolve como http.Handler, sem abrir listeners (útil em testes e embeddings).
+// Entra em pânico quando a configuração é inválida, nos mesmos casos em que Run
+// não inicia o servidor.
+func (s *Server) Handler() http.Handler {
+	if err := s.build(); err != nil {
+		panic(err)
+	}
+	return s.handler(nil)
+}
+
+// build inicializa o engine do Gin com middlewares, endpoints internos e rotas,
+// devolvendo erro quando a configuração impede o servidor de iniciar.
+func (s *Server) build() error {
 	gin.SetMode(s.ginMode)
 
 	s.gin = gin.New()
+	if err := s.applyTrustedProxies(); err != nil {
+		return err
+	}
+	if s.views != nil {
+		s.gin.HTMLRender = s.views
+	}
 
 	s.addInternalMiddlewares()
 	s.gin.Use(s.middlewares...)
 
 	s.addHealthCheck()
+	s.addReadiness()
+	s.addOpenAPI()
+	s.addRoutesAdmin()
 
 	for _, route := range s.routes {
 		route(s.gin)
 	}
 
-	fmt.Println("HTTP server is running...")
+	s.applyRouting()
 
-	if err := s.gin.Run(":" + addr); err != nil {
-		fmt.Errorf("Failed to start server: %v", err)
+	if s.mock != nil && s.mock.err != nil {
+		return s.mock.err
 	}
-}
-
-// addHealthCheck registra o endpoint padrão de verificação de saúde da aplicação.
-func (s *Server) addHealthCheck() {
-	s.gin.GET("/healthcheck", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
-	})
+	return nil
 }
 
 // addInternalMiddlewares aplica middlewares internos obrigatórios antes dos customizados.
 func (s *Server) addInternalMiddlewares() {
+	if dd.Enabled() && !s.noTracing {
+		s.gin.Use(dd.GinMiddleware(dd.ServiceName()))
+	}
+
 	s.gin.Use(
-		gin.Recovery(),
-		addLogger(),
+		s.chains.observe(),
+		s.recovery(),
+		addLogger(s.accessLog, s.ginMode),
 		xItauCorrelationId(),
+		s.connTrackerMiddleware(),
+		s.slo.observe(),
 	)
 }
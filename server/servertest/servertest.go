@@ -0,0 +1,282 @@
+// Package servertest fornece utilitários para testar handlers com a mesma
+// pilha de middlewares usada em produção pelo pacote server, sem abrir portas.
+package servertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/server"
+)
+
+// TestServer executa requisições em memória contra um server.Server configurado.
+type TestServer struct {
+	handler http.Handler
+}
+
+// NewTestServer cria um TestServer com as rotas informadas e a configuração padrão do server.
+func NewTestServer(routes ...server.RouteMount) *TestServer {
+	return FromServer(server.N().Routes(routes...))
+}
+
+// FromServer cria um TestServer a partir de um server.Server já configurado
+// (middlewares, rotas, opções), forçando o modo de teste do Gin.
+func FromServer(s *server.Server) *TestServer {
+	return &TestServer{handler: s.GinMode(gin.TestMode).Handler()}
+}
+
+// Request inicia a construção de uma requisição para o método e caminho informados.
+func (ts *TestServer) Request(method, path string) *RequestBuilder {
+	return &RequestBuilder{
+		ts:      ts,
+		method:  method,
+		path:    path,
+		headers: http.Header{},
+		query:   url.Values{},
+	}
+}
+
+// GET é um atalho para Request(http.MethodGet, path).
+func (ts *TestServer) GET(path string) *RequestBuilder {
+	return ts.Request(http.MethodGet, path)
+}
+
+// POST é um atalho para Request(http.MethodPost, path).
+func (ts *TestServer) POST(path string) *RequestBuilder {
+	return ts.Request(http.MethodPost, path)
+}
+
+// PUT é um atalho para Request(http.MethodPut, path).
+func (ts *TestServer) PUT(path string) *RequestBuilder {
+	return ts.Request(http.MethodPut, path)
+}
+
+// DELETE é um atalho para Request(http.MethodDelete, path).
+func (ts *TestServer) DELETE(path string) *RequestBuilder {
+	return ts.Request(http.MethodDelete, path)
+}
+
+// RequestBuilder monta requisições de teste de forma fluente.
+type RequestBuilder struct {
+	ts      *TestServer
+	method  string
+	path    string
+	headers http.Header
+	query   url.Values
+	body    io.Reader
+	err     error
+}
+
+// WithHeader adiciona um cabeçalho à requisição.
+func (b *RequestBuilder) WithHeader(key, value string) *RequestBuilder {
+	b.headers.Set(key, value)
+	return b
+}
+
+// WithQuery adiciona um parâmetro de query string à requisição.
+func (b *RequestBuilder) WithQuery(key, value string) *RequestBuilder {
+	b.query.Add(key, value)
+	return b
+}
+
+// WithBearerToken adiciona o cabeçalho Authorization com o token informado.
+func (b *RequestBuilder) WithBearerToken(token string) *RequestBuilder {
+	return b.WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithJSON serializa v como corpo JSON da requisição.
+func (b *RequestBuilder) WithJSON(v interface{}) *RequestBuilder {
+	data, err := json.Marshal(v)
+	if err != nil {
+		b.err = err
+		return b
+	}
+	b.body = bytes.NewReader(data)
+	return b.WithHeader("Content-Type", "application/json")
+}
+
+// WithBody define o corpo bruto da requisição e seu content-type.
+func (b *RequestBuilder) WithBody(contentType string, body []byte) *RequestBuilder {
+	b.body = bytes.NewReader(body)
+	return b.WithHeader("Content-Type", contentType)
+}
+
+// Do executa a requisição e devolve a resposta gravada. Falhas na montagem
+// da requisição encerram o teste.
+func (b *RequestBuilder) Do(t testing.TB) *Response {
+	t.Helper()
+
+	if b.err != nil {
+		t.Fatalf("servertest: failed to build request: %v", b.err)
+	}
+
+	target := b.path
+	if len(b.query) > 0 {
+		sep := "?"
+		if strings.Contains(target, "?") {
+			sep = "&"
+		}
+		target += sep + b.query.Encode()
+	}
+
+	req := httptest.NewRequest(b.method, target, b.body)
+	for key, values := range b.headers {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	b.ts.handler.ServeHTTP(rec, req)
+
+	return &Response{t: t, ResponseRecorder: rec}
+}
+
+// Response encapsula a resposta gravada e oferece asserções comuns.
+type Response struct {
+	t testing.TB
+	*httptest.ResponseRecorder
+}
+
+// Decode desserializa o corpo JSON da resposta em v, encerrando o teste em caso de erro.
+func (r *Response) Decode(v interface{}) *Response {
+	r.t.Helper()
+	if err := json.Unmarshal(r.Body.Bytes(), v); err != nil {
+		r.t.Fatalf("servertest: invalid JSON response: %v\nbody: %s", err, r.Body.String())
+	}
+	return r
+}
+
+// AssertStatus verifica o status HTTP da resposta.
+func (r *Response) AssertStatus(code int) *Response {
+	r.t.Helper()
+	if r.Code != code {
+		r.t.Errorf("servertest: expected status %d, got %d\nbody: %s", code, r.Code, r.Body.String())
+	}
+	return r
+}
+
+// AssertHeader verifica o valor de um cabeçalho da resposta.
+func (r *Response) AssertHeader(key, value string) *Response {
+	r.t.Helper()
+	if got := r.Header().Get(key); got != value {
+		r.t.Errorf("servertest: expected header %s=%q, got %q", key, value, got)
+	}
+	return r
+}
+
+// AssertJSON verifica se o corpo é semanticamente igual ao JSON de expected,
+// ignorando formatação e ordem das chaves.
+func (r *Response) AssertJSON(expected interface{}) *Response {
+	r.t.Helper()
+
+	want, err := normalize(expected, true)
+	if err != nil {
+		r.t.Fatalf("servertest: invalid expected JSON: %v", err)
+	}
+
+	var got interface{}
+	if err := json.Unmarshal(r.Body.Bytes(), &got); err != nil {
+		r.t.Fatalf("servertest: invalid JSON response: %v\nbody: %s", err, r.Body.String())
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		r.t.Errorf("servertest: JSON mismatch\nexpected: %s\ngot:      %s", mustJSON(want), r.Body.String())
+	}
+	return r
+}
+
+// AssertJSONPath verifica o valor no caminho informado (ex.: "data.items.0.id").
+func (r *Response) AssertJSONPath(path string, expected interface{}) *Response {
+	r.t.Helper()
+
+	var doc interface{}
+	if err := json.Unmarshal(r.Body.Bytes(), &doc); err != nil {
+		r.t.Fatalf("servertest: invalid JSON response: %v\nbody: %s", err, r.Body.String())
+	}
+
+	got, ok := lookup(doc, path)
+	if !ok {
+		r.t.Errorf("servertest: path %q not found\nbody: %s", path, r.Body.String())
+		return r
+	}
+
+	want, err := normalize(expected, false)
+	if err != nil {
+		r.t.Fatalf("servertest: invalid expected value: %v", err)
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		r.t.Errorf("servertest: path %q expected %s, got %s", path, mustJSON(want), mustJSON(got))
+	}
+	return r
+}
+
+// normalize converte v para a representação genérica produzida por json.Unmarshal.
+// Com raw verdadeiro, strings e []byte são tratados como JSON bruto.
+func normalize(v interface{}, raw bool) (interface{}, error) {
+	var data []byte
+	switch val := v.(type) {
+	case []byte:
+		if !raw {
+			return nil, errors.New("[]byte is only accepted as raw JSON")
+		}
+		data = val
+	case string:
+		if !raw {
+			return val, nil
+		}
+		data = []byte(val)
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+
+	var out interface{}
+	err := json.Unmarshal(data, &out)
+	return out, err
+}
+
+// lookup navega no documento JSON pelo caminho separado por pontos.
+func lookup(doc interface{}, path string) (interface{}, bool) {
+	current := doc
+	for _, key := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			var idx int
+			if err := json.Unmarshal([]byte(key), &idx); err != nil || idx < 0 || idx >= len(node) {
+				return nil, false
+			}
+			current = node[idx]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// mustJSON serializa v para mensagens de erro.
+func mustJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "<invalid>"
+	}
+	return string(data)
+}
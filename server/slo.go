@@ -0,0 +1,104 @@
+package server
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+)
+
+// sloKey é a chave do gin.Context onde o orçamento de latência da rota é armazenado.
+const sloKey = "server.slo"
+
+// SLOViolation descreve uma requisição que excedeu o orçamento de latência da rota.
+type SLOViolation struct {
+	Method   string
+	Route    string
+	Status   int
+	Budget   time.Duration
+	Duration time.Duration
+}
+
+// sloMetrics contabiliza as violações de orçamento por rota.
+type sloMetrics struct {
+	counters sync.Map
+	report   func(SLOViolation)
+}
+
+// WithSLO declara o orçamento de latência esperado para as rotas montadas por m.
+// Violações são contabilizadas automaticamente pelo middleware interno de métricas.
+func (m RouteMount) WithSLO(budget time.Duration) RouteMount {
+	return func(r gin.IRouter) {
+		m(r.Group("", SLO(budget)))
+	}
+}
+
+// SLO devolve um middleware que anota o orçamento de latência da rota ou grupo.
+func SLO(budget time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(sloKey, budget)
+		c.Next()
+	}
+}
+
+// OnSLOViolation registra uma função chamada a cada violação de orçamento,
+// além do contador server.slo.violation emitido pelo middleware.
+func (s *Server) OnSLOViolation(fn func(SLOViolation)) *Server {
+	s.slo.report = fn
+	return s
+}
+
+// SLOViolations devolve o total de violações de orçamento por "MÉTODO rota".
+func (s *Server) SLOViolations() map[string]int64 {
+	out := map[string]int64{}
+	s.slo.counters.Range(func(key, value interface{}) bool {
+		out[key.(string)] = value.(*atomic.Int64).Load()
+		return true
+	})
+	return out
+}
+
+// observe mede as requisições com orçamento declarado, contabilizando as violações
+// (métrica server.slo.violation) e anotando o span do Datadog com o orçamento e o resultado.
+func (m *sloMetrics) observe() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		value, ok := c.Get(sloKey)
+		if !ok {
+			return
+		}
+		budget := value.(time.Duration)
+		elapsed := time.Since(start)
+		violated := elapsed > budget
+
+		if span, ok := dd.SpanFromContext(c.Request.Context()); ok {
+			dd.SetSpanTag(span, "slo.budget_ms", budget.Milliseconds())
+			dd.SetSpanTag(span, "slo.violated", violated)
+		}
+
+		if !violated {
+			return
+		}
+
+		dd.Metrics().Incr("server.slo.violation", "method:"+c.Request.Method, "route:"+c.FullPath())
+
+		key := c.Request.Method + " " + c.FullPath()
+		counter, _ := m.counters.LoadOrStore(key, new(atomic.Int64))
+		counter.(*atomic.Int64).Add(1)
+
+		if m.report != nil {
+			m.report(SLOViolation{
+				Method:   c.Request.Method,
+				Route:    c.FullPath(),
+				Status:   c.Writer.Status(),
+				Budget:   budget,
+				Duration: elapsed,
+			})
+		}
+	}
+}
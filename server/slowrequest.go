@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// SlowRequest descreve uma requisição que ultrapassou o limite de latência.
+type SlowRequest struct {
+	Method        string        `json:"method"`
+	Route         string        `json:"route"`
+	Path          string        `json:"path"`
+	Status        int           `json:"status"`
+	Duration      time.Duration `json:"duration_ns"`
+	Threshold     time.Duration `json:"threshold_ns"`
+	CorrelationID string        `json:"correlation_id,omitempty"`
+}
+
+// SlowRequestOptions configura o alarme de requisições lentas.
+type SlowRequestOptions struct {
+	// Threshold é a latência a partir da qual a requisição é considerada lenta.
+	Threshold time.Duration
+	// TagSpan marca o span do Datadog da requisição com slow_request=true.
+	TagSpan bool
+	// OnSlow é chamado para cada requisição lenta; quando nil, a requisição é
+	// registrada no logger da requisição (logx.Ctx). A métrica
+	// server.request.slow é emitida em ambos os casos.
+	OnSlow func(SlowRequest)
+}
+
+// SlowRequestAlarm registra globalmente o alarme de requisições lentas.
+func (s *Server) SlowRequestAlarm(opts SlowRequestOptions) *Server {
+	return s.Middlewares(SlowRequests(opts))
+}
+
+// SlowRequests devolve um middleware que sinaliza requisições cuja latência
+// excede o limite configurado, informando o template da rota e o correlation ID.
+// Apenas observa a requisição, sem alterá-la, sendo seguro com hedging de clientes.
+func SlowRequests(opts SlowRequestOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		elapsed := time.Since(start)
+		if opts.Threshold <= 0 || elapsed < opts.Threshold {
+			return
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		if opts.TagSpan {
+			if span, ok := dd.SpanFromContext(c.Request.Context()); ok {
+				dd.SetSpanTag(span, "slow_request", true)
+				dd.SetSpanTag(span, "slow_request.threshold_ms", opts.Threshold.Milliseconds())
+			}
+		}
+
+		dd.Metrics().Incr("server.request.slow", "method:"+c.Request.Method, "route:"+route)
+
+		slow := SlowRequest{
+			Method:        c.Request.Method,
+			Route:         route,
+			Path:          c.Request.URL.Path,
+			Status:        c.Writer.Status(),
+			Duration:      elapsed,
+			Threshold:     opts.Threshold,
+			CorrelationID: c.Writer.Header().Get("x-itau-correlation-id"),
+		}
+		if opts.OnSlow != nil {
+			opts.OnSlow(slow)
+			return
+		}
+		logSlowRequest(c.Request.Context(), slow)
+	}
+}
+
+// logSlowRequest registra a requisição lenta no logger da requisição, que já
+// carrega o correlation id e o trace.
+func logSlowRequest(ctx context.Context, r SlowRequest) {
+	logx.Ctx(ctx).Warn("slow request",
+		"method", r.Method,
+		"route", r.Route,
+		"path", r.Path,
+		"status", r.Status,
+		"duration_ns", r.Duration,
+		"threshold_ns", r.Threshold,
+	)
+}
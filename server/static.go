@@ -0,0 +1,124 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+const (
+	// staticCacheControl é aplicado aos arquivos estáticos versionados (js, css, imagens).
+	staticCacheControl = "public, max-age=86400"
+	// indexCacheControl evita que o index.html do SPA fique preso em cache após um deploy.
+	indexCacheControl = "no-cache"
+)
+
+// spaConfig guarda a configuração de um frontend servido como Single Page Application.
+type spaConfig struct {
+	prefix string
+	fsys   fs.FS
+	index  string
+}
+
+// Static serve os arquivos do diretório dir sob o prefixo informado.
+func (s *Server) Static(prefix, dir string) *Server {
+	return s.StaticFS(prefix, os.DirFS(dir))
+}
+
+// StaticFS serve os arquivos de fsys (ex.: embed.FS) sob o prefixo informado,
+// com cabeçalhos de cache adequados para assets.
+func (s *Server) StaticFS(prefix string, fsys fs.FS) *Server {
+	return s.Routes(func(r gin.IRouter) {
+		handler := func(c *gin.Context) {
+			name := strings.TrimPrefix(path.Clean("/"+c.Param("filepath")), "/")
+			if !serveFile(c, fsys, name, staticCacheControl) {
+				Fail(c, errx.New("file not found").WithCode(errx.NOT_FOUND))
+			}
+		}
+
+		g := r.Group(prefix)
+		g.GET("/*filepath", handler)
+		g.HEAD("/*filepath", handler)
+	})
+}
+
+// SPA serve um frontend a partir do diretório dist sob o prefixo informado.
+// Caminhos que não correspondem a um arquivo devolvem o index para que o
+// roteamento seja resolvido no cliente.
+func (s *Server) SPA(prefix, dist, index string) *Server {
+	return s.SPAFS(prefix, os.DirFS(dist), index)
+}
+
+// SPAFS é a variante de SPA que recebe um fs.FS (ex.: embed.FS via fs.Sub).
+func (s *Server) SPAFS(prefix string, fsys fs.FS, index string) *Server {
+	s.spa = &spaConfig{prefix: prefix, fsys: fsys, index: index}
+	return s
+}
+
+// spaHandler responde as rotas não encontradas com os arquivos do SPA ou com o index.
+func (s *Server) spaHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		spa := s.spa
+		reqPath := c.Request.URL.Path
+
+		if (c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead) ||
+			!hasPathPrefix(reqPath, spa.prefix) {
+			return
+		}
+
+		name := strings.TrimPrefix(path.Clean("/"+strings.TrimPrefix(reqPath, spa.prefix)), "/")
+		if name != "" && name != spa.index && serveFile(c, spa.fsys, name, staticCacheControl) {
+			c.Abort()
+			return
+		}
+
+		if serveFile(c, spa.fsys, spa.index, indexCacheControl) {
+			c.Abort()
+		}
+	}
+}
+
+// hasPathPrefix informa se p está sob prefix comparando segmentos inteiros,
+// para que "/app" não corresponda a "/application".
+func hasPathPrefix(p, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return prefix == "" || p == prefix || strings.HasPrefix(p, prefix+"/")
+}
+
+// serveFile envia o arquivo name de fsys, informando se ele existia e pôde ser servido.
+func serveFile(c *gin.Context, fsys fs.FS, name, cacheControl string) bool {
+	if name == "" {
+		return false
+	}
+
+	f, err := fsys.Open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		return false
+	}
+
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return false
+		}
+		content = bytes.NewReader(data)
+	}
+
+	c.Header("Cache-Control", cacheControl)
+	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), content)
+	return true
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// streamFlushEvery é a quantidade de linhas escritas entre cada flush para o cliente.
+const streamFlushEvery = 100
+
+// StreamCSV escreve uma exportação CSV linha a linha, sem manter o conjunto em
+// memória. next devolve a próxima linha e io.EOF ao final. As escritas
+// bloqueiam enquanto o cliente não consome a resposta, e o envio é
+// interrompido quando a requisição é cancelada.
+//
+// Um erro antes da primeira linha é respondido por Fail; depois disso, o
+// status já foi enviado e a resposta é apenas encerrada, com o erro registrado
+// em log e devolvido.
+//
+//	c.Header("Content-Disposition", `attachment; filename="orders.csv"`)
+//	server.StreamCSV(c, []string{"id", "total"}, func() ([]string, error) {
+//		if !rows.Next() {
+//			return nil, io.EOF
+//		}
+//		...
+//	})
+func StreamCSV(c *gin.Context, header []string, next func() ([]string, error)) error {
+	var w *csv.Writer
+	return stream(c, "text/csv; charset=utf-8", func(out io.Writer) error {
+		w = csv.NewWriter(out)
+		if len(header) > 0 {
+			return w.Write(header)
+		}
+		return nil
+	}, func() error {
+		row, err := next()
+		if err != nil {
+			return err
+		}
+		return w.Write(row)
+	}, func() error {
+		w.Flush()
+		return w.Error()
+	})
+}
+
+// StreamNDJSON escreve uma exportação em JSON delimitado por linhas
+// (application/x-ndjson), um item por linha, com as mesmas garantias de
+// StreamCSV. next devolve o próximo item e io.EOF ao final.
+func StreamNDJSON[T any](c *gin.Context, next func() (T, error)) error {
+	var enc *json.Encoder
+	return stream(c, "application/x-ndjson", func(out io.Writer) error {
+		enc = json.NewEncoder(out)
+		return nil
+	}, func() error {
+		item, err := next()
+		if err != nil {
+			return err
+		}
+		return enc.Encode(item)
+	}, func() error {
+		return nil
+	})
+}
+
+// stream conduz a escrita incremental: start prepara o encoder, write escreve
+// uma linha (io.EOF encerra) e flush descarrega o encoder.
+func stream(c *gin.Context, contentType string, start func(io.Writer) error, write func() error, flush func() error) error {
+	ctx := c.Request.Context()
+
+	// A primeira linha é obtida antes de enviar o status, para que falhas
+	// imediatas (ex.: consulta inválida) ainda resultem em uma resposta de erro.
+	buf := bufio.NewWriterSize(c.Writer, 32<<10)
+	if err := start(buf); err != nil {
+		Fail(c, err)
+		return err
+	}
+	err := write()
+	if err != nil && !errors.Is(err, io.EOF) {
+		Fail(c, err)
+		return err
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(200)
+
+	for rows := 1; err == nil; rows++ {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+			break
+		}
+		if rows%streamFlushEvery == 0 {
+			if err = flushStream(c, buf, flush); err != nil {
+				break
+			}
+		}
+		err = write()
+	}
+
+	if errors.Is(err, io.EOF) {
+		return flushStream(c, buf, flush)
+	}
+
+	logx.Ctx(ctx).Warn("response stream interrupted", "error", err)
+	_ = flushStream(c, buf, flush)
+	_ = c.Error(err)
+	c.Abort()
+	return err
+}
+
+// flushStream descarrega o encoder e o buffer e envia os bytes ao cliente.
+func flushStream(c *gin.Context, buf *bufio.Writer, flush func() error) error {
+	if err := flush(); err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+	return nil
+}
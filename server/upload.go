@@ -0,0 +1,353 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/idgen"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// UploadStore é o destino dos arquivos enviados (S3, disco local etc.).
+// Put recebe o conteúdo em streaming, sem que o arquivo seja mantido em memória.
+type UploadStore interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+}
+
+// UploadOptions configura BindUpload.
+type UploadOptions struct {
+	// Store recebe os arquivos; obrigatório.
+	Store UploadStore
+	// Field é o campo do formulário com os arquivos; quando vazio, assume "file".
+	Field string
+	// MaxFiles limita a quantidade de arquivos; quando zero, assume 1.
+	MaxFiles int
+	// MaxSize limita o tamanho de cada arquivo; quando zero, assume 10 MiB.
+	MaxSize int64
+	// MaxValues limita a quantidade de campos que não são arquivos; quando zero, assume 100.
+	MaxValues int
+	// MaxValuesSize limita o tamanho somado desses campos; quando zero, assume 1 MiB.
+	MaxValuesSize int64
+	// AllowedTypes lista os Content-Types aceitos (ex.: "application/pdf",
+	// "image/*"), detectados pelo conteúdo; quando vazio, aceita qualquer tipo.
+	AllowedTypes []string
+	// Key gera a chave do arquivo no store; quando nil, usa um UUIDv7 com a extensão original.
+	Key func(c *gin.Context, filename string) string
+	// Scan inspeciona o conteúdo em paralelo ao envio (ex.: antivírus) e deve
+	// ler r até o fim; um erro remove o arquivo do store e rejeita o upload.
+	Scan func(ctx context.Context, file UploadedFile, r io.Reader) error
+}
+
+// UploadedFile descreve um arquivo armazenado por BindUpload.
+type UploadedFile struct {
+	Field       string `json:"field"`
+	Filename    string `json:"filename"`
+	Key         string `json:"key"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256"`
+}
+
+// Upload é o resultado de BindUpload: os arquivos armazenados e os demais campos do formulário.
+type Upload struct {
+	Files  []UploadedFile    `json:"files"`
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// errUploadTooLarge interrompe a leitura de arquivos acima de MaxSize.
+var errUploadTooLarge = errors.New("server: upload too large")
+
+// BindUpload lê o corpo multipart/form-data em streaming, validando tamanho e
+// tipo de cada arquivo e enviando-o diretamente ao store. Em caso de erro, os
+// arquivos já armazenados na requisição são removidos e o erro devolvido é uma
+// AppError pronta para Fail.
+//
+//	upload, err := server.BindUpload(c, server.UploadOptions{Store: store, AllowedTypes: []string{"application/pdf"}})
+//	if err != nil {
+//		server.Fail(c, err)
+//		return
+//	}
+func BindUpload(c *gin.Context, opts UploadOptions) (*Upload, error) {
+	if opts.Store == nil {
+		return nil, errx.New("upload store not configured").WithCode(errx.INTERNAL)
+	}
+	if opts.Field == "" {
+		opts.Field = "file"
+	}
+	if opts.MaxFiles <= 0 {
+		opts.MaxFiles = 1
+	}
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = 10 << 20
+	}
+	if opts.MaxValues <= 0 {
+		opts.MaxValues = 100
+	}
+	if opts.MaxValuesSize <= 0 {
+		opts.MaxValuesSize = defaultMaxBody
+	}
+	if opts.Key == nil {
+		opts.Key = func(_ *gin.Context, filename string) string {
+			return idgen.UUIDv7() + strings.ToLower(path.Ext(filename))
+		}
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, errx.New("invalid multipart request").WithCode(errx.BAD_REQUEST)
+	}
+
+	ctx := c.Request.Context()
+	upload := &Upload{Values: map[string]string{}}
+	valuesLeft := opts.MaxValuesSize
+	values := 0
+	fail := func(err error) (*Upload, error) {
+		for _, file := range upload.Files {
+			if derr := opts.Store.Delete(ctx, file.Key); derr != nil {
+				logx.Ctx(ctx).Warn("failed to remove upload", "key", file.Key, "error", derr)
+			}
+		}
+		return nil, err
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fail(errx.New("invalid multipart request").WithCode(errx.BAD_REQUEST))
+		}
+
+		if part.FileName() == "" {
+			if values++; values > opts.MaxValues {
+				part.Close()
+				return fail(errx.New("too many form values").
+					WithCode(errx.BAD_REQUEST).
+					WithDetails(map[string]interface{}{"max_values": opts.MaxValues}))
+			}
+			value, err := io.ReadAll(io.LimitReader(part, valuesLeft+1))
+			part.Close()
+			if err != nil {
+				return fail(errx.New("invalid multipart request").WithCode(errx.BAD_REQUEST))
+			}
+			if valuesLeft -= int64(len(value)); valuesLeft < 0 {
+				return fail(errx.New("form values too large").
+					WithCode(errx.PAYLOAD_TOO_LARGE).
+					WithDetails(map[string]interface{}{"max_values_size": opts.MaxValuesSize}))
+			}
+			upload.Values[part.FormName()] = string(value)
+			continue
+		}
+		if part.FormName() != opts.Field {
+			part.Close()
+			continue
+		}
+		if len(upload.Files) == opts.MaxFiles {
+			part.Close()
+			return fail(errx.New("too many files").
+				WithCode(errx.BAD_REQUEST).
+				WithDetails(map[string]interface{}{"max_files": opts.MaxFiles}))
+		}
+
+		file, err := storePart(c, opts, part)
+		part.Close()
+		if err != nil {
+			return fail(err)
+		}
+		upload.Files = append(upload.Files, file)
+	}
+
+	return upload, nil
+}
+
+// storePart valida e envia um arquivo ao store, calculando tamanho e SHA-256.
+func storePart(c *gin.Context, opts UploadOptions, part *multipart.Part) (UploadedFile, error) {
+	ctx := c.Request.Context()
+	filename := filepath.Base(part.FileName())
+
+	// O tipo é detectado pelo conteúdo, sem confiar no cabeçalho enviado pelo cliente.
+	buffered := bufio.NewReaderSize(part, 512)
+	head, _ := buffered.Peek(512)
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if !allowedType(contentType, opts.AllowedTypes) {
+		return UploadedFile{}, errx.New("unsupported file type").
+			WithCode(errx.BAD_REQUEST).
+			WithDetails(map[string]interface{}{"filename": filename, "content_type": contentType})
+	}
+
+	file := UploadedFile{
+		Field:       opts.Field,
+		Filename:    filename,
+		Key:         opts.Key(c, filename),
+		ContentType: contentType,
+	}
+
+	hash := sha256.New()
+	limited := &uploadReader{r: buffered, max: opts.MaxSize}
+	var body io.Reader = io.TeeReader(limited, hash)
+
+	var scanned chan error
+	var pw *io.PipeWriter
+	var scanInput *scanReader
+	if opts.Scan != nil {
+		var pr *io.PipeReader
+		pr, pw = io.Pipe()
+		scanInput = &scanReader{r: pr}
+		scanned = make(chan error, 1)
+		go func() {
+			err := opts.Scan(ctx, file, scanInput)
+			pr.CloseWithError(err)
+			scanned <- err
+		}()
+		body = io.TeeReader(body, pw)
+	}
+
+	err := opts.Store.Put(ctx, file.Key, body, contentType)
+	if pw != nil {
+		pw.CloseWithError(err)
+		// A rejeição do scan tem precedência sobre a falha do envio que ela
+		// provoca ao fechar o pipe. Só quando o scan falhou por ter recebido a
+		// falha do envio pelo pipe, o erro é tratado como falha do store.
+		if scanErr := <-scanned; scanErr != nil && !scanInput.aborted {
+			logx.Ctx(ctx).Warn("upload rejected by scan", "filename", filename, "error", scanErr)
+			err = errx.New("file rejected by content scan").
+				WithCode(errx.BAD_REQUEST).
+				WithDetails(map[string]interface{}{"filename": filename})
+		}
+	}
+	if err != nil {
+		if derr := opts.Store.Delete(ctx, file.Key); derr != nil {
+			logx.Ctx(ctx).Warn("failed to remove upload", "key", file.Key, "error", derr)
+		}
+		switch {
+		case errx.IsAppError(err):
+			return UploadedFile{}, err
+		case errors.Is(err, errUploadTooLarge):
+			return UploadedFile{}, errx.New("file too large").
+				WithCode(errx.PAYLOAD_TOO_LARGE).
+				WithDetails(map[string]interface{}{"filename": filename, "max_size": opts.MaxSize})
+		}
+		logx.Ctx(ctx).Error("failed to store upload", "key", file.Key, "error", err)
+		return UploadedFile{}, errx.New("failed to store upload").WithCode(errx.INTERNAL)
+	}
+
+	file.Size = limited.n
+	file.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return file, nil
+}
+
+// allowedType informa se o Content-Type é aceito, suportando curingas como "image/*".
+func allowedType(contentType string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, pattern := range allowed {
+		if pattern == contentType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok && strings.HasPrefix(contentType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// scanReader registra se a leitura do scan foi interrompida pela falha do envio
+// (o pipe só devolve um erro diferente de io.EOF quando o envio o fecha com erro).
+type scanReader struct {
+	r       io.Reader
+	aborted bool
+}
+
+func (s *scanReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	if err != nil && err != io.EOF {
+		s.aborted = true
+	}
+	return n, err
+}
+
+// uploadReader conta os bytes lidos e falha ao ultrapassar max.
+type uploadReader struct {
+	r   io.Reader
+	n   int64
+	max int64
+}
+
+func (u *uploadReader) Read(p []byte) (int, error) {
+	n, err := u.r.Read(p)
+	u.n += int64(n)
+	if u.n > u.max {
+		return n, errUploadTooLarge
+	}
+	return n, err
+}
+
+// LocalUploadStore é um UploadStore em disco, útil em desenvolvimento e em
+// volumes compartilhados.
+type LocalUploadStore struct {
+	dir string
+}
+
+// NewLocalUploadStore cria um UploadStore que grava os arquivos em dir.
+func NewLocalUploadStore(dir string) *LocalUploadStore {
+	return &LocalUploadStore{dir: dir}
+}
+
+// Put implementa UploadStore.
+func (l *LocalUploadStore) Put(_ context.Context, key string, r io.Reader, _ string) error {
+	target, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("server: create upload dir: %w", err)
+	}
+
+	f, err := os.Create(target)
+	if err != nil {
+		return fmt.Errorf("server: create upload: %w", err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Delete implementa UploadStore.
+func (l *LocalUploadStore) Delete(_ context.Context, key string) error {
+	target, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("server: remove upload: %w", err)
+	}
+	return nil
+}
+
+// path resolve a chave dentro do diretório, impedindo que ela escape dele.
+func (l *LocalUploadStore) path(key string) (string, error) {
+	target := filepath.Join(l.dir, filepath.FromSlash(path.Clean("/"+key)))
+	if !strings.HasPrefix(target, filepath.Clean(l.dir)+string(filepath.Separator)) {
+		return "", fmt.Errorf("server: invalid upload key %q", key)
+	}
+	return target, nil
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// ValidateRequests registra globalmente o middleware de validação de
+// requisições contra o documento OpenAPI informado (JSON ou YAML).
+// Um documento inválido interrompe a configuração com panic, pois indica erro de build.
+func (s *Server) ValidateRequests(spec []byte) *Server {
+	middleware, err := OpenAPIValidator(spec)
+	if err != nil {
+		panic(err)
+	}
+	return s.Middlewares(middleware)
+}
+
+// OpenAPIValidator devolve um middleware que valida parâmetros e corpo das
+// requisições contra o documento OpenAPI, respondendo 400 no formato errx
+// em caso de violação. Rotas ausentes do documento não são validadas.
+func OpenAPIValidator(spec []byte) (gin.HandlerFunc, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, errx.New("failed to load OpenAPI document").WithCode(errx.INTERNAL).WithError(err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, errx.New("invalid OpenAPI document").WithCode(errx.INTERNAL).WithError(err)
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, errx.New("failed to build OpenAPI router").WithCode(errx.INTERNAL).WithError(err)
+	}
+
+	options := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		MultiError:         true,
+	}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := router.FindRoute(c.Request)
+		if err != nil {
+			if errors.Is(err, routers.ErrPathNotFound) || errors.Is(err, routers.ErrMethodNotAllowed) {
+				c.Next()
+				return
+			}
+			Fail(c, errx.New("invalid request").WithCode(errx.BAD_REQUEST).WithError(err))
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			Fail(c, errx.New("request does not match the API contract").
+				WithCode(errx.BAD_REQUEST).
+				WithDetails(map[string]interface{}{"violations": violations(err)}))
+			return
+		}
+
+		c.Next()
+	}, nil
+}
+
+// ValidateWith valida as requisições contra o documento gerado pelo registro
+// OpenAPI. Como as rotas são registradas apenas no Run, o validador é construído
+// na primeira requisição, quando o documento já está completo.
+func (s *Server) ValidateWith(doc *OpenAPI) *Server {
+	var (
+		once      sync.Once
+		validator gin.HandlerFunc
+	)
+
+	return s.Middlewares(func(c *gin.Context) {
+		once.Do(func() {
+			spec, err := json.Marshal(doc.Document())
+			if err == nil {
+				validator, err = OpenAPIValidator(spec)
+			}
+			if err != nil {
+				logx.L().Error("OpenAPI validation disabled", "error", err)
+			}
+		})
+
+		if validator == nil {
+			c.Next()
+			return
+		}
+		validator(c)
+	})
+}
+
+// violations converte o erro de validação em uma lista de mensagens curtas,
+// no formato "<ponteiro JSON>: <motivo>" para erros de schema.
+func violations(err error) []string {
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		out := []string{}
+		for _, e := range multi {
+			out = append(out, violations(e)...)
+		}
+		return out
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		return []string{"/" + strings.Join(schemaErr.JSONPointer(), "/") + ": " + schemaErr.Reason}
+	}
+
+	var reqErr *openapi3filter.RequestError
+	if errors.As(err, &reqErr) && reqErr.Err != nil {
+		var nested openapi3.MultiError
+		if errors.As(reqErr.Err, &nested) {
+			return violations(nested)
+		}
+	}
+
+	return []string{err.Error()}
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// versionKey é a chave usada para armazenar a versão negociada no gin.Context.
+const versionKey = "server.version"
+
+// versionCtxKey é o tipo da chave da versão no context.Context da requisição.
+type versionCtxKey struct{}
+
+// VersionOptions configura a negociação de versão pelo media type.
+type VersionOptions struct {
+	// Vendor é o nome usado no media type application/vnd.<vendor>.v<N>+json.
+	Vendor string
+	// Default é a versão usada quando o cliente não pede uma; quando zero, assume 1.
+	Default int
+}
+
+// Versioning registra globalmente a negociação de versão pelo cabeçalho Accept.
+func (s *Server) Versioning(opts VersionOptions) *Server {
+	return s.Middlewares(NegotiateVersion(opts))
+}
+
+// NegotiateVersion devolve um middleware que resolve a versão pedida no
+// cabeçalho Accept (ex.: application/vnd.company.v2+json), disponibilizando-a
+// em APIVersion e VersionFromContext. Respostas JSON recebem o Content-Type da
+// versão negociada e Vary: Accept, complementando o versionamento por path.
+func NegotiateVersion(opts VersionOptions) gin.HandlerFunc {
+	if opts.Default <= 0 {
+		opts.Default = 1
+	}
+	pattern := regexp.MustCompile(`^application/vnd\.` + regexp.QuoteMeta(strings.ToLower(opts.Vendor)) + `\.v([0-9]+)\+json$`)
+
+	return func(c *gin.Context) {
+		version := opts.Default
+		for _, accepted := range strings.Split(c.GetHeader("Accept"), ",") {
+			mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+			if err != nil {
+				continue
+			}
+			if m := pattern.FindStringSubmatch(mediaType); m != nil {
+				version, _ = strconv.Atoi(m[1])
+				break
+			}
+		}
+
+		c.Set(versionKey, version)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), versionCtxKey{}, version))
+		c.Header("Vary", "Accept")
+
+		w := &versionWriter{
+			ResponseWriter: c.Writer,
+			mediaType:      fmt.Sprintf("application/vnd.%s.v%d+json; charset=utf-8", strings.ToLower(opts.Vendor), version),
+		}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+	}
+}
+
+// versionWriter troca o Content-Type das respostas JSON de sucesso pelo media type da versão negociada.
+type versionWriter struct {
+	gin.ResponseWriter
+	mediaType string
+	done      bool
+}
+
+// rewrite ajusta o Content-Type antes do envio dos cabeçalhos.
+func (w *versionWriter) rewrite() {
+	if w.done {
+		return
+	}
+	w.done = true
+	// Erros mantêm o envelope padrão, independente da versão.
+	if w.Status() < 400 && strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		w.Header().Set("Content-Type", w.mediaType)
+	}
+}
+
+func (w *versionWriter) WriteHeaderNow() {
+	w.rewrite()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *versionWriter) Write(data []byte) (int, error) {
+	w.rewrite()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *versionWriter) WriteString(s string) (int, error) {
+	w.rewrite()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// APIVersion devolve a versão negociada para a requisição ou zero se o middleware não estiver ativo.
+func APIVersion(c *gin.Context) int {
+	return c.GetInt(versionKey)
+}
+
+// VersionFromContext devolve a versão negociada armazenada no context.Context da requisição.
+func VersionFromContext(ctx context.Context) int {
+	version, _ := ctx.Value(versionCtxKey{}).(int)
+	return version
+}
+
+// Versioned devolve um handler que despacha para a implementação da versão
+// negociada por NegotiateVersion. Versões sem handler são rejeitadas com 400,
+// informando as versões suportadas. Para mudanças pequenas, prefira um único
+// handler que adapte o payload conforme APIVersion.
+//
+//	r.GET("/users/:id", server.Versioned(map[int]gin.HandlerFunc{1: getUserV1, 2: getUserV2}))
+func Versioned(handlers map[int]gin.HandlerFunc) gin.HandlerFunc {
+	supported := make([]int, 0, len(handlers))
+	for version := range handlers {
+		supported = append(supported, version)
+	}
+	sort.Ints(supported)
+
+	return func(c *gin.Context) {
+		handler, ok := handlers[APIVersion(c)]
+		if !ok {
+			Fail(c, errx.New("unsupported API version").
+				WithCode(errx.BAD_REQUEST).
+				WithDetails(map[string]interface{}{
+					"version":   APIVersion(c),
+					"supported": supported,
+				}))
+			return
+		}
+		handler(c)
+	}
+}
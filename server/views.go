@@ -0,0 +1,38 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	ginrender "github.com/gin-gonic/gin/render"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// Views define o renderizador das páginas HTML usadas por HTML (ex.: um
+// *render.Renderer), para as ferramentas internas que servem HTML.
+func (s *Server) Views(renderer ginrender.HTMLRender) *Server {
+	s.views = renderer
+	return s
+}
+
+// HTML responde 200 com a view renderizada com data.
+func HTML(c *gin.Context, view string, data interface{}) {
+	HTMLStatus(c, http.StatusOK, view, data)
+}
+
+// HTMLStatus responde com o status e a view informados. Falhas de
+// renderização respondem como Fail, pois a view é renderizada antes do envio.
+func HTMLStatus(c *gin.Context, status int, view string, data interface{}) {
+	if c.Writer.Written() {
+		return
+	}
+
+	errs := len(c.Errors)
+	c.HTML(status, view, data)
+	if len(c.Errors) > errs && !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		logx.Ctx(c.Request.Context()).Error("Failed to render view", "view", view, "error", c.Errors.Last().Err)
+		Fail(c, errx.New("failed to render view").WithCode(errx.INTERNAL))
+	}
+}
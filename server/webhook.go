@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/cryptox"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"github.com/nathanribeiroo/module-dep-projects/secrets"
+)
+
+// WebhookSecretProvider devolve as chaves HMAC aceitas na verificação; mais de
+// uma chave permite a rotação sem rejeitar entregas assinadas com a anterior.
+type WebhookSecretProvider func(ctx context.Context) ([]string, error)
+
+// WebhookSecret devolve um provedor com chaves fixas.
+func WebhookSecret(keys ...string) WebhookSecretProvider {
+	return func(context.Context) ([]string, error) {
+		return keys, nil
+	}
+}
+
+// WebhookSecretRef devolve um provedor que lê as chaves do secrets.Default
+// (ex.: "vault:webhooks/github#secret"), com o cache do próprio Store.
+func WebhookSecretRef(refs ...string) WebhookSecretProvider {
+	return func(ctx context.Context) ([]string, error) {
+		keys := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			key, err := secrets.Get(ctx, ref)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		return keys, nil
+	}
+}
+
+// WebhookVerify registra globalmente a verificação de assinatura HMAC.
+// Para restringir a rotas de webhook, use VerifyWebhook no grupo.
+func (s *Server) WebhookVerify(provider WebhookSecretProvider, header string) *Server {
+	return s.Middlewares(VerifyWebhook(provider, header))
+}
+
+// webhookTolerance é a diferença máxima aceita entre o timestamp assinado
+// ("t=" no formato do Stripe) e o relógio do servidor.
+const webhookTolerance = 5 * time.Minute
+
+// VerifyWebhook devolve um middleware que valida a assinatura HMAC-SHA256 do
+// corpo enviada no cabeçalho informado, aceitando os formatos do GitHub
+// ("sha256=<hex>") e do Stripe ("t=<ts>,v1=<hex>", assinando "<ts>.<corpo>"),
+// além do hexadecimal puro. A comparação é feita em tempo constante e
+// assinaturas ausentes ou inválidas são rejeitadas com 401, assim como
+// timestamps assinados a mais de 5 minutos do relógio do servidor.
+// Para proteção contra repetição com nonce, use RejectReplays.
+//
+//	r.POST("/webhooks/github", server.VerifyWebhook(server.WebhookSecret(key), "X-Hub-Signature-256"), handler)
+func VerifyWebhook(provider WebhookSecretProvider, header string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		signature := c.GetHeader(header)
+		if signature == "" {
+			Fail(c, errx.New("missing webhook signature").WithCode(errx.UNAUTHORIZED))
+			return
+		}
+
+		body, err := Body(c)
+		if err != nil {
+			if errors.Is(err, ErrBodyTooLarge) {
+				Fail(c, errx.New("request body too large").WithCode(errx.PAYLOAD_TOO_LARGE))
+				return
+			}
+			logx.Ctx(c.Request.Context()).Warn("failed to read webhook body", "error", err)
+			Fail(c, errx.New("failed to read request body").WithCode(errx.BAD_REQUEST))
+			return
+		}
+
+		keys, err := provider(c.Request.Context())
+		if err != nil {
+			logx.Ctx(c.Request.Context()).Error("failed to load webhook secret", "error", err)
+			Fail(c, errx.New("internal server error").WithCode(errx.INTERNAL))
+			return
+		}
+
+		payload, timestamp, candidates := parseSignature(signature, body)
+		for _, key := range keys {
+			for _, candidate := range candidates {
+				if !cryptox.Verify([]byte(key), payload, candidate) {
+					continue
+				}
+				if timestamp != "" {
+					sent, ok := parseTimestamp(timestamp)
+					if skew := time.Since(sent); !ok || skew > webhookTolerance || skew < -webhookTolerance {
+						Fail(c, errx.New("webhook timestamp outside the allowed window").WithCode(errx.UNAUTHORIZED))
+						return
+					}
+				}
+				c.Next()
+				return
+			}
+		}
+
+		Fail(c, errx.New("invalid webhook signature").WithCode(errx.UNAUTHORIZED))
+	}
+}
+
+// parseSignature devolve o conteúdo assinado, o timestamp assinado (apenas no
+// formato do Stripe) e as assinaturas candidatas do cabeçalho.
+func parseSignature(header string, body []byte) ([]byte, string, []string) {
+	if !strings.Contains(header, "v1=") {
+		return body, "", []string{strings.TrimPrefix(strings.TrimSpace(header), "sha256=")}
+	}
+
+	var timestamp string
+	var candidates []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			candidates = append(candidates, value)
+		}
+	}
+
+	// Sem o timestamp, a assinatura do Stripe não é aceita.
+	if timestamp == "" {
+		return nil, "", nil
+	}
+
+	payload := make([]byte, 0, len(timestamp)+1+len(body))
+	payload = append(payload, timestamp...)
+	payload = append(payload, '.')
+	payload = append(payload, body...)
+	return payload, timestamp, candidates
+}
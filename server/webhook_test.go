@@ -0,0 +1,65 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/cryptox"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const (
+		key     = "whsec_test"
+		rotated = "whsec_previous"
+		body    = `{"id":"evt_1"}`
+	)
+	stripe := func(key string, sent time.Time) string {
+		ts := strconv.FormatInt(sent.Unix(), 10)
+		return "t=" + ts + ",v1=" + cryptox.Sign([]byte(key), []byte(ts+"."+body))
+	}
+
+	tests := []struct {
+		name       string
+		header     string
+		signature  string
+		wantStatus int
+	}{
+		{name: "github", header: "X-Hub-Signature-256", signature: "sha256=" + cryptox.Sign([]byte(key), []byte(body)), wantStatus: http.StatusOK},
+		{name: "github with previous key", header: "X-Hub-Signature-256", signature: "sha256=" + cryptox.Sign([]byte(rotated), []byte(body)), wantStatus: http.StatusOK},
+		{name: "plain hex", header: "X-Signature", signature: cryptox.Sign([]byte(key), []byte(body)), wantStatus: http.StatusOK},
+		{name: "stripe", header: "Stripe-Signature", signature: stripe(key, time.Now()), wantStatus: http.StatusOK},
+		{name: "stripe with extra v1", header: "Stripe-Signature", signature: stripe(key, time.Now()) + ",v1=deadbeef", wantStatus: http.StatusOK},
+		{name: "stripe stale timestamp", header: "Stripe-Signature", signature: stripe(key, time.Now().Add(-10*time.Minute)), wantStatus: http.StatusUnauthorized},
+		{name: "stripe future timestamp", header: "Stripe-Signature", signature: stripe(key, time.Now().Add(10*time.Minute)), wantStatus: http.StatusUnauthorized},
+		{name: "stripe without timestamp", header: "Stripe-Signature", signature: "v1=" + cryptox.Sign([]byte(key), []byte(body)), wantStatus: http.StatusUnauthorized},
+		{name: "wrong key", header: "X-Hub-Signature-256", signature: "sha256=" + cryptox.Sign([]byte("other"), []byte(body)), wantStatus: http.StatusUnauthorized},
+		{name: "missing signature", header: "X-Hub-Signature-256", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/webhook", VerifyWebhook(WebhookSecret(key, rotated), tt.header), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+			if tt.signature != "" {
+				req.Header.Set(tt.header, tt.signature)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package tenant
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// Selector escolhe um recurso por tenant (ex.: o *db.DB de cada banco),
+// abrindo-o na primeira vez em que o tenant é usado e reaproveitando-o depois.
+//
+//	databases := tenant.NewSelector(func(ctx context.Context, id string) (*db.DB, error) {
+//		return db.Open(ctx, db.Options{Driver: db.Postgres, DSN: dsnFor(id)})
+//	})
+//	server.N().OnStop(func(ctx context.Context) error {
+//		return databases.Close(func(_ string, d *db.DB) error { return d.Close() })
+//	})
+//
+//	database, err := databases.Get(ctx)
+type Selector[T any] struct {
+	open func(ctx context.Context, id string) (T, error)
+
+	mu      sync.Mutex
+	entries map[string]*entry[T]
+}
+
+// entry é um recurso aberto ou em abertura.
+type entry[T any] struct {
+	ready    chan struct{}
+	resource T
+	err      error
+}
+
+// NewSelector cria o Selector com a função que abre o recurso de um tenant.
+func NewSelector[T any](open func(ctx context.Context, id string) (T, error)) *Selector[T] {
+	return &Selector[T]{open: open, entries: map[string]*entry[T]{}}
+}
+
+// Get devolve o recurso do tenant do contexto, respondendo 400 quando não há tenant.
+func (s *Selector[T]) Get(ctx context.Context) (T, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		var zero T
+		return zero, errx.New("tenant not identified").WithCode(errx.BAD_REQUEST)
+	}
+	return s.For(ctx, id)
+}
+
+// For devolve o recurso do tenant informado. Aberturas concorrentes do mesmo
+// tenant são unificadas; falhas não ficam em cache e são tentadas de novo.
+func (s *Selector[T]) For(ctx context.Context, id string) (T, error) {
+	s.mu.Lock()
+	e, ok := s.entries[id]
+	if !ok {
+		e = &entry[T]{ready: make(chan struct{})}
+		s.entries[id] = e
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		e.resource, e.err = s.open(ctx, id)
+		if e.err != nil {
+			s.mu.Lock()
+			delete(s.entries, id)
+			s.mu.Unlock()
+		}
+		close(e.ready)
+	}
+
+	select {
+	case <-e.ready:
+		return e.resource, e.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Close fecha os recursos abertos com closeFn e esvazia o Selector,
+// devolvendo o primeiro erro; compatível com server.OnStop via closure.
+func (s *Selector[T]) Close(closeFn func(id string, resource T) error) error {
+	s.mu.Lock()
+	entries := s.entries
+	s.entries = map[string]*entry[T]{}
+	s.mu.Unlock()
+
+	var first error
+	for id, e := range entries {
+		<-e.ready
+		if e.err != nil {
+			continue
+		}
+		if err := closeFn(id, e.resource); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,149 @@
+// Package tenant identifica o tenant de cada requisição e o propaga pelo
+// contexto: um middleware do Gin o resolve a partir de cabeçalho, subdomínio
+// ou claim do JWT, e o tenant passa a marcar os spans do Datadog (tag tenant)
+// e os logs obtidos com logx.Ctx. Selector escolhe recursos por tenant, como
+// a conexão com o banco de cada um.
+//
+//	server.N().
+//		Middlewares(
+//			auth.Middleware(verifier),
+//			tenant.Middleware(tenant.Options{
+//				Resolvers: []tenant.Resolver{tenant.FromClaim("tenant_id"), tenant.FromHeader("X-Tenant-Id")},
+//				Required:  true,
+//			}),
+//		)
+//
+//	id := tenant.ID(ctx)
+package tenant
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nathanribeiroo/module-dep-projects/auth"
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+	"github.com/nathanribeiroo/module-dep-projects/server"
+)
+
+// tagKey é o nome da tag nos spans e do campo nos logs.
+const tagKey = "tenant"
+
+// tenantKey é a chave do contexto onde o tenant é armazenado.
+type tenantKey struct{}
+
+// WithTenant devolve um contexto que carrega o tenant, marcando os spans e os
+// logs criados a partir dele. Use em consumidores e jobs, fora do middleware.
+func WithTenant(ctx context.Context, id string) context.Context {
+	ctx = dd.WithTag(ctx, tagKey, id)
+	ctx = logx.WithFields(ctx, tagKey, id)
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// FromContext devolve o tenant do contexto, se houver.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantKey{}).(string)
+	return id, ok && id != ""
+}
+
+// ID devolve o tenant do contexto, ou vazio se não houver.
+func ID(ctx context.Context) string {
+	id, _ := FromContext(ctx)
+	return id
+}
+
+// Resolver extrai o tenant da requisição, devolvendo vazio quando ela não o informa.
+type Resolver func(c *gin.Context) string
+
+// FromHeader resolve o tenant pelo cabeçalho informado (ex.: "X-Tenant-Id").
+func FromHeader(name string) Resolver {
+	return func(c *gin.Context) string {
+		return strings.TrimSpace(c.GetHeader(name))
+	}
+}
+
+// FromSubdomain resolve o tenant pelo subdomínio imediatamente anterior a
+// domain (ex.: "acme" em "acme.app.example.com" com domain "app.example.com").
+func FromSubdomain(domain string) Resolver {
+	suffix := "." + strings.ToLower(strings.TrimPrefix(domain, "."))
+	return func(c *gin.Context) string {
+		host := strings.ToLower(c.Request.Host)
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		sub, ok := strings.CutSuffix(host, suffix)
+		if !ok || sub == "" {
+			return ""
+		}
+		if i := strings.LastIndex(sub, "."); i >= 0 {
+			sub = sub[i+1:]
+		}
+		return sub
+	}
+}
+
+// FromClaim resolve o tenant pela claim do JWT validado por auth.Middleware,
+// que deve ser registrado antes.
+func FromClaim(claim string) Resolver {
+	return func(c *gin.Context) string {
+		claims, ok := auth.ClaimsFromContext(c.Request.Context())
+		if !ok {
+			return ""
+		}
+		return claims.String(claim)
+	}
+}
+
+// Options configura o middleware.
+type Options struct {
+	// Resolvers são consultados em ordem; o primeiro tenant não vazio vence.
+	Resolvers []Resolver
+	// Required responde 400 quando nenhum resolver identifica o tenant.
+	Required bool
+	// Validate verifica se o tenant existe e se a requisição pode acessá-lo;
+	// erros da errx são respondidos como tal e os demais, como 403.
+	Validate func(ctx context.Context, id string) error
+}
+
+// Middleware resolve o tenant da requisição e o associa ao contexto (veja
+// FromContext), aos spans e aos logs.
+func Middleware(opts Options) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var id string
+		for _, resolve := range opts.Resolvers {
+			if id = resolve(c); id != "" {
+				break
+			}
+		}
+
+		if id == "" {
+			if opts.Required {
+				server.Fail(c, errx.New("tenant not identified").WithCode(errx.BAD_REQUEST))
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if opts.Validate != nil {
+			if err := opts.Validate(c.Request.Context(), id); err != nil {
+				if !errx.IsAppError(err) {
+					logx.Ctx(c.Request.Context()).Warn("Tenant rejected", "tenant", id, "error", err)
+					err = errx.New("tenant not allowed").
+						WithCode(errx.FORBIDDEN).
+						WithDetails(map[string]interface{}{"tenant": id})
+				}
+				server.Fail(c, err)
+				return
+			}
+		}
+
+		c.Set(tagKey, id)
+		c.Request = c.Request.WithContext(WithTenant(c.Request.Context(), id))
+		c.Next()
+	}
+}
@@ -0,0 +1,101 @@
+package validate
+
+import "strings"
+
+// IsCPF informa se s é um CPF válido, com ou sem pontuação.
+func IsCPF(s string) bool {
+	digits := onlyDigits(s, ".-")
+	if len(digits) != 11 || repeated(digits) {
+		return false
+	}
+	return checkDigit(digits[:9], 10) == digits[9] && checkDigit(digits[:10], 11) == digits[10]
+}
+
+// IsCNPJ informa se s é um CNPJ válido, com ou sem pontuação.
+func IsCNPJ(s string) bool {
+	digits := onlyDigits(s, ".-/")
+	if len(digits) != 14 || repeated(digits) {
+		return false
+	}
+
+	weights := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	return cnpjDigit(digits[:12], weights[1:]) == digits[12] && cnpjDigit(digits[:13], weights) == digits[13]
+}
+
+// IsCEP informa se s é um CEP no formato 12345-678 ou 12345678.
+func IsCEP(s string) bool {
+	if len(s) == 9 && s[5] == '-' {
+		s = s[:5] + s[6:]
+	}
+	return len(s) == 8 && len(onlyDigits(s, "")) == 8
+}
+
+// IsPhone informa se s é um telefone brasileiro com DDD, fixo ou celular,
+// com ou sem o código do país (ex.: "+55 (11) 91234-5678", "1132345678").
+func IsPhone(s string) bool {
+	digits := onlyDigits(strings.TrimPrefix(strings.TrimSpace(s), "+"), " ()-")
+	if len(digits) == 12 || len(digits) == 13 {
+		if !strings.HasPrefix(digits, "55") {
+			return false
+		}
+		digits = digits[2:]
+	}
+
+	switch {
+	case len(digits) < 10 || len(digits) > 11:
+		return false
+	case digits[0] == '0' || digits[1] == '0':
+		return false
+	case len(digits) == 11:
+		return digits[2] == '9'
+	default:
+		return digits[2] >= '2' && digits[2] <= '5'
+	}
+}
+
+// onlyDigits remove os separadores permitidos e devolve "" se restar algo além de dígitos.
+func onlyDigits(s string, separators string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case strings.ContainsRune(separators, r):
+		default:
+			return ""
+		}
+	}
+	return b.String()
+}
+
+// repeated informa se todos os dígitos são iguais (ex.: "11111111111"), sequência inválida.
+func repeated(digits string) bool {
+	return strings.Count(digits, digits[:1]) == len(digits)
+}
+
+// checkDigit calcula o dígito verificador do CPF com pesos decrescentes a partir de weight.
+func checkDigit(digits string, weight int) byte {
+	sum := 0
+	for i := range digits {
+		sum += int(digits[i]-'0') * (weight - i)
+	}
+	return mod11(sum)
+}
+
+// cnpjDigit calcula o dígito verificador do CNPJ com os pesos informados.
+func cnpjDigit(digits string, weights []int) byte {
+	sum := 0
+	for i := range digits {
+		sum += int(digits[i]-'0') * weights[i]
+	}
+	return mod11(sum)
+}
+
+// mod11 converte a soma ponderada no dígito verificador.
+func mod11(sum int) byte {
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - rest)
+}
@@ -0,0 +1,237 @@
+// Package validate valida structs com o go-playground/validator, acrescentando
+// regras brasileiras (cpf, cnpj, cep, phone) e convertendo as violações em
+// errx.ValidationErrors, prontas para a resposta 400 padrão do server.
+//
+//	type CreateCustomer struct {
+//		Name     string `json:"name" validate:"required,max=100"`
+//		Document string `json:"document" validate:"required,cpf|cnpj"`
+//		ZipCode  string `json:"zip_code" validate:"omitempty,cep"`
+//	}
+//
+//	if err := validate.Struct(input); err != nil {
+//		server.Fail(c, err)
+//		return
+//	}
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+var (
+	// engine é o validador compartilhado, com as regras do pacote registradas.
+	engine = newEngine()
+
+	// messages guarda as mensagens por regra; "{param}" é substituído pelo parâmetro.
+	messages = struct {
+		sync.RWMutex
+		byRule map[string]string
+	}{byRule: map[string]string{
+		"required": "is required",
+		"email":    "must be a valid email",
+		"min":      "must have at least {param}",
+		"max":      "must have at most {param}",
+		"len":      "must have length {param}",
+		"gt":       "must be greater than {param}",
+		"gte":      "must be greater than or equal to {param}",
+		"lt":       "must be less than {param}",
+		"lte":      "must be less than or equal to {param}",
+		"oneof":    "must be one of [{param}]",
+		"uuid":     "must be a valid UUID",
+		"url":      "must be a valid URL",
+		"cpf":      "must be a valid CPF",
+		"cnpj":     "must be a valid CNPJ",
+		"cep":      "must be a valid CEP",
+		"phone":    "must be a valid Brazilian phone number",
+	}}
+)
+
+// newEngine cria o validador usando os nomes do JSON nos campos e registra as regras brasileiras.
+func newEngine() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return f.Name
+		}
+		return name
+	})
+
+	for rule, check := range map[string]func(string) bool{
+		"cpf":   IsCPF,
+		"cnpj":  IsCNPJ,
+		"cep":   IsCEP,
+		"phone": IsPhone,
+	} {
+		check := check
+		_ = v.RegisterValidation(rule, func(fl validator.FieldLevel) bool {
+			return check(fl.Field().String())
+		})
+	}
+	return v
+}
+
+// Engine devolve o validador subjacente, para configurações não cobertas pelo pacote.
+func Engine() *validator.Validate {
+	return engine
+}
+
+// Struct valida a struct pelas tags validate, devolvendo uma AppError
+// BAD_REQUEST com as violações (veja errx.NewValidation).
+func Struct(v interface{}) error {
+	return convert(engine.Struct(v))
+}
+
+// Var valida um valor isolado contra as regras informadas (ex.: "required,cpf").
+func Var(field string, value interface{}, rules string) error {
+	err := convert(engine.Var(value, rules))
+	if violations, ok := errx.GetValidationErrors(err); ok {
+		for i := range violations {
+			violations[i].Field = field
+		}
+		return errx.NewValidation(violations)
+	}
+	return err
+}
+
+// RegisterRule registra uma regra personalizada e a sua mensagem, válida
+// tanto nas tags validate quanto nas tags binding do Gin (veja UseWithGin).
+func RegisterRule(rule string, check func(fl validator.FieldLevel) bool, message string) error {
+	custom.Lock()
+	defer custom.Unlock()
+
+	if err := engine.RegisterValidation(rule, check); err != nil {
+		return err
+	}
+	if binder != nil {
+		if err := binder.RegisterValidation(rule, check); err != nil {
+			return err
+		}
+	}
+	custom.rules[rule] = check
+	RegisterMessage(rule, message)
+	return nil
+}
+
+// RegisterMessage define a mensagem de uma regra; "{param}" é substituído
+// pelo parâmetro da regra (ex.: "10" em "max=10").
+func RegisterMessage(rule string, message string) {
+	messages.Lock()
+	defer messages.Unlock()
+	messages.byRule[rule] = message
+}
+
+// UseWithGin passa a usar este validador no binding do Gin (ShouldBind e
+// similares), de modo que as tags binding também aceitem as regras do pacote.
+func UseWithGin() {
+	binding.Validator = ginValidator{}
+}
+
+// ginValidator adapta o pacote à interface binding.StructValidator.
+type ginValidator struct{}
+
+// ValidateStruct implementa binding.StructValidator.
+func (ginValidator) ValidateStruct(obj interface{}) error {
+	if obj == nil {
+		return nil
+	}
+	value := reflect.ValueOf(obj)
+	for value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	return convert(bindingEngine().Struct(obj))
+}
+
+// Engine implementa binding.StructValidator.
+func (ginValidator) Engine() interface{} {
+	return bindingEngine()
+}
+
+var (
+	bindingOnce sync.Once
+	// binder é protegido por custom enquanto as regras personalizadas são copiadas.
+	binder *validator.Validate
+
+	// custom guarda as regras de RegisterRule, repetidas no validador do binding.
+	custom = struct {
+		sync.Mutex
+		rules map[string]validator.Func
+	}{rules: map[string]validator.Func{}}
+)
+
+// bindingEngine é uma cópia do validador que lê as tags binding usadas pelo
+// Gin, com as regras personalizadas já registradas.
+func bindingEngine() *validator.Validate {
+	bindingOnce.Do(func() {
+		custom.Lock()
+		defer custom.Unlock()
+
+		v := newEngine()
+		v.SetTagName("binding")
+		for rule, check := range custom.rules {
+			_ = v.RegisterValidation(rule, check)
+		}
+		binder = v
+	})
+	return binder
+}
+
+// convert transforma os erros do validator em errx.ValidationErrors.
+func convert(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return errx.New("invalid input").WithCode(errx.BAD_REQUEST).WithError(err)
+	}
+
+	violations := make(errx.ValidationErrors, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		violations = append(violations, errx.FieldError{
+			Field:   fieldPath(fe.Namespace()),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: message(fe.Tag(), fe.Param()),
+		})
+	}
+	return errx.NewValidation(violations)
+}
+
+// fieldPath remove o nome da struct raiz do caminho (ex.: "Input.address.zip" → "address.zip").
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+// message monta a mensagem da regra violada.
+func message(rule string, param string) string {
+	messages.RLock()
+	msg, ok := messages.byRule[rule]
+	messages.RUnlock()
+
+	if !ok {
+		msg = "failed on rule " + rule
+		if param != "" {
+			msg += "=" + param
+		}
+		return msg
+	}
+	return strings.ReplaceAll(msg, "{param}", param)
+}
@@ -0,0 +1,117 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/nathanribeiroo/module-dep-projects/errx"
+)
+
+// Go executa as tarefas no pool e aguarda todas, devolvendo o primeiro erro.
+// Após o primeiro erro, o contexto das tarefas restantes é cancelado. Pode ser
+// chamado de dentro de uma tarefa do mesmo pool sem risco de bloqueio mútuo.
+func (p *Pool) Go(ctx context.Context, tasks ...Task) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for _, task := range tasks {
+		wg.Add(1)
+		done := func(err error) {
+			if err != nil {
+				fail(err)
+			}
+			wg.Done()
+		}
+		if err := p.submit(ctx, item{ctx: ctx, task: task, done: done}, true); err != nil {
+			wg.Done()
+			fail(err)
+			break
+		}
+	}
+
+	p.wait(ctx, &wg)
+	return firstErr
+}
+
+// wait aguarda wg. Dentro de uma tarefa do próprio pool, a goroutine executa
+// os itens da fila enquanto espera, para que as tarefas submetidas por ela
+// não fiquem presas atrás da goroutine que as aguarda.
+func (p *Pool) wait(ctx context.Context, wg *sync.WaitGroup) {
+	if owner, _ := ctx.Value(poolCtxKey{}).(*Pool); owner != p {
+		wg.Wait()
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	queue := p.queue
+	for {
+		select {
+		case <-done:
+			return
+		case it, ok := <-queue:
+			if !ok {
+				queue = nil
+				continue
+			}
+			p.gauge()
+			p.finish(it, p.run(it))
+		}
+	}
+}
+
+// Map aplica fn a cada item usando o pool e devolve os resultados na ordem da
+// entrada. Como Go, interrompe as demais chamadas no primeiro erro.
+func Map[T, R any](ctx context.Context, p *Pool, items []T, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	tasks := make([]Task, len(items))
+	for i, it := range items {
+		i, it := i, it
+		tasks[i] = func(ctx context.Context) error {
+			result, err := fn(ctx, it)
+			if err != nil {
+				return err
+			}
+			results[i] = result
+			return nil
+		}
+	}
+
+	if err := p.Go(ctx, tasks...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// call executa a tarefa convertendo panics em erros da errx.
+func call(ctx context.Context, task Task) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errx.New("worker task panicked").
+				WithCode(errx.INTERNAL).
+				WithDetails(map[string]interface{}{
+					"panic": fmt.Sprint(r),
+					"stack": string(debug.Stack()),
+				})
+		}
+	}()
+	return task(ctx)
+}
@@ -0,0 +1,264 @@
+// Package workers oferece um pool de goroutines de tamanho fixo com fila
+// limitada, novas tentativas por tarefa, métricas e drenagem no encerramento,
+// além de utilitários para distribuir trabalho dentro de handlers e consumidores.
+//
+//	pool := workers.New(workers.Options{Name: "thumbnails", Size: 8, QueueSize: 100})
+//	server.N().OnStop(pool.Stop)
+//
+//	err := pool.Submit(ctx, func(ctx context.Context) error {
+//		return resize(ctx, image)
+//	})
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nathanribeiroo/module-dep-projects/dd"
+	"github.com/nathanribeiroo/module-dep-projects/logx"
+)
+
+// ErrClosed indica que o pool foi encerrado e não aceita novas tarefas.
+var ErrClosed = errors.New("workers: pool closed")
+
+// ErrQueueFull indica que a fila do pool está cheia (veja TrySubmit).
+var ErrQueueFull = errors.New("workers: queue full")
+
+// Task é uma unidade de trabalho executada pelo pool.
+type Task func(ctx context.Context) error
+
+// Options configura o pool.
+type Options struct {
+	// Name identifica o pool nas métricas e nos logs (tag pool:<name>).
+	Name string
+	// Size é o número de goroutines (padrão: 4).
+	Size int
+	// QueueSize é a capacidade da fila de tarefas pendentes (padrão: Size).
+	QueueSize int
+	// MaxRetries é o número de novas tentativas de uma tarefa que falhou (padrão: 0).
+	MaxRetries int
+	// Backoff é a espera antes da primeira nova tentativa, dobrada a cada
+	// tentativa seguinte (padrão: 100ms).
+	Backoff time.Duration
+	// OnError é chamado quando uma tarefa falha após esgotar as tentativas.
+	// Por padrão, o erro é registrado no log.
+	OnError func(ctx context.Context, err error)
+}
+
+// item é uma tarefa enfileirada com o contexto de quem a submeteu.
+type item struct {
+	ctx  context.Context
+	task Task
+	done func(error)
+}
+
+// Pool executa tarefas em um número fixo de goroutines.
+type Pool struct {
+	opts  Options
+	tags  []string
+	queue chan item
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+	// stopping é fechado por Stop para liberar quem aguarda espaço na fila.
+	stopping chan struct{}
+	// senders conta as submissões em andamento; a fila só é fechada quando
+	// todas terminam, sem que submit precise aguardar segurando mu.
+	senders   sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// poolCtxKey marca o contexto das tarefas com o pool que as executa.
+type poolCtxKey struct{}
+
+// New cria o pool e inicia as suas goroutines.
+func New(opts Options) *Pool {
+	if opts.Size <= 0 {
+		opts.Size = 4
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = opts.Size
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = 100 * time.Millisecond
+	}
+	if opts.Name == "" {
+		opts.Name = "default"
+	}
+
+	p := &Pool{
+		opts:     opts,
+		tags:     []string{"pool:" + opts.Name},
+		queue:    make(chan item, opts.QueueSize),
+		stopping: make(chan struct{}),
+	}
+
+	p.wg.Add(opts.Size)
+	for i := 0; i < opts.Size; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Submit enfileira a tarefa, aguardando espaço na fila enquanto ctx não for
+// cancelado. A tarefa recebe ctx, preservando trace e valores da requisição;
+// cancelar ctx também interrompe a tarefa, se ela o respeitar.
+func (p *Pool) Submit(ctx context.Context, task Task) error {
+	return p.submit(ctx, item{ctx: ctx, task: task}, true)
+}
+
+// TrySubmit enfileira a tarefa sem aguardar, devolvendo ErrQueueFull se não houver espaço.
+func (p *Pool) TrySubmit(ctx context.Context, task Task) error {
+	return p.submit(ctx, item{ctx: ctx, task: task}, false)
+}
+
+// submit enfileira o item, respeitando o encerramento do pool. Quando a fila
+// está cheia e quem submete é uma tarefa do próprio pool, o item roda na
+// goroutine dela: aguardar espaço poderia bloquear todas as goroutines do pool.
+func (p *Pool) submit(ctx context.Context, it item, wait bool) error {
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return ErrClosed
+	}
+	p.senders.Add(1)
+	p.mu.RUnlock()
+
+	err := p.enqueue(ctx, it, wait)
+	p.senders.Done()
+	if err != errInline {
+		return err
+	}
+
+	dd.Metrics().Incr("workers.inline", p.tags...)
+	p.finish(it, p.run(it))
+	return nil
+}
+
+// errInline indica que o item deve rodar na goroutine de quem o submeteu.
+var errInline = errors.New("workers: run inline")
+
+// enqueue coloca o item na fila, aguardando espaço quando wait for verdadeiro.
+func (p *Pool) enqueue(ctx context.Context, it item, wait bool) error {
+	select {
+	case p.queue <- it:
+		p.gauge()
+		return nil
+	default:
+	}
+
+	if !wait {
+		dd.Metrics().Incr("workers.rejected", p.tags...)
+		return ErrQueueFull
+	}
+	if owner, _ := ctx.Value(poolCtxKey{}).(*Pool); owner == p {
+		return errInline
+	}
+
+	select {
+	case p.queue <- it:
+		p.gauge()
+		return nil
+	case <-p.stopping:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Len devolve o número de tarefas aguardando na fila.
+func (p *Pool) Len() int {
+	return len(p.queue)
+}
+
+// Stop deixa de aceitar tarefas e aguarda a execução das que já estão na
+// fila; compatível com server.OnStop.
+func (p *Pool) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.stopping)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.senders.Wait()
+		p.closeOnce.Do(func() { close(p.queue) })
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work consome a fila até o encerramento do pool.
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for it := range p.queue {
+		p.gauge()
+		p.finish(it, p.run(it))
+	}
+}
+
+// finish entrega o resultado da tarefa a quem aguarda ou, na falta, ao OnError.
+func (p *Pool) finish(it item, err error) {
+	if it.done != nil {
+		it.done(err)
+		return
+	}
+	if err != nil {
+		if p.opts.OnError != nil {
+			p.opts.OnError(it.ctx, err)
+			return
+		}
+		logx.Ctx(it.ctx).Error("Worker task failed", "pool", p.opts.Name, "error", err)
+	}
+}
+
+// run executa a tarefa com as novas tentativas configuradas.
+func (p *Pool) run(it item) error {
+	ctx := context.WithValue(it.ctx, poolCtxKey{}, p)
+	backoff := p.opts.Backoff
+	for attempt := 0; ; attempt++ {
+		if err := it.ctx.Err(); err != nil {
+			dd.Metrics().Incr("workers.canceled", p.tags...)
+			return err
+		}
+
+		start := time.Now()
+		err := call(ctx, it.task)
+		dd.Metrics().Timing("workers.task.duration", time.Since(start), p.tags...)
+		if err == nil {
+			dd.Metrics().Incr("workers.task.success", p.tags...)
+			return nil
+		}
+
+		dd.Metrics().Incr("workers.task.error", p.tags...)
+		if attempt >= p.opts.MaxRetries {
+			return err
+		}
+
+		dd.Metrics().Incr("workers.task.retry", p.tags...)
+		select {
+		case <-it.ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// gauge publica o tamanho atual da fila.
+func (p *Pool) gauge() {
+	dd.Metrics().Gauge("workers.queue.size", float64(len(p.queue)), p.tags...)
+}
@@ -0,0 +1,147 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStopReleasesBlockedSubmit(t *testing.T) {
+	p := New(Options{Size: 1, QueueSize: 1})
+
+	release := make(chan struct{})
+	running := make(chan struct{})
+	if err := p.Submit(context.Background(), func(context.Context) error {
+		close(running)
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	<-running
+	if err := p.Submit(context.Background(), func(context.Context) error { return nil }); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	blocked := make(chan error, 1)
+	go func() {
+		blocked <- p.Submit(context.Background(), func(context.Context) error { return nil })
+	}()
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- p.Stop(context.Background())
+	}()
+
+	select {
+	case err := <-blocked:
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("blocked Submit error = %v, want ErrClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Submit still blocked after Stop")
+	}
+
+	close(release)
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatalf("Stop: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Stop did not return")
+	}
+
+	if err := p.Submit(context.Background(), func(context.Context) error { return nil }); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Submit after Stop error = %v, want ErrClosed", err)
+	}
+}
+
+func TestNestedGoDoesNotDeadlock(t *testing.T) {
+	tests := []struct {
+		name  string
+		size  int
+		queue int
+	}{
+		{name: "single worker", size: 1, queue: 1},
+		{name: "small queue", size: 2, queue: 1},
+		{name: "default", size: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(Options{Size: tt.size, QueueSize: tt.queue})
+			defer p.Stop(context.Background())
+
+			var ran atomic.Int32
+			leaf := func(context.Context) error {
+				ran.Add(1)
+				return nil
+			}
+			parent := func(ctx context.Context) error {
+				return p.Go(ctx, leaf, leaf, leaf)
+			}
+
+			done := make(chan error, 1)
+			go func() {
+				done <- p.Go(context.Background(), parent, parent, parent)
+			}()
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Fatalf("Go: %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("nested Go deadlocked")
+			}
+			if got := ran.Load(); got != 9 {
+				t.Fatalf("ran %d tasks, want 9", got)
+			}
+		})
+	}
+}
+
+func TestMap(t *testing.T) {
+	p := New(Options{Size: 2})
+	defer p.Stop(context.Background())
+
+	failure := errors.New("odd item")
+	tests := []struct {
+		name    string
+		items   []int
+		want    []int
+		wantErr error
+	}{
+		{name: "keeps order", items: []int{1, 2, 3, 4}, want: []int{2, 4, 6, 8}},
+		{name: "empty", items: []int{}, want: []int{}},
+		{name: "first error", items: []int{2, 3, 4}, wantErr: failure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Map(context.Background(), p, tt.items, func(_ context.Context, n int) (int, error) {
+				if tt.wantErr != nil && n%2 == 1 {
+					return 0, failure
+				}
+				return n * 2, nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Map error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Map = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Map = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}